@@ -2,70 +2,260 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/samsvi/mdm-webapi/api"
+	"github.com/samsvi/mdm-webapi/internal/codes"
+	"github.com/samsvi/mdm-webapi/internal/config"
 	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"github.com/samsvi/mdm-webapi/internal/events/kafka"
+	"github.com/samsvi/mdm-webapi/internal/events/nats"
+	"github.com/samsvi/mdm-webapi/internal/graph"
+	"github.com/samsvi/mdm-webapi/internal/grpc_api"
+	"github.com/samsvi/mdm-webapi/internal/health"
+	"github.com/samsvi/mdm-webapi/internal/logging"
 	"github.com/samsvi/mdm-webapi/internal/mdm"
+	"github.com/samsvi/mdm-webapi/internal/metrics"
+	"github.com/samsvi/mdm-webapi/internal/migrations"
+	"github.com/samsvi/mdm-webapi/internal/telemetry"
+	"github.com/samsvi/mdm-webapi/internal/webhooks"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"google.golang.org/grpc"
 )
 
 func main() {
-    log.Printf("Server started")
-    port := os.Getenv("MDM_API_PORT")
-    if port == "" {
-        port = "8080"
+    os.Exit(run())
+}
+
+// run starts the servers and returns exit code once they are shut down, so
+// the deferred calls release the resources before the process exits
+func run() (exitCode int) {
+    flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+    printConfig := flags.Bool("print-config", false, "print the effective configuration and exit")
+    cfg, err := config.Load(flags, os.Args[1:])
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        return 1
+    }
+    if *printConfig {
+        cfg.Print(os.Stdout)
+        return 0
+    }
+
+    logging.Setup(cfg.Log.Level, cfg.LogFormat())
+    slog.Info("Server started", "config", cfg)
+    if cfg.Codes.Icd10File != "" {
+        if err := codes.LoadIcd10(cfg.Codes.Icd10File); err != nil {
+            slog.Error("Failed to load the ICD-10 code list", "error", err)
+            return 1
+        }
+        slog.Info("ICD-10 code list loaded", "codes", codes.Icd10.Len())
     }
-    environment := os.Getenv("MDM_API_ENVIRONMENT")
-    if !strings.EqualFold(environment, "production") { // case insensitive comparison
+    if !cfg.IsProduction() {
         gin.SetMode(gin.DebugMode)
     }
     
+    shutdownTracing, err := telemetry.SetupTracing(context.Background(), cfg.Tracing.Exporter)
+    if err != nil {
+        slog.Error("Failed to setup tracing", "error", err)
+        return 1
+    }
+    defer shutdownTracing(context.Background())
+
     engine := gin.New()
-    engine.Use(gin.Recovery())
+    engine.Use(logging.Recovery())
+    engine.Use(logging.RequestIdMiddleware())
+    engine.Use(otelgin.Middleware(telemetry.ServiceName, otelgin.WithFilter(func(req *http.Request) bool {
+        // probes and scrapes would drown the traces of actual requests
+        return !strings.HasPrefix(req.URL.Path, "/health/") && req.URL.Path != "/metrics"
+    })))
+    engine.Use(logging.AccessLogMiddleware("/health/live", "/health/ready", "/metrics"))
     
     corsMiddleware := cors.New(cors.Config{
         AllowOrigins:     []string{"*"},
         AllowMethods:     []string{"GET", "PUT", "POST", "DELETE", "PATCH"},
-        AllowHeaders:     []string{"Origin", "Authorization", "Content-Type"},
-        ExposeHeaders:    []string{""},
+        AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "X-Request-ID"},
+        ExposeHeaders:    []string{"X-Request-ID", "X-Total-Count"},
         AllowCredentials: false,
         MaxAge: 12 * time.Hour,
     })
     engine.Use(corsMiddleware)
+    engine.Use(metrics.Middleware())
 
-    // Setup database services for individual documents
-    patientsDbService := db_service.NewMongoService[mdm.Patient](db_service.MongoServiceConfig{
-        Collection: "patients",
-    })
-    defer patientsDbService.Disconnect(context.Background())
+    // Setup database services for individual documents, sharing one client
+    // and one circuit breaker
+    mongoClient := cfg.MongoDB.Client()
+    defer mongoClient.Disconnect(context.Background())
+    resilience := cfg.MongoDB.Resilience()
+
+    // Apply pending schema migrations, replicas starting at once wait for the first one
+    if cfg.MongoDB.Migrate {
+        if err := migrate(mongoClient, cfg.MongoDB.MigrateTimeout); err != nil {
+            slog.Error("Failed to migrate the database", "error", err)
+            return 1
+        }
+    }
+
+    patientsDbService := collectionService[mdm.Patient](mongoClient, resilience, "patients")
+    medicalRecordsDbService := collectionService[mdm.MedicalRecord](mongoClient, resilience, "medical-records")
+    vitalsDbService := metrics.WrapTimeSeriesService("vitals", db_service.NewResilientTimeSeriesService(db_service.NewTimeSeriesService[mdm.VitalSign](mongoClient, "vitals", mdm.VitalSignSeries), resilience))
+
+    // Readiness checks of the dependencies
+    healthChecker := health.NewChecker(health.Config{})
+    healthChecker.Add("mongodb", mongoClient.Ping)
+
+    webhookSubscriptionsDbService := collectionService[mdm.WebhookSubscription](mongoClient, resilience, "webhook-subscriptions")
+
+    // deliveries are claimed by the dispatcher by a lease held in their time of the next attempt
+    webhookDeliveriesDbService := metrics.WrapLeaseService("webhook-deliveries", db_service.NewResilientLeaseService(db_service.NewLeaseService[mdm.WebhookDelivery](mongoClient, "webhook-deliveries", "nextattemptat"), resilience))
+
+    outboxDbService := metrics.WrapOutboxService(cfg.MongoDB.OutboxCollection, db_service.NewResilientOutboxService(db_service.NewOutboxService(mongoClient, cfg.MongoDB.OutboxCollection), resilience))
+
+    // Background workers run until the shutdown, when they are cancelled and awaited
+    workersCtx, stopWorkers := context.WithCancel(context.Background())
+    defer stopWorkers()
+    var workers sync.WaitGroup
+    runWorker := func(run func(ctx context.Context)) {
+        workers.Add(1)
+        go func() {
+            defer workers.Done()
+            run(workersCtx)
+        }()
+    }
 
-    medicalRecordsDbService := db_service.NewMongoService[mdm.MedicalRecord](db_service.MongoServiceConfig{
-        Collection: "medical-records", 
+    // Deliver events to webhook subscribers in the background
+    webhookDispatcher := webhooks.NewDispatcher(
+        webhookSubscriptionsDbService,
+        webhookDeliveriesDbService,
+        outboxDbService,
+        webhooks.DispatcherConfig{},
+    )
+    runWorker(webhookDispatcher.Run)
+
+    // Relay events stored in the outbox to in-process subscribers and to the configured broker
+    eventBus := events.NewBus()
+    eventBus.Subscribe(webhookDispatcher)
+
+    var eventBroker events.Broker = eventBus
+    switch strings.ToLower(cfg.Events.Broker) {
+    case "nats":
+        natsBroker, err := nats.NewBroker(context.Background(), nats.Config{
+            Url:           cfg.Events.Nats.Url,
+            SubjectPrefix: cfg.Events.Nats.SubjectPrefix,
+            Stream:        cfg.Events.Nats.Stream,
+        })
+        if err != nil {
+            slog.Error("Failed to connect to NATS", "error", err)
+            return 1
+        }
+        eventBroker = events.Fanout{eventBus, natsBroker}
+        if pinger, ok := natsBroker.(health.Pinger); ok {
+            healthChecker.Add("nats", pinger.Ping)
+        }
+    case "kafka":
+        kafkaBroker := kafka.NewBroker(kafka.Config{
+            Brokers: cfg.Events.Kafka.Brokers,
+            Topic:   cfg.Events.Kafka.Topic,
+        })
+        eventBroker = events.Fanout{eventBus, kafkaBroker}
+        if pinger, ok := kafkaBroker.(health.Pinger); ok {
+            healthChecker.Add("kafka", pinger.Ping)
+        }
+    }
+    defer eventBroker.Close()
+
+    // Create or update the declared indexes of the collections
+    if cfg.MongoDB.Migrate {
+        err := errors.Join(
+            ensureIndexes(patientsDbService, "patients", mdm.PatientIndexes),
+            ensureIndexes(medicalRecordsDbService, "medical-records", mdm.MedicalRecordIndexes),
+            ensureIndexes(webhookSubscriptionsDbService, "webhook-subscriptions", mdm.WebhookSubscriptionIndexes),
+            ensureIndexes(webhookDeliveriesDbService, "webhook-deliveries", mdm.WebhookDeliveryIndexes),
+            ensureIndexes(outboxDbService, cfg.MongoDB.OutboxCollection, db_service.OutboxIndexes(cfg.MongoDB.OutboxRetention)),
+        )
+        if err != nil {
+            slog.Error("Failed to ensure unique indexes", "error", err)
+            return 1
+        }
+    }
+
+    outboxRelay := events.NewRelay(outboxDbService, eventBroker, events.RelayConfig{})
+    runWorker(outboxRelay.Run)
+
+    // Stream events to live dashboards
+    eventBroadcaster := events.NewBroadcaster()
+    runWorker(func(ctx context.Context) {
+        events.FeedBroadcaster(ctx, outboxDbService, eventBus, eventBroadcaster)
     })
-    defer medicalRecordsDbService.Disconnect(context.Background())
+
+    // NEWS2 score reporting deterioration of the patients
+    earlyWarning := mdm.EarlyWarningConfig{
+        Threshold: cfg.EarlyWarning.Threshold,
+        Action:    strings.ToLower(cfg.EarlyWarning.Action),
+        MaxAge:    cfg.EarlyWarning.MaxAge,
+    }
 
     // Setup context middleware to set appropriate db_service
     engine.Use(func(ctx *gin.Context) {
         path := ctx.Request.URL.Path
-        if strings.Contains(path, "/medical-records") {
+        if strings.Contains(path, "/medical-records") || strings.HasSuffix(path, "/medications") {
             ctx.Set("db_service", medicalRecordsDbService)
+        } else if strings.Contains(path, "/vitals") || strings.Contains(path, "/early-warning") {
+            ctx.Set("db_service", vitalsDbService)
         } else if strings.Contains(path, "/patients") {
             ctx.Set("db_service", patientsDbService)
+        } else if strings.Contains(path, "/deliveries") {
+            ctx.Set("db_service", webhookDeliveriesDbService)
+        } else if strings.Contains(path, "/webhooks") {
+            ctx.Set("db_service", webhookSubscriptionsDbService)
+        } else if strings.Contains(path, "/interactions") {
+            ctx.Set("db_service", medicalRecordsDbService)
         }
+        ctx.Set("patients_db_service", patientsDbService)
+        ctx.Set("vitals_db_service", vitalsDbService)
+        ctx.Set("transaction_runner", mongoClient)
+        ctx.Set("event_broadcaster", eventBroadcaster)
+        ctx.Set("early_warning_config", earlyWarning)
         ctx.Next()
     })
 
     // Create API implementations
     patientsAPI := mdm.NewPatientsAPI()
     medicalRecordsAPI := mdm.NewMedicalRecordsAPI()
+    webhooksAPI := mdm.NewWebhooksAPI()
+    eventsAPI := mdm.NewEventsAPI()
+    codesAPI := mdm.NewCodesAPI()
+    interactionsAPI := mdm.NewInteractionsAPI()
+    medicationsAPI := mdm.NewMedicationsAPI()
+    vitalsAPI := mdm.NewVitalsAPI()
+    earlyWarningAPI := mdm.NewEarlyWarningAPI()
 
     // Request routings
     engine.GET("/openapi", api.HandleOpenApi)
+
+    // Prometheus metrics
+    engine.GET("/metrics", metrics.Handler())
+    runWorker(metrics.NewPatientsGauge(patientsDbService, 0).Run)
+
+    // Health probes
+    engine.GET("/health/live", healthChecker.HandleLive)
+    engine.GET("/health/ready", healthChecker.HandleReady)
     
     // Patients routes
     engine.GET("/api/patients", patientsAPI.GetAllPatients)
@@ -80,5 +270,182 @@ func main() {
     engine.PUT("/api/patients/:patientId/medical-records/:recordId", medicalRecordsAPI.UpdateMedicalRecord)
     engine.DELETE("/api/patients/:patientId/medical-records/:recordId", medicalRecordsAPI.DeleteMedicalRecord)
 
-    engine.Run(":" + port)
-}
\ No newline at end of file
+    // Medications routes
+    engine.GET("/api/patients/:patientId/medications", medicationsAPI.GetPatientMedications)
+    engine.POST("/api/patients/:patientId/medical-records/:recordId/reconciliation", medicationsAPI.ReconcileMedications)
+
+    // Vital signs routes
+    engine.GET("/api/patients/:patientId/vitals", vitalsAPI.GetPatientVitals)
+    engine.POST("/api/patients/:patientId/vitals", vitalsAPI.RecordPatientVitals)
+    engine.GET("/api/patients/:patientId/vitals/summary", vitalsAPI.GetPatientVitalsSummary)
+
+    // Early warning routes
+    engine.GET("/api/patients/:patientId/early-warning", earlyWarningAPI.GetPatientEarlyWarningScore)
+    engine.GET("/api/early-warning", earlyWarningAPI.GetEarlyWarningScores)
+
+    // Webhook subscriptions routes
+    engine.GET("/api/webhooks", webhooksAPI.GetWebhookSubscriptions)
+    engine.POST("/api/webhooks", webhooksAPI.CreateWebhookSubscription)
+    engine.GET("/api/webhooks/:subscriptionId", webhooksAPI.GetWebhookSubscription)
+    engine.PUT("/api/webhooks/:subscriptionId", webhooksAPI.UpdateWebhookSubscription)
+    engine.DELETE("/api/webhooks/:subscriptionId", webhooksAPI.DeleteWebhookSubscription)
+    engine.GET("/api/webhooks/:subscriptionId/deliveries", webhooksAPI.GetWebhookDeliveries)
+
+    // Code lists routes
+    engine.GET("/api/codes/icd10", codesAPI.SearchIcd10Codes)
+    engine.GET("/api/codes/atc", codesAPI.SearchAtcCodes)
+
+    // Drug interactions routes
+    engine.POST("/api/interactions\\:check", interactionsAPI.CheckInteractions)
+
+    // Live event stream routes
+    engine.GET("/api/events", eventsAPI.StreamEvents)
+    engine.GET("/api/events/ws", eventsAPI.StreamEventsWebSocket)
+
+    // GraphQL endpoint over patients and medical records
+    graphqlHandler, err := graph.NewHandler(patientsDbService, medicalRecordsDbService)
+    if err != nil {
+        slog.Error("Failed to create GraphQL schema", "error", err)
+        return 1
+    }
+    engine.GET("/graphql", graphqlHandler)
+    engine.POST("/graphql", graphqlHandler)
+
+    // gRPC API on a separate port
+    grpcListener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.Server.GrpcPort))
+    if err != nil {
+        slog.Error("Failed to listen on gRPC port", "port", cfg.Server.GrpcPort, "error", err)
+        return 1
+    }
+    grpcServer := grpc_api.NewServer(patientsDbService, medicalRecordsDbService)
+    go func() {
+        if err := grpcServer.Serve(grpcListener); err != nil {
+            slog.Error("gRPC server stopped", "error", err)
+        }
+    }()
+
+    server := &http.Server{
+        Addr:              ":" + strconv.Itoa(cfg.Server.Port),
+        Handler:           engine.Handler(),
+        ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+    }
+    serverErrors := make(chan error, 1)
+    go func() {
+        slog.Info("HTTP server listening", "port", cfg.Server.Port)
+        serverErrors <- server.ListenAndServe()
+    }()
+
+    // On termination report not ready first and give load balancers time to
+    // notice, then drain requests in progress within the shutdown timeout
+    shutdownDelay := cfg.Server.ShutdownDelay
+    shutdownTimeout := cfg.Server.ShutdownTimeout
+
+    signals := make(chan os.Signal, 1)
+    signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+    select {
+    case err := <-serverErrors:
+        slog.Error("HTTP server stopped", "error", err)
+        exitCode = 1
+    case received := <-signals:
+        slog.Info("Shutting down", "signal", received.String(), "delay", shutdownDelay, "timeout", shutdownTimeout)
+        healthChecker.Shutdown()
+        time.Sleep(shutdownDelay)
+    }
+    // a second signal terminates the process immediately
+    signal.Stop(signals)
+
+    shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+    defer cancelShutdown()
+
+    // event streams never end on their own
+    eventBroadcaster.Close()
+    if err := shutdownServers(shutdownCtx, server, grpcServer); err != nil {
+        slog.Error("Requests were not drained before the shutdown timeout", "error", err)
+    }
+
+    // workers stop only after the requests are drained; events of the last
+    // writes are kept in the outbox and relayed by another replica or on restart
+    stopWorkers()
+    if err := wait(shutdownCtx, &workers); err != nil {
+        slog.Error("Background workers did not stop before the shutdown timeout", "error", err)
+    }
+
+    // deferred calls close the broker and database connections and flush traces
+    slog.Info("Server stopped")
+    return exitCode
+}
+
+// shutdownServers stops accepting connections and waits for requests in
+// progress. Connections still active when the context expires are closed.
+func shutdownServers(ctx context.Context, server *http.Server, grpcServer *grpc.Server) error {
+    grpcStopped := make(chan struct{})
+    go func() {
+        grpcServer.GracefulStop()
+        close(grpcStopped)
+    }()
+
+    err := server.Shutdown(ctx)
+    if err != nil {
+        server.Close()
+    }
+
+    select {
+    case <-grpcStopped:
+    case <-ctx.Done():
+        grpcServer.Stop()
+        err = ctx.Err()
+    }
+    return err
+}
+
+// wait waits for the group unless the context expires first
+func wait(ctx context.Context, group *sync.WaitGroup) error {
+    done := make(chan struct{})
+    go func() {
+        group.Wait()
+        close(done)
+    }()
+
+    select {
+    case <-done:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+// collectionService returns service of the collection retrying transient
+// failures and recording metrics of the operations
+func collectionService[DocType interface{}](client *db_service.MongoClient, resilience db_service.ResilienceConfig, collection string) db_service.DbService[DocType] {
+    return metrics.WrapDbService(collection, db_service.NewResilientService(db_service.NewCollectionService[DocType](client, collection), resilience))
+}
+
+func migrate(client *db_service.MongoClient, timeout time.Duration) error {
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+    migrator, err := migrations.NewMigrator(client, migrations.Config{}, migrations.All...)
+    if err != nil {
+        return err
+    }
+    applied, err := migrator.Up(ctx, 0)
+    if len(applied) > 0 {
+        slog.Info("Database migrated", "applied", len(applied), "version", applied[len(applied)-1].Version)
+    }
+    return err
+}
+
+// ensureIndexes returns the failure when the indexes include unique ones,
+// e.g. when the collection has duplicate values, as duplicate documents
+// could be stored without them. Failures of other indexes are only logged,
+// the service works without them, only slower.
+func ensureIndexes[DocType interface{}](db db_service.DbService[DocType], collection string, indexes []db_service.Index) error {
+    err := db.EnsureIndexes(context.Background(), indexes...)
+    if err == nil {
+        return nil
+    }
+    if slices.ContainsFunc(indexes, func(index db_service.Index) bool { return index.Unique }) {
+        return fmt.Errorf("%s: %w", collection, err)
+    }
+    slog.Error("Failed to ensure indexes", "collection", collection, "error", err)
+    return nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// Kinds of integrity issues
+const (
+	issueOrphanedRecord           = "orphaned-medical-record"
+	issueDuplicateInsuranceNumber = "duplicate-insurance-number"
+	issueDuplicateId              = "duplicate-id"
+)
+
+type issue struct {
+	Kind       string   `json:"kind"`
+	Collection string   `json:"collection"`
+	Ids        []string `json:"ids"`
+	Detail     string   `json:"detail"`
+}
+
+type checkResult struct {
+	Patients       int     `json:"patients"`
+	MedicalRecords int     `json:"medicalRecords"`
+	Issues         []issue `json:"issues"`
+}
+
+func (r *checkResult) String() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Checked %d patients and %d medical records, found %d issues",
+		r.Patients, r.MedicalRecords, len(r.Issues))
+	for _, issue := range r.Issues {
+		fmt.Fprintf(&text, "\n%s: %s %s: %s", issue.Kind, issue.Collection, strings.Join(issue.Ids, ", "), issue.Detail)
+	}
+	return text.String()
+}
+
+func setupCheck(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		patientsDb := newMongoService[mdm.Patient]("patients")
+		patients, err := patientsDb.FindAllDocuments(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load patients: %w", err)
+		}
+
+		medicalRecordsDb := newMongoService[mdm.MedicalRecord]("medical-records")
+		records, err := medicalRecordsDb.FindAllDocuments(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load medical records: %w", err)
+		}
+
+		result := &checkResult{
+			Patients:       len(patients),
+			MedicalRecords: len(records),
+			Issues:         checkIntegrity(patients, records),
+		}
+		if len(result.Issues) > 0 {
+			return result, errIssuesFound
+		}
+		return result, nil
+	}
+}
+
+// checkIntegrity finds medical records of missing patients and documents
+// sharing values which are expected to be unique
+func checkIntegrity(patients []mdm.Patient, records []mdm.MedicalRecord) []issue {
+	issues := []issue{}
+
+	patientIds := map[string][]string{}
+	insuranceNumbers := map[string][]string{}
+	for _, patient := range patients {
+		patientIds[patient.Id] = append(patientIds[patient.Id], patient.Id)
+		if patient.InsuranceNumber != "" {
+			insuranceNumbers[patient.InsuranceNumber] = append(insuranceNumbers[patient.InsuranceNumber], patient.Id)
+		}
+	}
+	recordIds := map[string][]string{}
+	for _, record := range records {
+		recordIds[record.Id] = append(recordIds[record.Id], record.Id)
+		if _, ok := patientIds[record.PatientId]; !ok {
+			issues = append(issues, issue{
+				Kind:       issueOrphanedRecord,
+				Collection: "medical-records",
+				Ids:        []string{record.Id},
+				Detail:     fmt.Sprintf("patient %s does not exist", record.PatientId),
+			})
+		}
+	}
+
+	for _, id := range sortedKeys(patientIds) {
+		if len(patientIds[id]) > 1 {
+			issues = append(issues, issue{
+				Kind:       issueDuplicateId,
+				Collection: "patients",
+				Ids:        []string{id},
+				Detail:     fmt.Sprintf("%d patients share the id", len(patientIds[id])),
+			})
+		}
+	}
+	for _, id := range sortedKeys(recordIds) {
+		if len(recordIds[id]) > 1 {
+			issues = append(issues, issue{
+				Kind:       issueDuplicateId,
+				Collection: "medical-records",
+				Ids:        []string{id},
+				Detail:     fmt.Sprintf("%d medical records share the id", len(recordIds[id])),
+			})
+		}
+	}
+	for _, number := range sortedKeys(insuranceNumbers) {
+		if len(insuranceNumbers[number]) > 1 {
+			issues = append(issues, issue{
+				Kind:       issueDuplicateInsuranceNumber,
+				Collection: "patients",
+				Ids:        insuranceNumbers[number],
+				Detail:     fmt.Sprintf("patients share insurance number %s", number),
+			})
+		}
+	}
+	return issues
+}
+
+func sortedKeys(values map[string][]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
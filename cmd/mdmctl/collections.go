@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// collection gives commands access to documents of one collection without
+// knowing their type. Documents are exchanged as JSON using the API models.
+type collection interface {
+	Name() string
+	Export(ctx context.Context, w io.Writer) (int, error)
+	Import(ctx context.Context, r io.Reader, upsert bool) (importResult, error)
+	EnsureIndexes(ctx context.Context) ([]db_service.Index, error)
+}
+
+type typedCollection[T any] struct {
+	name    string
+	db      db_service.DbService[T]
+	id      func(document *T) string
+	indexes []db_service.Index
+}
+
+func newCollection[T any](name string, id func(document *T) string, indexes []db_service.Index) collection {
+	return &typedCollection[T]{
+		name:    name,
+		db:      newMongoService[T](name),
+		id:      id,
+		indexes: indexes,
+	}
+}
+
+// collections lists collections of the service, names are the same as in
+// cmd/mdm-api-service
+var collections = map[string]func() collection{
+	"patients": func() collection {
+		return newCollection("patients", func(p *mdm.Patient) string { return p.Id }, mdm.PatientIndexes)
+	},
+	"medical-records": func() collection {
+		return newCollection("medical-records", func(r *mdm.MedicalRecord) string { return r.Id }, mdm.MedicalRecordIndexes)
+	},
+	"webhook-subscriptions": func() collection {
+		return newCollection("webhook-subscriptions", func(s *mdm.WebhookSubscription) string { return s.Id }, mdm.WebhookSubscriptionIndexes)
+	},
+	"webhook-deliveries": func() collection {
+		return newCollection("webhook-deliveries", func(d *mdm.WebhookDelivery) string { return d.Id }, mdm.WebhookDeliveryIndexes)
+	},
+	"outbox": func() collection {
+		return newCollection("outbox", func(m *db_service.OutboxMessage) string { return m.Id }, db_service.OutboxIndexes(outboxRetention))
+	},
+}
+
+// mongoClient is shared by all collections, it is created by main before the
+// command runs and disconnected after it
+var mongoClient *db_service.MongoClient
+
+// outboxRetention is the configured time published outbox messages are kept
+var outboxRetention time.Duration
+
+func newMongoService[T any](collection string) db_service.DbService[T] {
+	return db_service.NewCollectionService[T](mongoClient, collection)
+}
+
+func collectionNames() []string {
+	names := make([]string, 0, len(collections))
+	for name := range collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func openCollection(name string) (collection, error) {
+	open, ok := collections[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown collection %q, use one of %s", name, strings.Join(collectionNames(), ", "))
+	}
+	return open(), nil
+}
+
+func (c *typedCollection[T]) Name() string {
+	return c.name
+}
+
+// Export writes all documents as JSON lines
+func (c *typedCollection[T]) Export(ctx context.Context, w io.Writer) (int, error) {
+	documents, err := c.db.FindAllDocuments(ctx)
+	if err != nil {
+		return 0, err
+	}
+	encoder := json.NewEncoder(w)
+	for i := range documents {
+		if err := encoder.Encode(&documents[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(documents), nil
+}
+
+type importResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Skipped int `json:"skipped"`
+}
+
+// Import creates documents read as JSON lines. Documents already existing are
+// replaced if upsert is set, skipped otherwise. Imported documents do not
+// emit events.
+func (c *typedCollection[T]) Import(ctx context.Context, r io.Reader, upsert bool) (importResult, error) {
+	result := importResult{}
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var document T
+		if err := decoder.Decode(&document); err == io.EOF {
+			return result, nil
+		} else if err != nil {
+			return result, fmt.Errorf("document %d: %w", line, err)
+		}
+
+		id := c.id(&document)
+		if id == "" {
+			return result, fmt.Errorf("document %d: missing id", line)
+		}
+
+		err := c.db.CreateDocument(ctx, id, &document)
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.Is(err, db_service.ErrConflict):
+			if !upsert {
+				result.Skipped++
+				continue
+			}
+			if err := c.db.UpdateDocument(ctx, id, &document); err != nil {
+				return result, fmt.Errorf("document %d: %w", line, err)
+			}
+			result.Updated++
+		default:
+			return result, fmt.Errorf("document %d: %w", line, err)
+		}
+	}
+}
+
+func (c *typedCollection[T]) EnsureIndexes(ctx context.Context) ([]db_service.Index, error) {
+	return c.indexes, c.db.EnsureIndexes(ctx, c.indexes...)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+type testDocument struct {
+	Id string `json:"id"`
+}
+
+// conflictingDb reports conflict of existing documents by *ConflictError as
+// the unique index of the collection does
+type conflictingDb struct {
+	db_service.DbService[testDocument]
+	existing map[string]bool
+	updated  []string
+}
+
+func (d *conflictingDb) CreateDocument(ctx context.Context, id string, document *testDocument) error {
+	if d.existing[id] {
+		return &db_service.ConflictError{Index: "id", Fields: []string{"id"}}
+	}
+	d.existing[id] = true
+	return nil
+}
+
+func (d *conflictingDb) UpdateDocument(ctx context.Context, id string, document *testDocument) error {
+	d.updated = append(d.updated, id)
+	return nil
+}
+
+func TestImportHandlesConflictError(t *testing.T) {
+	input := "{\"id\":\"doc1\"}\n{\"id\":\"doc2\"}\n"
+	for _, upsert := range []bool{false, true} {
+		db := &conflictingDb{existing: map[string]bool{"doc1": true}}
+		collection := &typedCollection[testDocument]{name: "test", db: db, id: func(d *testDocument) string { return d.Id }}
+
+		result, err := collection.Import(context.Background(), strings.NewReader(input), upsert)
+		if err != nil {
+			t.Fatalf("upsert %v: %v", upsert, err)
+		}
+		want := importResult{Created: 1, Skipped: 1}
+		if upsert {
+			want = importResult{Created: 1, Updated: 1}
+		}
+		if result != want {
+			t.Fatalf("upsert %v: result %+v, want %+v", upsert, result, want)
+		}
+	}
+}
+
+func TestSeedDocumentSkipsConflictError(t *testing.T) {
+	db := &conflictingDb{existing: map[string]bool{"doc1": true}}
+	result := &importResult{}
+	if err := seedDocument[testDocument](context.Background(), db, "doc1", &testDocument{Id: "doc1"}, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Skipped != 1 {
+		t.Fatalf("result %+v, want the document skipped", result)
+	}
+}
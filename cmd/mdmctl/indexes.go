@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type indexInfo struct {
+	Name        string   `json:"name"`
+	Keys        []string `json:"keys"`
+	Unique      bool     `json:"unique"`
+	ExpireAfter string   `json:"expireAfter,omitempty"`
+	Partial     string   `json:"partialFilter,omitempty"`
+}
+
+func newIndexInfo(index db_service.Index) indexInfo {
+	info := indexInfo{Name: index.Name, Keys: index.Keys, Unique: index.Unique}
+	if index.ExpireAfter > 0 {
+		info.ExpireAfter = index.ExpireAfter.String()
+	}
+	if index.PartialFilter != nil {
+		filter, _ := bson.MarshalExtJSON(index.PartialFilter, false, false)
+		info.Partial = string(filter)
+	}
+	return info
+}
+
+type indexesResult struct {
+	Collections map[string][]indexInfo `json:"collections"`
+}
+
+func (r *indexesResult) String() string {
+	var text strings.Builder
+	for _, name := range collectionNames() {
+		for _, index := range r.Collections[name] {
+			var properties []string
+			if index.Unique {
+				properties = append(properties, "unique")
+			}
+			if index.ExpireAfter != "" {
+				properties = append(properties, "expires after "+index.ExpireAfter)
+			}
+			if index.Partial != "" {
+				properties = append(properties, "partial "+index.Partial)
+			}
+			details := ""
+			if len(properties) > 0 {
+				details = " (" + strings.Join(properties, ", ") + ")"
+			}
+			fmt.Fprintf(&text, "%s: %s on %s%s\n", name, index.Name, strings.Join(index.Keys, ", "), details)
+		}
+	}
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+func setupIndexes(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		result := &indexesResult{Collections: map[string][]indexInfo{}}
+		for _, name := range collectionNames() {
+			coll, _ := openCollection(name)
+			indexes, err := coll.EnsureIndexes(ctx)
+			if err != nil {
+				return result, fmt.Errorf("failed to create indexes of %s: %w", name, err)
+			}
+			result.Collections[name] = []indexInfo{}
+			for _, index := range indexes {
+				result.Collections[name] = append(result.Collections[name], newIndexInfo(index))
+			}
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,114 @@
+// mdmctl is the administrative command line tool of the MDM service. It
+// connects to the same MongoDB database as the service, configured by the
+// file named by MDM_API_CONFIG_FILE and the MDM_API_MONGODB_* environment
+// variables.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/samsvi/mdm-webapi/internal/config"
+)
+
+// command is a subcommand of mdmctl. Setup defines flags of the command and
+// returns function running it, which returns the result printed to the
+// standard output, either as JSON or as text provided by its String method.
+type command struct {
+	summary string
+	setup   func(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error)
+}
+
+var commands = map[string]command{
+	"export":  {"Export documents of a collection as JSON lines", setupExport},
+	"import":  {"Import documents of a collection from JSON lines", setupImport},
+	"indexes": {"Create or update indexes of all collections", setupIndexes},
+	"migrate": {"Show, apply or revert schema migrations", setupMigrate},
+	"seed":    {"Insert demo and synthetic patients and medical records", setupSeed},
+	"purge":   {"Delete published outbox messages and finished webhook deliveries", setupPurge},
+	"check":   {"Verify data integrity, exits with status 2 when issues are found", setupCheck},
+}
+
+// errIssuesFound makes mdmctl exit with status 2 after printing the result
+var errIssuesFound = errors.New("issues found")
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: mdmctl <command> [flags]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(w, "\nRun 'mdmctl <command> -h' for flags of the command.\n")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(os.Stderr)
+		os.Exit(1)
+	}
+	name := os.Args[1]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage(os.Stdout)
+		return
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", name)
+		usage(os.Stderr)
+		os.Exit(1)
+	}
+
+	flags := flag.NewFlagSet("mdmctl "+name, flag.ExitOnError)
+	output := flags.String("o", "text", "output format, text or json")
+	run := cmd.setup(flags)
+	flags.Parse(os.Args[2:])
+	if *output != "text" && *output != "json" {
+		fmt.Fprintf(os.Stderr, "Invalid output format: %s\n", *output)
+		os.Exit(1)
+	}
+	cfg, err := config.Load(nil, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	mongoClient = cfg.MongoDB.Client()
+	outboxRetention = cfg.MongoDB.OutboxRetention
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	result, err := run(ctx)
+	stop()
+	mongoClient.Disconnect(context.Background())
+	if result != nil {
+		if *output == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.Encode(result)
+		} else {
+			fmt.Println(result.String())
+		}
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, errIssuesFound):
+		os.Exit(2)
+	default:
+		if *output == "json" {
+			json.NewEncoder(os.Stderr).Encode(map[string]string{"error": err.Error()})
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/migrations"
+)
+
+type migrateResult struct {
+	Action     string              `json:"action"`
+	Migrations []migrations.Status `json:"migrations"`
+}
+
+func (r *migrateResult) String() string {
+	if len(r.Migrations) == 0 {
+		if r.Action == "status" {
+			return "No migrations defined"
+		}
+		return "Nothing to " + map[string]string{"up": "apply", "down": "revert"}[r.Action]
+	}
+
+	var text strings.Builder
+	for _, migration := range r.Migrations {
+		state := "pending"
+		switch {
+		case r.Action == "down":
+			state = "reverted"
+		case migration.Unknown:
+			state = "applied by newer version " + migration.AppliedAt.Format(time.RFC3339)
+		case migration.AppliedAt != nil:
+			state = "applied " + migration.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(&text, "%4d  %-28s %s\n", migration.Version, state, migration.Description)
+	}
+	return strings.TrimSuffix(text.String(), "\n")
+}
+
+// setupMigrate applies or reverts the schema migrations, which the service
+// also applies at startup unless disabled by MDM_API_MONGODB_MIGRATE
+func setupMigrate(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: mdmctl migrate [flags] [status|up|down]\n\n"+
+			"up applies pending migrations, down reverts the latest applied one,\n"+
+			"or all above the version given by -to\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+	to := flags.Int("to", -1, "target version, up applies migrations up to it, down reverts those above it")
+
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		action := "status"
+		if flags.NArg() > 0 {
+			action = flags.Arg(0)
+		}
+		result := &migrateResult{Action: action, Migrations: []migrations.Status{}}
+
+		migrator, err := migrations.NewMigrator(mongoClient, migrations.Config{}, migrations.All...)
+		if err != nil {
+			return nil, err
+		}
+
+		var statuses []migrations.Status
+		switch action {
+		case "status":
+			statuses, err = migrator.Status(ctx)
+		case "up":
+			statuses, err = migrator.Up(ctx, max(*to, 0))
+		case "down":
+			target := *to
+			if target < 0 {
+				if target, err = previousVersion(ctx, migrator); err != nil {
+					return nil, err
+				}
+			}
+			statuses, err = migrator.Down(ctx, target)
+		default:
+			return nil, fmt.Errorf("unknown action %q, expected status, up or down", action)
+		}
+		result.Migrations = append(result.Migrations, statuses...)
+		return result, err
+	}
+}
+
+// previousVersion returns version preceding the latest applied migration
+func previousVersion(ctx context.Context, migrator *migrations.Migrator) (int, error) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	previous, latest := 0, 0
+	for _, status := range statuses {
+		if status.AppliedAt != nil && !status.Unknown {
+			previous, latest = latest, status.Version
+		}
+	}
+	if latest == 0 {
+		return 0, nil
+	}
+	return previous, nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	"github.com/samsvi/mdm-webapi/internal/webhooks"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type purgeResult struct {
+	Before            time.Time `json:"before"`
+	DryRun            bool      `json:"dryRun"`
+	OutboxMessages    int       `json:"outboxMessages"`
+	WebhookDeliveries int       `json:"webhookDeliveries"`
+}
+
+func (r *purgeResult) String() string {
+	verb := "Deleted"
+	if r.DryRun {
+		verb = "Would delete"
+	}
+	return fmt.Sprintf("%s %d outbox messages and %d webhook deliveries older than %s",
+		verb, r.OutboxMessages, r.WebhookDeliveries, r.Before.Format(time.RFC3339))
+}
+
+// setupPurge removes documents the service no longer needs. Patients and
+// medical records are deleted by the API right away, so only the published
+// outbox messages and webhook deliveries which are not going to be retried
+// accumulate over time.
+func setupPurge(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	olderThan := flags.Duration("older-than", 7*24*time.Hour, "purge only documents older than this")
+	dryRun := flags.Bool("dry-run", false, "only count the documents to purge")
+
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		result := &purgeResult{Before: time.Now().Add(-*olderThan).UTC(), DryRun: *dryRun}
+
+		outboxDb := newMongoService[db_service.OutboxMessage]("outbox")
+		count, err := purgeDocuments(ctx, outboxDb, bson.M{
+			"publishedat": bson.M{"$ne": nil, "$lt": result.Before},
+		}, func(m *db_service.OutboxMessage) string { return m.Id }, *dryRun)
+		result.OutboxMessages = count
+		if err != nil {
+			return result, fmt.Errorf("failed to purge outbox messages: %w", err)
+		}
+
+		deliveriesDb := newMongoService[mdm.WebhookDelivery]("webhook-deliveries")
+		count, err = purgeDocuments(ctx, deliveriesDb, bson.M{
+			"status":    bson.M{"$in": []string{webhooks.StatusSucceeded, webhooks.StatusFailed}},
+			"createdat": bson.M{"$lt": result.Before},
+		}, func(d *mdm.WebhookDelivery) string { return d.Id }, *dryRun)
+		result.WebhookDeliveries = count
+		if err != nil {
+			return result, fmt.Errorf("failed to purge webhook deliveries: %w", err)
+		}
+
+		return result, nil
+	}
+}
+
+func purgeDocuments[T any](ctx context.Context, db db_service.DbService[T], filter bson.M, id func(*T) string, dryRun bool) (int, error) {
+	documents, err := db.FindDocumentsByCondition(ctx, filter)
+	if err != nil || dryRun {
+		return len(documents), err
+	}
+	for i := range documents {
+		switch err := db.DeleteDocument(ctx, id(&documents[i])); err {
+		case nil, db_service.ErrNotFound:
+		default:
+			return i, err
+		}
+	}
+	return len(documents), nil
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	"github.com/samsvi/mdm-webapi/internal/synthetic"
+)
+
+// demoPatients and demoMedicalRecords are the sample data seeded by the job
+// of the with-demo-data kustomize overlay
+func demoPatients(now time.Time) []mdm.Patient {
+	return []mdm.Patient{
+		{
+			Id:              "pat123456",
+			FirstName:       "Ján",
+			LastName:        "Novák",
+			DateOfBirth:     "1990-01-01",
+			Gender:          "M",
+			InsuranceNumber: "900101/1234",
+			BloodType:       "A+",
+			Status:          "Stable",
+			Allergies: []mdm.Allergy{
+				{Substance: "Penicilín", Reaction: "žihľavka", Severity: "Severe", Verified: true},
+				{Substance: "Arašidy", Reaction: "opuch", Severity: "Moderate"},
+			},
+			MedicalNotes: "Pacient má chronické problémy s tlakom",
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		{
+			Id:              "pat789012",
+			FirstName:       "Anna",
+			LastName:        "Svobodová",
+			DateOfBirth:     "1985-03-15",
+			Gender:          "F",
+			InsuranceNumber: "850315/5678",
+			BloodType:       "O-",
+			Status:          "Recovering",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		},
+	}
+}
+
+func demoMedicalRecords() []mdm.MedicalRecord {
+	firstVisit := time.Date(2024, 5, 15, 9, 30, 0, 0, time.UTC)
+	secondVisit := time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC)
+	return []mdm.MedicalRecord{
+		{
+			Id:          "rec789012",
+			PatientId:   "pat123456",
+			DateOfVisit: firstVisit,
+			Diagnosis:   "Akútna respiračná infekcia",
+			Symptoms:    []string{"kašeľ", "teploty", "bolesti hrdla"},
+			Treatment:   "Predpísané antibiotiká, odpočinok, zvýšený príjem tekutín",
+			Medications: []mdm.Medication{
+				{
+					Id: "med789012", Name: "Klaritromycín", AtcCode: "J01FA09", Dosage: "500mg", Frequency: "2x denne", Duration: "7 dní",
+					StartDate: "2024-05-15", EndDate: "2024-05-21",
+				},
+			},
+			DoctorName:   "Dr. Peter Kováč",
+			Notes:        "Pacient má alergiu na penicilín",
+			FollowUpDate: "2024-05-22",
+			CreatedAt:    firstVisit,
+			UpdatedAt:    firstVisit,
+		},
+		{
+			Id:           "rec789013",
+			PatientId:    "pat123456",
+			DateOfVisit:  secondVisit,
+			Diagnosis:    "Preventívna prehliadka",
+			Symptoms:     []string{},
+			Treatment:    "Kontrola zdravotného stavu",
+			Medications:  []mdm.Medication{},
+			DoctorName:   "Dr. Eva Horáková",
+			Notes:        "Všetko v poriadku",
+			FollowUpDate: "2025-03-10",
+			CreatedAt:    secondVisit,
+			UpdatedAt:    secondVisit,
+		},
+	}
+}
+
+type seedResult struct {
+	Patients       importResult `json:"patients"`
+	MedicalRecords importResult `json:"medicalRecords"`
+}
+
+func (r *seedResult) String() string {
+	return fmt.Sprintf("Seeded %d patients and %d medical records, %d patients and %d medical records already existed",
+		r.Patients.Created, r.MedicalRecords.Created, r.Patients.Skipped, r.MedicalRecords.Skipped)
+}
+
+// setupSeed inserts the demo data and optionally synthetic patients, which
+// are the same for the same seed and reference date, so repeated seeding
+// skips the patients seeded before
+func setupSeed(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	patients := flags.Int("patients", 0, "number of synthetic patients to generate in addition to the demo data")
+	seed := flags.Uint64("seed", 1, "seed of the synthetic data")
+	maxRecords := flags.Int("max-records", 5, "maximal number of medical records of a synthetic patient")
+	now := flags.String("now", "", "reference date of the synthetic data, YYYY-MM-DD (default today)")
+
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		patientsDb := newMongoService[mdm.Patient]("patients")
+		medicalRecordsDb := newMongoService[mdm.MedicalRecord]("medical-records")
+
+		config := synthetic.Config{Seed: *seed, MaxRecords: *maxRecords}
+		if *now != "" {
+			date, err := time.Parse(time.DateOnly, *now)
+			if err != nil {
+				return nil, fmt.Errorf("invalid reference date: %w", err)
+			}
+			config.Now = date
+		}
+
+		// records are never seeded without their patients
+		result := &seedResult{}
+		err := mongoClient.WithTransaction(ctx, func(ctx context.Context) error {
+			*result = seedResult{}
+			for _, patient := range demoPatients(time.Now()) {
+				if err := seedDocument(ctx, patientsDb, patient.Id, &patient, &result.Patients); err != nil {
+					return err
+				}
+			}
+			for _, record := range demoMedicalRecords() {
+				if err := seedDocument(ctx, medicalRecordsDb, record.Id, &record, &result.MedicalRecords); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil || *patients <= 0 {
+			return result, err
+		}
+
+		generator := synthetic.NewGenerator(config)
+		for range *patients {
+			patient := generator.Patient()
+			records := generator.MedicalRecords(&patient)
+
+			// each patient is seeded with its records in own transaction
+			var seeded seedResult
+			err := mongoClient.WithTransaction(ctx, func(ctx context.Context) error {
+				seeded = seedResult{}
+				created, err := seedPatient(ctx, patientsDb, &patient, &seeded.Patients)
+				if err != nil || !created {
+					seeded.MedicalRecords.Skipped = len(records)
+					return err
+				}
+				for _, record := range records {
+					if err := seedDocument(ctx, medicalRecordsDb, record.Id, &record, &seeded.MedicalRecords); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Patients.Created += seeded.Patients.Created
+			result.Patients.Skipped += seeded.Patients.Skipped
+			result.MedicalRecords.Created += seeded.MedicalRecords.Created
+			result.MedicalRecords.Skipped += seeded.MedicalRecords.Skipped
+		}
+		return result, nil
+	}
+}
+
+// seedPatient creates the synthetic patient unless it exists, or another
+// patient has the same insurance number, e.g. one of the demo patients
+func seedPatient(ctx context.Context, db db_service.DbService[mdm.Patient], patient *mdm.Patient, result *importResult) (bool, error) {
+	err := db.CreateDocument(ctx, patient.Id, patient)
+	switch {
+	case err == nil:
+		result.Created++
+		return true, nil
+	case errors.Is(err, db_service.ErrConflict):
+		result.Skipped++
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to seed %s: %w", patient.Id, err)
+	}
+}
+
+// seedDocument creates the document unless it already exists
+func seedDocument[T any](ctx context.Context, db db_service.DbService[T], id string, document *T, result *importResult) error {
+	err := db.CreateDocument(ctx, id, document)
+	switch {
+	case err == nil:
+		result.Created++
+	case errors.Is(err, db_service.ErrConflict):
+		result.Skipped++
+	default:
+		return fmt.Errorf("failed to seed %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+type exportResult struct {
+	Collection string `json:"collection"`
+	File       string `json:"file"`
+	Exported   int    `json:"exported"`
+}
+
+func (r *exportResult) String() string {
+	return fmt.Sprintf("Exported %d documents of %s to %s", r.Exported, r.Collection, r.File)
+}
+
+func setupExport(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	name := flags.String("collection", "", "collection to export: "+strings.Join(collectionNames(), ", "))
+	file := flags.String("file", "", "file to write JSON lines to")
+
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		// documents go to the file, so the standard output is left for the result
+		if *file == "" {
+			return nil, errors.New("-file is required")
+		}
+		coll, err := openCollection(*name)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := os.Create(*file)
+		if err != nil {
+			return nil, err
+		}
+		exported, err := coll.Export(ctx, out)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &exportResult{Collection: *name, File: *file, Exported: exported}, nil
+	}
+}
+
+type importSummary struct {
+	Collection string `json:"collection"`
+	File       string `json:"file"`
+	importResult
+}
+
+func (r *importSummary) String() string {
+	return fmt.Sprintf("Imported documents of %s from %s: %d created, %d updated, %d skipped",
+		r.Collection, r.File, r.Created, r.Updated, r.Skipped)
+}
+
+func setupImport(flags *flag.FlagSet) func(ctx context.Context) (fmt.Stringer, error) {
+	name := flags.String("collection", "", "collection to import to: "+strings.Join(collectionNames(), ", "))
+	file := flags.String("file", "-", "file to read JSON lines from, - for the standard input")
+	upsert := flags.Bool("upsert", false, "replace existing documents instead of skipping them")
+
+	return func(ctx context.Context) (fmt.Stringer, error) {
+		coll, err := openCollection(*name)
+		if err != nil {
+			return nil, err
+		}
+
+		var in io.Reader = os.Stdin
+		if *file != "-" {
+			f, err := os.Open(*file)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		result, err := coll.Import(ctx, in, *upsert)
+		return &importSummary{Collection: *name, File: *file, importResult: result}, err
+	}
+}
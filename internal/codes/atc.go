@@ -0,0 +1,32 @@
+package codes
+
+import (
+	_ "embed"
+	"regexp"
+	"strings"
+)
+
+//go:embed atc.tsv
+var atcData string
+
+// Atc is the list of medicinal substances classified by the Anatomical
+// Therapeutic Chemical classification with their Slovak names. It covers the
+// substances commonly prescribed in outpatient care.
+var Atc = mustParse(atcData, NormalizeAtc)
+
+// atcCode matches codes of the chemical substances, the fifth level of the
+// classification, in the canonical form, e.g. "J01CA04"
+var atcCode = regexp.MustCompile(`^[A-Z][0-9]{2}[A-Z]{2}[0-9]{2}$`)
+
+// IsAtcCode reports whether the code has the form of ATC code of a chemical
+// substance, regardless of whether it is in the list, so that malformed codes
+// are told apart from unknown ones
+func IsAtcCode(code string) bool {
+	return atcCode.MatchString(NormalizeAtc(code))
+}
+
+// NormalizeAtc returns the code in the canonical form, e.g. "j01ca04" is
+// "J01CA04"
+func NormalizeAtc(code string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
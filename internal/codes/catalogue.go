@@ -0,0 +1,170 @@
+// Package codes provides the code lists clinical data are coded by. The lists
+// are embedded in the binary, so that validation and lookups need no database,
+// the ICD-10 list may be replaced by a file with the whole classification.
+package codes
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// DefaultSearchLimit is the number of matches returned when not limited
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest number of matches returned at once
+	MaxSearchLimit = 100
+)
+
+// Code is an entry of the code list
+type Code struct {
+	Code    string `json:"code"`
+	Display string `json:"display"`
+}
+
+// Catalogue is an immutable code list, it is safe for concurrent use
+type Catalogue struct {
+	codes  []Code
+	byCode map[string]int
+	// normalize returns the canonical form of the code the list is keyed by
+	normalize func(code string) string
+	// folded display texts for the search
+	folded []string
+	// index of the first code by the folded display text
+	byDisplay map[string]int
+}
+
+// parseCatalogue reads the list of tab separated codes and display texts,
+// empty lines and lines starting with # are skipped
+func parseCatalogue(data string, normalize func(string) string) (*Catalogue, error) {
+	catalogue := &Catalogue{byCode: map[string]int{}, normalize: normalize}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		code, display, ok := strings.Cut(text, "\t")
+		if !ok || strings.TrimSpace(display) == "" {
+			return nil, fmt.Errorf("line %d: expected code and display text separated by tab", line)
+		}
+		code = normalize(code)
+		if _, exists := catalogue.byCode[code]; exists {
+			return nil, fmt.Errorf("line %d: duplicate code %s", line, code)
+		}
+		catalogue.byCode[code] = len(catalogue.codes)
+		catalogue.codes = append(catalogue.codes, Code{Code: code, Display: strings.TrimSpace(display)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(catalogue.codes, func(i, j int) bool { return catalogue.codes[i].Code < catalogue.codes[j].Code })
+	catalogue.folded = make([]string, len(catalogue.codes))
+	catalogue.byDisplay = map[string]int{}
+	for i, code := range catalogue.codes {
+		catalogue.byCode[code.Code] = i
+		catalogue.folded[i] = Fold(code.Display)
+		if _, exists := catalogue.byDisplay[catalogue.folded[i]]; !exists {
+			catalogue.byDisplay[catalogue.folded[i]] = i
+		}
+	}
+	return catalogue, nil
+}
+
+// Len returns the number of codes in the list
+func (c *Catalogue) Len() int {
+	return len(c.codes)
+}
+
+// Lookup returns the code entry, the code may be written in any of the forms
+// the list accepts, e.g. in lower case
+func (c *Catalogue) Lookup(code string) (Code, bool) {
+	i, ok := c.byCode[c.normalize(code)]
+	if !ok {
+		return Code{}, false
+	}
+	return c.codes[i], true
+}
+
+// LookupDisplay returns the code entry whose display text equals the text,
+// ignoring case and diacritics
+func (c *Catalogue) LookupDisplay(display string) (Code, bool) {
+	i, ok := c.byDisplay[Fold(strings.TrimSpace(display))]
+	if !ok {
+		return Code{}, false
+	}
+	return c.codes[i], true
+}
+
+// Search returns codes matching the query for autocomplete. Codes starting
+// with the query come first, then codes whose display text contains words
+// starting with all the words of the query, ignoring case and diacritics.
+// Non-positive limit means DefaultSearchLimit, it is capped at MaxSearchLimit.
+func (c *Catalogue) Search(query string, limit int) []Code {
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	matches := []Code{}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return matches
+	}
+
+	matched := map[int]bool{}
+	prefix := c.normalize(query)
+	start := sort.Search(len(c.codes), func(i int) bool { return c.codes[i].Code >= prefix })
+	for i := start; i < len(c.codes) && strings.HasPrefix(c.codes[i].Code, prefix) && len(matches) < limit; i++ {
+		matches = append(matches, c.codes[i])
+		matched[i] = true
+	}
+
+	words := strings.Fields(Fold(query))
+	for i := range c.codes {
+		if len(matches) >= limit {
+			break
+		}
+		if !matched[i] && containsWords(c.folded[i], words) {
+			matches = append(matches, c.codes[i])
+		}
+	}
+	return matches
+}
+
+// containsWords reports whether each of the words starts a word of the text
+func containsWords(text string, words []string) bool {
+	textWords := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		found := false
+		for _, textWord := range textWords {
+			if strings.HasPrefix(textWord, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Fold returns the text in lower case without diacritics, so that "pluc"
+// matches "pľúc"
+func Fold(text string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		folded = text
+	}
+	return strings.ToLower(folded)
+}
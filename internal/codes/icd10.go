@@ -0,0 +1,61 @@
+package codes
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+//go:embed icd10.tsv
+var icd10Data string
+
+// Icd10 is the list of ICD-10 diagnoses in the Slovak version MKCH-10. The
+// embedded list covers the diagnoses common in outpatient care, deployments
+// coding other diagnoses load the whole classification by LoadIcd10.
+var Icd10 = mustParse(icd10Data, NormalizeIcd10)
+
+// LoadIcd10 replaces the embedded list by the code list in the file, e.g. the
+// whole MKCH-10 classification. It must be called before the list is used.
+func LoadIcd10(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	catalogue, err := parseCatalogue(string(data), NormalizeIcd10)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	Icd10 = catalogue
+	return nil
+}
+
+// icd10Code matches codes of the categories and subcategories of ICD-10 in
+// the canonical form, e.g. "J06" or "J06.9"
+var icd10Code = regexp.MustCompile(`^[A-Z][0-9]{2}(\.[0-9A-Z]{1,4})?$`)
+
+// IsIcd10Code reports whether the code has the form of ICD-10 code,
+// regardless of whether it is in the list, so that malformed codes are told
+// apart from unknown ones
+func IsIcd10Code(code string) bool {
+	return icd10Code.MatchString(NormalizeIcd10(code))
+}
+
+// NormalizeIcd10 returns the code in the canonical form, e.g. "j069" and
+// "J06.9" are both "J06.9"
+func NormalizeIcd10(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+	if len(code) > 3 && !strings.Contains(code, ".") {
+		code = code[:3] + "." + code[3:]
+	}
+	return code
+}
+
+func mustParse(data string, normalize func(string) string) *Catalogue {
+	catalogue, err := parseCatalogue(data, normalize)
+	if err != nil {
+		panic("invalid code list: " + err.Error())
+	}
+	return catalogue
+}
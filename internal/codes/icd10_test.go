@@ -0,0 +1,34 @@
+package codes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadIcd10(t *testing.T) {
+	embedded := Icd10
+	t.Cleanup(func() { Icd10 = embedded })
+
+	path := filepath.Join(t.TempDir(), "mkch10.tsv")
+	data := "# MKCH-10\nJ06.9\tAkútna infekcia horných dýchacích ciest, bližšie neurčená\nQ871\tMarfanov syndróm\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadIcd10(path); err != nil {
+		t.Fatal(err)
+	}
+	if code, ok := Icd10.Lookup("q87.1"); !ok || code.Display != "Marfanov syndróm" {
+		t.Fatalf("lookup of loaded code returned %+v, %v", code, ok)
+	}
+	if Icd10.Len() != 2 {
+		t.Fatalf("loaded %d codes, want 2", Icd10.Len())
+	}
+
+	if err := os.WriteFile(path, []byte("J06.9 no tab\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadIcd10(path); err == nil || Icd10.Len() != 2 {
+		t.Fatalf("invalid list loaded, error %v", err)
+	}
+}
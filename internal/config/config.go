@@ -0,0 +1,342 @@
+// Package config provides typed configuration of the service. Values are
+// loaded by Load from defaults, YAML file, environment variables and command
+// line flags, each source overriding the previous ones.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Fields are bound to the sources by tags:
+//
+//   - yaml - key in the configuration file, nested keys are joined by dots
+//     and the flag name is derived from them, e.g. mongodb.host is -mongodb-host
+//   - env - environment variable
+//   - usage - description printed by -h
+//   - secret - value masked when the configuration is printed, uri masks
+//     only the password of connection string
+//
+// Durations are given either as whole seconds or as Go durations, e.g. 1m30s.
+type Config struct {
+	Environment  string             `yaml:"environment" env:"MDM_API_ENVIRONMENT" usage:"environment of the deployment, production enables release mode"`
+	Server       ServerConfig       `yaml:"server"`
+	MongoDB      MongoDBConfig      `yaml:"mongodb"`
+	Log          LogConfig          `yaml:"log"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+	Events       EventsConfig       `yaml:"events"`
+	EarlyWarning EarlyWarningConfig `yaml:"earlyWarning"`
+	Codes        CodesConfig        `yaml:"codes"`
+}
+
+type ServerConfig struct {
+	Port     int `yaml:"port" env:"MDM_API_PORT" usage:"port of the HTTP server"`
+	GrpcPort int `yaml:"grpcPort" env:"MDM_API_GRPC_PORT" usage:"port of the gRPC server"`
+	// time to report not ready before the requests are drained
+	ShutdownDelay     time.Duration `yaml:"shutdownDelay" env:"MDM_API_SHUTDOWN_DELAY_SECONDS" usage:"time the service reports not ready before shutting down"`
+	ShutdownTimeout   time.Duration `yaml:"shutdownTimeout" env:"MDM_API_SHUTDOWN_TIMEOUT_SECONDS" usage:"deadline for draining requests in progress on shutdown"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"MDM_API_READ_HEADER_TIMEOUT_SECONDS" usage:"time allowed to read request headers"`
+}
+
+// MongoDBConfig either sets the connection string, e.g. of a replica set,
+// or the host and port the connection string is built from
+type MongoDBConfig struct {
+	Uri                   string        `yaml:"uri" env:"MDM_API_MONGODB_URI" usage:"MongoDB connection string, overrides host, port and srv" secret:"uri"`
+	Host                  string        `yaml:"host" env:"MDM_API_MONGODB_HOST" usage:"MongoDB server host"`
+	Port                  int           `yaml:"port" env:"MDM_API_MONGODB_PORT" usage:"MongoDB server port"`
+	Srv                   bool          `yaml:"srv" env:"MDM_API_MONGODB_SRV" usage:"resolve the host by DNS SRV record"`
+	Username              string        `yaml:"username" env:"MDM_API_MONGODB_USERNAME" usage:"MongoDB user, empty to connect without authentication" secret:"true"`
+	Password              string        `yaml:"password" env:"MDM_API_MONGODB_PASSWORD" usage:"MongoDB password" secret:"true"`
+	AuthSource            string        `yaml:"authSource" env:"MDM_API_MONGODB_AUTH_SOURCE" usage:"database authenticating the user"`
+	ReplicaSet            string        `yaml:"replicaSet" env:"MDM_API_MONGODB_REPLICA_SET" usage:"name of the replica set"`
+	ReadPreference        string        `yaml:"readPreference" env:"MDM_API_MONGODB_READ_PREFERENCE" usage:"read preference, primary, primaryPreferred, secondary, secondaryPreferred or nearest"`
+	Tls                   bool          `yaml:"tls" env:"MDM_API_MONGODB_TLS" usage:"connect using TLS"`
+	TlsCaFile             string        `yaml:"tlsCaFile" env:"MDM_API_MONGODB_TLS_CA_FILE" usage:"PEM file with certificate authorities of the server"`
+	TlsCertificateKeyFile string        `yaml:"tlsCertificateKeyFile" env:"MDM_API_MONGODB_TLS_CERTIFICATE_KEY_FILE" usage:"PEM file with client certificate and key"`
+	TlsInsecure           bool          `yaml:"tlsInsecure" env:"MDM_API_MONGODB_TLS_INSECURE" usage:"skip verification of the server certificate, for development only"`
+	MaxPoolSize           int           `yaml:"maxPoolSize" env:"MDM_API_MONGODB_MAX_POOL_SIZE" usage:"maximal number of connections to each server, 0 for the driver default"`
+	MinPoolSize           int           `yaml:"minPoolSize" env:"MDM_API_MONGODB_MIN_POOL_SIZE" usage:"number of connections kept open to each server"`
+	MaxConnIdleTime       time.Duration `yaml:"maxConnIdleTime" env:"MDM_API_MONGODB_MAX_CONN_IDLE_TIME_SECONDS" usage:"time after which idle connections are closed, 0 for no limit"`
+	Database              string        `yaml:"database" env:"MDM_API_MONGODB_DATABASE" usage:"MongoDB database"`
+	OutboxCollection      string        `yaml:"outboxCollection" env:"MDM_API_MONGODB_OUTBOX_COLLECTION" usage:"collection of the transactional outbox"`
+	OutboxRetention       time.Duration `yaml:"outboxRetention" env:"MDM_API_MONGODB_OUTBOX_RETENTION_SECONDS" usage:"time published outbox messages are kept before they expire"`
+	Timeout               time.Duration `yaml:"timeout" env:"MDM_API_MONGODB_TIMEOUT_SECONDS" usage:"timeout of MongoDB operations"`
+	RetryAttempts         int           `yaml:"retryAttempts" env:"MDM_API_MONGODB_RETRY_ATTEMPTS" usage:"attempts of operations failing transiently, including the first one"`
+	BreakerThreshold      int           `yaml:"breakerThreshold" env:"MDM_API_MONGODB_BREAKER_THRESHOLD" usage:"consecutive failures after which operations fail fast"`
+	BreakerOpenTimeout    time.Duration `yaml:"breakerOpenTimeout" env:"MDM_API_MONGODB_BREAKER_OPEN_SECONDS" usage:"time operations fail fast before the database is tried again"`
+	Migrate               bool          `yaml:"migrate" env:"MDM_API_MONGODB_MIGRATE" usage:"apply pending schema migrations and ensure indexes at startup"`
+	MigrateTimeout        time.Duration `yaml:"migrateTimeout" env:"MDM_API_MONGODB_MIGRATE_TIMEOUT_SECONDS" usage:"time to apply the migrations at startup, including waiting for other replicas"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"MDM_API_LOG_LEVEL" usage:"minimal log level, debug, info, warn or error"`
+	// text or json, json by default in production
+	Format string `yaml:"format" env:"MDM_API_LOG_FORMAT" usage:"log format, text or json (default json in production, text otherwise)"`
+}
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"MDM_API_TRACING_EXPORTER" usage:"tracing exporter, none, stdout or otlp"`
+}
+
+type EventsConfig struct {
+	// in-process bus only when empty
+	Broker string      `yaml:"broker" env:"MDM_API_EVENTS_BROKER" usage:"external events broker, nats or kafka, empty for none"`
+	Nats   NatsConfig  `yaml:"nats"`
+	Kafka  KafkaConfig `yaml:"kafka"`
+}
+
+type NatsConfig struct {
+	Url           string `yaml:"url" env:"MDM_API_NATS_URL" usage:"NATS server URL"`
+	SubjectPrefix string `yaml:"subjectPrefix" env:"MDM_API_NATS_SUBJECT_PREFIX" usage:"prefix of NATS subjects"`
+	Stream        string `yaml:"stream" env:"MDM_API_NATS_STREAM" usage:"JetStream stream, empty for core NATS"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers" env:"MDM_API_KAFKA_BROKERS" usage:"comma separated Kafka brokers"`
+	Topic   string   `yaml:"topic" env:"MDM_API_KAFKA_TOPIC" usage:"Kafka topic"`
+}
+
+// EarlyWarningConfig sets the NEWS2 score detecting deterioration of the
+// patients and what happens when it is reached
+type EarlyWarningConfig struct {
+	Threshold int           `yaml:"threshold" env:"MDM_API_EARLY_WARNING_THRESHOLD" usage:"NEWS2 score at which deterioration of the patient is reported"`
+	Action    string        `yaml:"action" env:"MDM_API_EARLY_WARNING_ACTION" usage:"action at the threshold, propose Critical status, alert by event or both"`
+	MaxAge    time.Duration `yaml:"maxAge" env:"MDM_API_EARLY_WARNING_MAX_AGE_SECONDS" usage:"age of the latest observations still scored"`
+}
+
+// CodesConfig replaces the embedded code lists, which cover the codes common
+// in outpatient care, by complete classifications
+type CodesConfig struct {
+	Icd10File string `yaml:"icd10File" env:"MDM_API_CODES_ICD10_FILE" usage:"file with the complete MKCH-10 classification as code<TAB>name lines, the embedded list of common diagnoses when empty"`
+}
+
+// Default returns configuration used when no source sets a value
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:              8080,
+			GrpcPort:          9090,
+			ShutdownDelay:     5 * time.Second,
+			ShutdownTimeout:   25 * time.Second,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		MongoDB: MongoDBConfig{
+			Host:               "localhost",
+			Port:               27017,
+			Database:           "mdm-patient-management",
+			OutboxCollection:   "outbox",
+			OutboxRetention:    7 * 24 * time.Hour,
+			Timeout:            10 * time.Second,
+			RetryAttempts:      3,
+			BreakerThreshold:   5,
+			BreakerOpenTimeout: 30 * time.Second,
+			Migrate:            true,
+			MigrateTimeout:     2 * time.Minute,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+		Events: EventsConfig{
+			Nats: NatsConfig{
+				Url:           "nats://localhost:4222",
+				SubjectPrefix: "mdm",
+			},
+			Kafka: KafkaConfig{
+				Brokers: []string{"localhost:9092"},
+				Topic:   "mdm-events",
+			},
+		},
+		EarlyWarning: EarlyWarningConfig{
+			Threshold: 7,
+			Action:    "both",
+			MaxAge:    24 * time.Hour,
+		},
+	}
+}
+
+// IsProduction reports whether the service runs in production environment
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.Environment, "production")
+}
+
+// Validate returns all invalid values at once
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+	port := func(key string, value int) {
+		if value < 1 || value > 65535 {
+			invalid(key, "port %d is out of range 1-65535", value)
+		}
+	}
+	oneOf := func(key string, value string, allowed ...string) {
+		if !slices.Contains(allowed, strings.ToLower(value)) {
+			invalid(key, "%q is not one of %s", value, strings.Join(allowed, ", "))
+		}
+	}
+	positive := func(key string, value time.Duration) {
+		if value <= 0 {
+			invalid(key, "must be positive")
+		}
+	}
+
+	port("server.port", c.Server.Port)
+	port("server.grpcPort", c.Server.GrpcPort)
+	if c.Server.Port == c.Server.GrpcPort {
+		invalid("server.grpcPort", "must differ from server.port")
+	}
+	if c.Server.ShutdownDelay < 0 {
+		invalid("server.shutdownDelay", "must not be negative")
+	}
+	positive("server.shutdownTimeout", c.Server.ShutdownTimeout)
+	positive("server.readHeaderTimeout", c.Server.ReadHeaderTimeout)
+
+	if c.MongoDB.Uri != "" {
+		if !strings.HasPrefix(c.MongoDB.Uri, "mongodb://") && !strings.HasPrefix(c.MongoDB.Uri, "mongodb+srv://") {
+			invalid("mongodb.uri", "must start with mongodb:// or mongodb+srv://")
+		}
+	} else {
+		if c.MongoDB.Host == "" {
+			invalid("mongodb.host", "must not be empty")
+		}
+		port("mongodb.port", c.MongoDB.Port)
+	}
+	if c.MongoDB.Password != "" && c.MongoDB.Username == "" {
+		invalid("mongodb.password", "is set without mongodb.username")
+	}
+	if c.MongoDB.ReadPreference != "" {
+		if _, err := readpref.ModeFromString(c.MongoDB.ReadPreference); err != nil {
+			invalid("mongodb.readPreference", "%q is not a read preference", c.MongoDB.ReadPreference)
+		}
+	}
+	readable := func(key string, path string) {
+		if path == "" {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			invalid(key, "%v", err)
+		}
+	}
+	readable("mongodb.tlsCaFile", c.MongoDB.TlsCaFile)
+	readable("mongodb.tlsCertificateKeyFile", c.MongoDB.TlsCertificateKeyFile)
+	if c.MongoDB.MaxPoolSize < 0 || c.MongoDB.MinPoolSize < 0 {
+		invalid("mongodb.maxPoolSize", "pool sizes must not be negative")
+	}
+	if c.MongoDB.MaxPoolSize > 0 && c.MongoDB.MinPoolSize > c.MongoDB.MaxPoolSize {
+		invalid("mongodb.minPoolSize", "must not exceed mongodb.maxPoolSize")
+	}
+	if c.MongoDB.MaxConnIdleTime < 0 {
+		invalid("mongodb.maxConnIdleTime", "must not be negative")
+	}
+	if c.MongoDB.Database == "" {
+		invalid("mongodb.database", "must not be empty")
+	}
+	if c.MongoDB.OutboxCollection == "" {
+		invalid("mongodb.outboxCollection", "must not be empty")
+	}
+	positive("mongodb.outboxRetention", c.MongoDB.OutboxRetention)
+	positive("mongodb.timeout", c.MongoDB.Timeout)
+	if c.MongoDB.RetryAttempts < 1 {
+		invalid("mongodb.retryAttempts", "must be at least 1")
+	}
+	if c.MongoDB.BreakerThreshold < 1 {
+		invalid("mongodb.breakerThreshold", "must be at least 1")
+	}
+	positive("mongodb.breakerOpenTimeout", c.MongoDB.BreakerOpenTimeout)
+	if c.MongoDB.Migrate {
+		positive("mongodb.migrateTimeout", c.MongoDB.MigrateTimeout)
+	}
+
+	oneOf("log.level", c.Log.Level, "debug", "info", "warn", "warning", "error")
+	oneOf("log.format", c.Log.Format, "", "text", "json")
+	oneOf("tracing.exporter", c.Tracing.Exporter, "", "none", "stdout", "otlp")
+
+	oneOf("events.broker", c.Events.Broker, "", "none", "nats", "kafka")
+	switch strings.ToLower(c.Events.Broker) {
+	case "nats":
+		if c.Events.Nats.Url == "" {
+			invalid("events.nats.url", "must not be empty with nats broker")
+		}
+	case "kafka":
+		if len(c.Events.Kafka.Brokers) == 0 {
+			invalid("events.kafka.brokers", "must not be empty with kafka broker")
+		}
+		if c.Events.Kafka.Topic == "" {
+			invalid("events.kafka.topic", "must not be empty with kafka broker")
+		}
+	}
+
+	// NEWS2 scores range from 0 to 20
+	if c.EarlyWarning.Threshold < 1 || c.EarlyWarning.Threshold > 20 {
+		invalid("earlyWarning.threshold", "%d is out of range 1-20", c.EarlyWarning.Threshold)
+	}
+	oneOf("earlyWarning.action", c.EarlyWarning.Action, "propose", "alert", "both")
+	positive("earlyWarning.maxAge", c.EarlyWarning.MaxAge)
+
+	return errors.Join(errs...)
+}
+
+// LogFormat returns the configured log format or the default of the environment
+func (c *Config) LogFormat() string {
+	if c.Log.Format != "" {
+		return strings.ToLower(c.Log.Format)
+	}
+	if c.IsProduction() {
+		return "json"
+	}
+	return "text"
+}
+
+// Client returns client of the database shared by the collections
+func (c MongoDBConfig) Client() *db_service.MongoClient {
+	return db_service.NewMongoClient(c.Service(""))
+}
+
+// Resilience returns configuration of retries with circuit breaker to be
+// shared by services of all the collections
+func (c MongoDBConfig) Resilience() db_service.ResilienceConfig {
+	return db_service.ResilienceConfig{
+		MaxAttempts: c.RetryAttempts,
+		Breaker: db_service.NewCircuitBreaker(db_service.CircuitBreakerConfig{
+			FailureThreshold: c.BreakerThreshold,
+			OpenTimeout:      c.BreakerOpenTimeout,
+		}),
+	}
+}
+
+// Service returns configuration of the database service of the collection
+func (c MongoDBConfig) Service(collection string) db_service.MongoServiceConfig {
+	return db_service.MongoServiceConfig{
+		Uri:                   c.Uri,
+		ServerHost:            c.Host,
+		ServerPort:            c.Port,
+		Srv:                   c.Srv,
+		UserName:              c.Username,
+		Password:              c.Password,
+		AuthSource:            c.AuthSource,
+		ReplicaSet:            c.ReplicaSet,
+		ReadPreference:        c.ReadPreference,
+		Tls:                   c.Tls,
+		TlsCaFile:             c.TlsCaFile,
+		TlsCertificateKeyFile: c.TlsCertificateKeyFile,
+		TlsInsecure:           c.TlsInsecure,
+		MaxPoolSize:           uint64(c.MaxPoolSize),
+		MinPoolSize:           uint64(c.MinPoolSize),
+		MaxConnIdleTime:       c.MaxConnIdleTime,
+		DbName:                c.Database,
+		Collection:            collection,
+		Timeout:               c.Timeout,
+		OutboxCollection:      c.OutboxCollection,
+	}
+}
@@ -0,0 +1,274 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvConfigFile names environment variable with path of the configuration
+// file, used when the -config flag is not given
+const EnvConfigFile = "MDM_API_CONFIG_FILE"
+
+// field is a configuration value bound to its sources
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret string
+	value  reflect.Value
+}
+
+// fields lists the configuration values in order of declaration
+func (c *Config) fields() []field {
+	var fields []field
+	var walk func(prefix string, value reflect.Value)
+	walk = func(prefix string, value reflect.Value) {
+		for i := 0; i < value.NumField(); i++ {
+			structField := value.Type().Field(i)
+			key := prefix + structField.Tag.Get("yaml")
+			if structField.Type.Kind() == reflect.Struct {
+				walk(key+".", value.Field(i))
+				continue
+			}
+			fields = append(fields, field{
+				key:    key,
+				env:    structField.Tag.Get("env"),
+				usage:  structField.Tag.Get("usage"),
+				secret: structField.Tag.Get("secret"),
+				value:  value.Field(i),
+			})
+		}
+	}
+	walk("", reflect.ValueOf(c).Elem())
+	return fields
+}
+
+// flagName derives name of the command line flag from the key,
+// e.g. server.grpcPort is server-grpc-port
+func (f field) flagName() string {
+	var name strings.Builder
+	for _, r := range f.key {
+		switch {
+		case r == '.':
+			name.WriteRune('-')
+		case r >= 'A' && r <= 'Z':
+			name.WriteRune('-')
+			name.WriteRune(r - 'A' + 'a')
+		default:
+			name.WriteRune(r)
+		}
+	}
+	return name.String()
+}
+
+func (f field) set(text string) error {
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(text)
+	case int:
+		value, err := strconv.Atoi(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", text)
+		}
+		f.value.SetInt(int64(value))
+	case bool:
+		value, err := strconv.ParseBool(strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", text)
+		}
+		f.value.SetBool(value)
+	case time.Duration:
+		value, err := parseDuration(text)
+		if err != nil {
+			return err
+		}
+		f.value.SetInt(int64(value))
+	case []string:
+		var values []string
+		for _, value := range strings.Split(text, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		f.value.Set(reflect.ValueOf(values))
+	default:
+		return fmt.Errorf("unsupported type %s", f.value.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	switch value := f.value.Interface().(type) {
+	case []string:
+		return strings.Join(value, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// flagValue keeps text of the flag until the values are set in order of
+// precedence, boolean flags may be given without value
+type flagValue struct {
+	text   string
+	isBool bool
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+	return v.text
+}
+
+func (v *flagValue) Set(text string) error {
+	v.text = text
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// parseDuration accepts whole seconds, as used by the *_SECONDS variables,
+// or Go duration
+func parseDuration(text string) (time.Duration, error) {
+	text = strings.TrimSpace(text)
+	if seconds, err := strconv.Atoi(text); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	value, err := time.ParseDuration(text)
+	if err != nil {
+		return 0, fmt.Errorf("%q is neither number of seconds nor duration", text)
+	}
+	return value, nil
+}
+
+// Load returns validated configuration. Values are taken from the defaults,
+// then from YAML file given by -config flag or MDM_API_CONFIG_FILE variable,
+// then from the environment and finally from the command line flags.
+//
+// Flags of all values are registered to the flag set and the arguments are
+// parsed, flags may be nil when the configuration is not read from the
+// command line.
+func Load(flags *flag.FlagSet, args []string) (*Config, error) {
+	config := Default()
+	fields := config.fields()
+
+	configFile := os.Getenv(EnvConfigFile)
+	var flagValues map[string]*string
+	if flags != nil {
+		flags.StringVar(&configFile, "config", configFile, "path of YAML configuration file (env "+EnvConfigFile+")")
+		flagValues = map[string]*string{}
+		for _, f := range fields {
+			usage := f.usage
+			if f.env != "" {
+				usage += " (env " + f.env + ")"
+			}
+			value := &flagValue{text: f.String(), isBool: f.value.Kind() == reflect.Bool}
+			flags.Var(value, f.flagName(), usage)
+			flagValues[f.flagName()] = &value.text
+		}
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []error
+	if configFile != "" {
+		if err := config.loadFile(configFile, fields); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, f := range fields {
+		if value, ok := os.LookupEnv(f.env); ok && f.env != "" {
+			if err := f.set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", f.env, err))
+			}
+		}
+	}
+
+	if flags != nil {
+		flags.Visit(func(visited *flag.Flag) {
+			value, ok := flagValues[visited.Name]
+			if !ok {
+				return
+			}
+			index := slices.IndexFunc(fields, func(f field) bool { return f.flagName() == visited.Name })
+			if err := fields[index].set(*value); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", visited.Name, err))
+			}
+		})
+	}
+
+	if len(errs) == 0 {
+		errs = append(errs, config.Validate())
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return config, nil
+}
+
+// loadFile sets values found in the YAML file. Unknown keys are rejected,
+// so misspelled keys do not go unnoticed.
+func (c *Config) loadFile(path string, fields []field) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return fmt.Errorf("failed to parse configuration file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", document, values)
+
+	var errs []error
+	for _, f := range fields {
+		value, ok := values[f.key]
+		if !ok {
+			continue
+		}
+		delete(values, f.key)
+		if err := f.set(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", path, f.key, err))
+		}
+	}
+	for key := range values {
+		errs = append(errs, fmt.Errorf("%s: unknown key %s", path, key))
+	}
+	return errors.Join(errs...)
+}
+
+// flatten converts nested YAML mappings to values keyed by dotted paths.
+// Sequences are joined by commas.
+func flatten(prefix string, document map[string]any, values map[string]string) {
+	for key, value := range document {
+		switch typed := value.(type) {
+		case map[string]any:
+			flatten(prefix+key+".", typed, values)
+		case []any:
+			items := make([]string, len(typed))
+			for i, item := range typed {
+				items[i] = fmt.Sprint(item)
+			}
+			values[prefix+key] = strings.Join(items, ",")
+		case nil:
+			values[prefix+key] = ""
+		default:
+			values[prefix+key] = fmt.Sprint(typed)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes the YAML configuration to a temporary file
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func load(args ...string) (*Config, error) {
+	flags := flag.NewFlagSet("mdm-api-service", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return Load(flags, args)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeConfigFile(t, `
+server:
+  port: 8000
+  shutdownTimeout: 1m
+mongodb:
+  host: mongo.file
+  database: file-db
+events:
+  kafka:
+    brokers: [kafka-1:9092, kafka-2:9092]
+`)
+
+	cases := []struct {
+		name   string
+		env    map[string]string
+		args   []string
+		verify func(config *Config) bool
+	}{
+		{"defaults", nil, nil, func(config *Config) bool {
+			return config.Server.Port == 8080 && config.MongoDB.Host == "localhost" && config.MongoDB.Database == "mdm-patient-management"
+		}},
+		{"file by flag", nil, []string{"-config", file}, func(config *Config) bool {
+			return config.Server.Port == 8000 && config.Server.ShutdownTimeout == time.Minute &&
+				config.MongoDB.Host == "mongo.file" && strings.Join(config.Events.Kafka.Brokers, ",") == "kafka-1:9092,kafka-2:9092" &&
+				config.Server.GrpcPort == 9090
+		}},
+		{"file by environment", map[string]string{EnvConfigFile: file}, nil, func(config *Config) bool {
+			return config.Server.Port == 8000 && config.MongoDB.Database == "file-db"
+		}},
+		{"environment overrides file", map[string]string{
+			EnvConfigFile:                      file,
+			"MDM_API_PORT":                     "8001",
+			"MDM_API_SHUTDOWN_TIMEOUT_SECONDS": "30",
+			"MDM_API_KAFKA_BROKERS":            "kafka-3:9092",
+		}, nil, func(config *Config) bool {
+			return config.Server.Port == 8001 && config.Server.ShutdownTimeout == 30*time.Second &&
+				strings.Join(config.Events.Kafka.Brokers, ",") == "kafka-3:9092" && config.MongoDB.Host == "mongo.file"
+		}},
+		{"flags override environment and file", map[string]string{
+			EnvConfigFile:          file,
+			"MDM_API_PORT":         "8001",
+			"MDM_API_MONGODB_HOST": "mongo.env",
+		}, []string{"-server-port", "8002", "-server-shutdown-timeout", "45s", "-mongodb-migrate=false"}, func(config *Config) bool {
+			return config.Server.Port == 8002 && config.Server.ShutdownTimeout == 45*time.Second &&
+				config.MongoDB.Host == "mongo.env" && !config.MongoDB.Migrate
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for key, value := range c.env {
+				t.Setenv(key, value)
+			}
+			config, err := load(c.args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !c.verify(config) {
+				t.Fatalf("configuration %+v", config)
+			}
+		})
+	}
+}
+
+func TestLoadFailsFast(t *testing.T) {
+	cases := []struct {
+		name   string
+		file   string
+		env    map[string]string
+		args   []string
+		errors []string
+	}{
+		{"missing file", "", nil, []string{"-config", "/nonexistent/config.yaml"}, []string{"failed to read configuration file"}},
+		{"unknown key", "server:\n  prot: 8000\n", nil, nil, []string{"unknown key server.prot"}},
+		{"invalid file value", "server:\n  port: eighty\n", nil, nil, []string{`server.port: "eighty" is not an integer`}},
+		{"invalid environment value", "", map[string]string{"MDM_API_MONGODB_TIMEOUT_SECONDS": "soon"}, nil,
+			[]string{`MDM_API_MONGODB_TIMEOUT_SECONDS: "soon" is neither number of seconds nor duration`}},
+		{"invalid flag value", "", nil, []string{"-mongodb-srv=maybe"}, []string{`-mongodb-srv: "maybe" is not a boolean`}},
+		{"port out of range", "", map[string]string{"MDM_API_PORT": "70000"}, nil, []string{"server.port: port 70000 is out of range 1-65535"}},
+		{"same ports", "", nil, []string{"-server-grpc-port", "8080"}, []string{"server.grpcPort: must differ from server.port"}},
+		{"password without user", "", map[string]string{"MDM_API_MONGODB_PASSWORD": "secret"}, nil, []string{"mongodb.password: is set without mongodb.username"}},
+		{"all invalid values at once", "log:\n  level: verbose\nearlyWarning:\n  threshold: 21\n", nil, []string{"-mongodb-uri", "localhost:27017"},
+			[]string{`log.level: "verbose" is not one of`, "earlyWarning.threshold: 21 is out of range 1-20", "mongodb.uri: must start with mongodb://"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.file != "" {
+				t.Setenv(EnvConfigFile, writeConfigFile(t, c.file))
+			}
+			for key, value := range c.env {
+				t.Setenv(key, value)
+			}
+			config, err := load(c.args...)
+			if err == nil {
+				t.Fatalf("configuration %+v loaded, want error", config)
+			}
+			for _, message := range c.errors {
+				if !strings.Contains(err.Error(), message) {
+					t.Errorf("error %q does not contain %q", err, message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// Masked replaces values of secrets when the configuration is printed
+const Masked = "********"
+
+// Print writes the effective configuration as YAML file with masked secrets,
+// together with the environment variables setting the values
+func (c *Config) Print(w io.Writer) error {
+	var section []string
+	for _, f := range c.fields() {
+		path := strings.Split(f.key, ".")
+		common := 0
+		for common < len(section) && common < len(path)-1 && section[common] == path[common] {
+			common++
+		}
+		for i := common; i < len(path)-1; i++ {
+			if _, err := fmt.Fprintf(w, "%s%s:\n", strings.Repeat("  ", i), path[i]); err != nil {
+				return err
+			}
+		}
+		section = path[:len(path)-1]
+
+		line := fmt.Sprintf("%s%s: %s", strings.Repeat("  ", len(section)), path[len(path)-1], f.yaml())
+		if f.env != "" {
+			line += " # " + f.env
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogValue logs the effective configuration with masked secrets
+func (c *Config) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0)
+	for _, f := range c.fields() {
+		attrs = append(attrs, slog.String(f.key, f.masked()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// yaml formats the masked value, so the printed configuration can be loaded back
+func (f field) yaml() string {
+	switch value := f.value.Interface().(type) {
+	case string:
+		return strconv.Quote(f.masked())
+	case []string:
+		quoted := make([]string, len(value))
+		for i, item := range value {
+			quoted[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return f.masked()
+	}
+}
+
+func (f field) masked() string {
+	switch {
+	case f.secret == "" || f.value.IsZero():
+		return f.String()
+	case f.secret == "uri":
+		return db_service.RedactUri(f.String())
+	default:
+		return Masked
+	}
+}
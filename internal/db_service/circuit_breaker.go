@@ -0,0 +1,163 @@
+package db_service
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is matched by errors returned when the database cannot be
+// reached, either because the circuit breaker is open or the retries of
+// a transient failure were exhausted
+var ErrUnavailable = fmt.Errorf("database is temporarily unavailable")
+
+// UnavailableError tells the caller when to try again
+type UnavailableError struct {
+	RetryAfter time.Duration
+	// the last failure, nil when the circuit breaker rejected the operation
+	Err error
+}
+
+func (e *UnavailableError) Error() string {
+	if e.Err == nil {
+		return ErrUnavailable.Error()
+	}
+	return ErrUnavailable.Error() + ": " + e.Err.Error()
+}
+
+func (e *UnavailableError) Is(target error) bool {
+	return target == ErrUnavailable
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+type CircuitBreakerConfig struct {
+	// consecutive failures opening the circuit
+	FailureThreshold int
+	// time the circuit stays open before a probe operation is let through
+	OpenTimeout time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker fails operations fast once the database failed repeatedly,
+// so requests do not pile up waiting for timeouts during an outage. After
+// the open timeout a single probe operation is let through, closing the
+// circuit on success. Services of collections on the same server should
+// share one breaker.
+type CircuitBreaker struct {
+	CircuitBreakerConfig
+	lock     sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	b := &CircuitBreaker{CircuitBreakerConfig: config, now: time.Now}
+	if b.FailureThreshold == 0 {
+		b.FailureThreshold = 5
+	}
+	if b.OpenTimeout == 0 {
+		b.OpenTimeout = 30 * time.Second
+	}
+	return b
+}
+
+// Allow returns *UnavailableError if the operation must not be attempted
+func (b *CircuitBreaker) Allow() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if elapsed := b.now().Sub(b.openedAt); elapsed < b.OpenTimeout {
+			return &UnavailableError{RetryAfter: b.OpenTimeout - elapsed}
+		}
+		b.transition(circuitHalfOpen)
+		b.probing = true
+		return nil
+	case circuitHalfOpen:
+		if b.probing {
+			return &UnavailableError{RetryAfter: time.Second}
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record counts outcome of the allowed operation. Only failures of the
+// database itself count, see IsRetryable, not errors like ErrNotFound.
+func (b *CircuitBreaker) Record(err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	failed := err != nil && IsRetryable(err)
+	if b.state == circuitHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.transition(circuitClosed)
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == circuitClosed && b.failures >= b.FailureThreshold {
+		b.open()
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.now()
+	b.transition(circuitOpen)
+}
+
+func (b *CircuitBreaker) transition(state circuitState) {
+	if b.state == state {
+		return
+	}
+	slog.Warn("Database circuit breaker changed state", "from", b.state.String(), "to", state.String())
+	b.state = state
+}
+
+// IsRetryable reports whether the error is a transient failure of the
+// database, e.g. network error or election of a new primary, after which
+// the operation may succeed
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrUnavailable) {
+		return false
+	}
+	return isTransientMongoError(err)
+}
@@ -0,0 +1,250 @@
+package db_service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Index describes an index of the collection. Keys are names of the document
+// fields as stored in the database, several keys make a compound index. The
+// index is ascending on a key unless the key is prefixed by "-".
+type Index struct {
+	Name   string
+	Keys   []string
+	Unique bool
+	// documents expire once the time elapses after the date stored in the
+	// only key, documents without the date are kept
+	ExpireAfter time.Duration
+	// only documents matching the filter are indexed, e.g. so that unique
+	// index ignores documents without the value
+	PartialFilter bson.D
+}
+
+// OutboxIndexes are indexes of the outbox collection. The relay claims the
+// oldest messages neither published nor dead-lettered, the published messages
+// expire after the retention.
+func OutboxIndexes(retention time.Duration) []Index {
+	return []Index{
+		{Name: "id", Keys: []string{"id"}, Unique: true},
+		{Name: "publishedat", Keys: []string{"publishedat"}, ExpireAfter: retention},
+		{Name: "pending", Keys: []string{"publishedat", "deadletteredat", "createdat"}},
+	}
+}
+
+// ConflictError is returned when a write violates unique index, e.g. of
+// a business key of the document. It matches ErrConflict.
+type ConflictError struct {
+	Index string
+	// fields of the index as stored in the database
+	Fields []string
+	// an outbox message of the write is already stored, as when the write
+	// is repeated after its response was lost
+	Outbox bool
+}
+
+func (e *ConflictError) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("conflict: duplicate key of index %s", e.Index)
+	}
+	return fmt.Sprintf("conflict: duplicate value of %s", strings.Join(e.Fields, ", "))
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Field returns the first field of the violated index
+func (e *ConflictError) Field() string {
+	if len(e.Fields) == 0 {
+		return e.Index
+	}
+	return e.Fields[0]
+}
+
+var duplicateKeyIndex = regexp.MustCompile(`index: (\S+) dup key`)
+
+// conflictError translates duplicate key error of the server to
+// *ConflictError naming the violated index, other errors are returned as is
+func conflictError(err error) error {
+	if err == nil || !mongo.IsDuplicateKeyError(err) {
+		return err
+	}
+
+	conflict := &ConflictError{}
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		for _, writeError := range writeErr.WriteErrors {
+			if keyPattern, ok := writeError.Raw.Lookup("keyPattern").DocumentOK(); ok {
+				elements, _ := keyPattern.Elements()
+				for _, element := range elements {
+					conflict.Fields = append(conflict.Fields, element.Key())
+				}
+			}
+		}
+	}
+	if match := duplicateKeyIndex.FindStringSubmatch(err.Error()); match != nil {
+		conflict.Index = match[1]
+	}
+	return conflict
+}
+
+// EnsureIndexes creates the indexes missing in the collection and recreates
+// those whose definition changed. Existing index on the same keys under
+// another name is replaced, other indexes are left untouched.
+func (m *mongoSvc[DocType]) EnsureIndexes(ctx context.Context, indexes ...Index) (err error) {
+	ctx, span := m.startSpan(ctx, "createIndexes", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	collection := client.Database(m.DbName).Collection(m.Collection)
+	// the collection does not exist until the first document is stored
+	var existing []existingIndex
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil && !isNamespaceNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if err := cursor.All(ctx, &existing); err != nil {
+			return err
+		}
+	}
+
+	models := make([]mongo.IndexModel, 0, len(indexes))
+	for _, index := range indexes {
+		model := index.model()
+		current := existingIndex{}
+		for _, other := range existing {
+			if other.Name == index.Name || (other.Name != "_id_" && equalKeys(other.Key, model.Keys.(bson.D))) {
+				current = other
+				break
+			}
+		}
+
+		if current.Name != "" {
+			if current.Name == index.Name && current.matches(index, model) {
+				continue
+			}
+			slog.InfoContext(ctx, "Replacing index", "collection", m.Collection, "index", current.Name, "by", index.Name)
+			if _, err := collection.Indexes().DropOne(ctx, current.Name); err != nil {
+				return fmt.Errorf("failed to drop index %s: %w", current.Name, err)
+			}
+		}
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		return nil
+	}
+
+	if _, err := collection.Indexes().CreateMany(ctx, models); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("unique index of %s cannot be created, the collection has duplicate values: %w", m.Collection, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (index Index) model() mongo.IndexModel {
+	keys := bson.D{}
+	for _, key := range index.Keys {
+		if field, descending := strings.CutPrefix(key, "-"); descending {
+			keys = append(keys, bson.E{Key: field, Value: -1})
+		} else {
+			keys = append(keys, bson.E{Key: key, Value: 1})
+		}
+	}
+
+	indexOptions := options.Index().SetName(index.Name)
+	if index.Unique {
+		indexOptions.SetUnique(true)
+	}
+	if index.ExpireAfter > 0 {
+		indexOptions.SetExpireAfterSeconds(int32(index.ExpireAfter.Seconds()))
+	}
+	if index.PartialFilter != nil {
+		indexOptions.SetPartialFilterExpression(index.PartialFilter)
+	}
+	return mongo.IndexModel{Keys: keys, Options: indexOptions}
+}
+
+// existingIndex is the index as listed by the server
+type existingIndex struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.D   `bson:"key"`
+	Unique                  bool     `bson:"unique"`
+	ExpireAfterSeconds      *float64 `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+}
+
+func (e existingIndex) matches(index Index, model mongo.IndexModel) bool {
+	if !equalKeys(e.Key, model.Keys.(bson.D)) || e.Unique != index.Unique {
+		return false
+	}
+
+	expireAfter := time.Duration(0)
+	if e.ExpireAfterSeconds != nil {
+		expireAfter = time.Duration(*e.ExpireAfterSeconds) * time.Second
+	}
+	if expireAfter != index.ExpireAfter.Truncate(time.Second) {
+		return false
+	}
+
+	var filter []byte
+	if index.PartialFilter != nil {
+		filter, _ = bson.Marshal(index.PartialFilter)
+	}
+	return bytes.Equal(e.PartialFilterExpression, filter)
+}
+
+// equalKeys compares keys of the indexes, the server may list the directions
+// as integers or doubles
+func equalKeys(a, b bson.D) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || direction(a[i].Value) != direction(b[i].Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func direction(value interface{}) float64 {
+	switch v := value.(type) {
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return 0
+	}
+}
+
+func isNamespaceNotFound(err error) bool {
+	var commandErr mongo.CommandError
+	return errors.As(err, &commandErr) && commandErr.Code == 26
+}
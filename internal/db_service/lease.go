@@ -0,0 +1,131 @@
+package db_service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// LeaseService claims documents to be processed by a lease, so workers of
+// several replicas of the service do not process the same document, like
+// OutboxService does for the outbox messages. The lease is held in a time
+// field of the documents, e.g. time of the next attempt of a delivery, so a
+// document is due again once its lease expires, and the claiming worker is
+// stored in the leaseowner field.
+type LeaseService[DocType interface{}] interface {
+	DbService[DocType]
+	// ClaimDocuments leases at most limit documents matching the filter
+	// whose lease expired to the owner for the duration, ordered by the
+	// lease time
+	ClaimDocuments(ctx context.Context, filter bson.M, owner string, limit int, lease time.Duration) ([]DocType, error)
+	// ReleaseDocument stores the document claimed by the owner, which sets
+	// the outcome of the processing and ends or extends the lease. The owner
+	// of the document is kept, so the release can be repeated. ErrNotFound is
+	// returned when the document was claimed by another owner meanwhile.
+	ReleaseDocument(ctx context.Context, owner string, id string, document *DocType) error
+}
+
+type leaseSvc[DocType interface{}] struct {
+	*mongoSvc[DocType]
+	leaseField string
+}
+
+// NewLeaseService returns service of the collection using the shared client,
+// which holds the leases in the lease field
+func NewLeaseService[DocType interface{}](client *MongoClient, collection string, leaseField string) LeaseService[DocType] {
+	return &leaseSvc[DocType]{
+		mongoSvc:   NewCollectionService[DocType](client, collection).(*mongoSvc[DocType]),
+		leaseField: leaseField,
+	}
+}
+
+// claimable matches documents of the filter whose lease expired
+func (m *leaseSvc[DocType]) claimable(filter bson.M, now time.Time) bson.D {
+	claimable := bson.D{{Key: m.leaseField, Value: bson.D{{Key: "$lte", Value: now}}}}
+	for key, value := range filter {
+		claimable = append(claimable, bson.E{Key: key, Value: value})
+	}
+	return claimable
+}
+
+func (m *leaseSvc[DocType]) ClaimDocuments(ctx context.Context, filter bson.M, owner string, limit int, lease time.Duration) (_ []DocType, err error) {
+	ctx, span := m.startSpan(ctx, "claim", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection := client.Database(m.DbName).Collection(m.Collection)
+
+	now := time.Now()
+	cursor, err := collection.Find(ctx, m.claimable(filter, now), options.Find().
+		SetSort(bson.D{{Key: m.leaseField, Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		Id string `bson:"id"`
+	}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []DocType{}, nil
+	}
+	ids := make(bson.A, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.Id
+	}
+
+	// candidates claimed meanwhile by another owner no longer match, the
+	// lease time stored in milliseconds identifies this claim
+	leaseUntil := now.Add(lease).Truncate(time.Millisecond)
+	claimFilter := append(m.claimable(filter, now), bson.E{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}})
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "leaseowner", Value: owner}, {Key: m.leaseField, Value: leaseUntil}}}}
+	if _, err := collection.UpdateMany(ctx, claimFilter, update); err != nil {
+		return nil, err
+	}
+
+	cursor, err = collection.Find(ctx, bson.D{
+		{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "leaseowner", Value: owner},
+		{Key: m.leaseField, Value: leaseUntil},
+	}, options.Find().SetSort(bson.D{{Key: m.leaseField, Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	documents := []DocType{}
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (m *leaseSvc[DocType]) ReleaseDocument(ctx context.Context, owner string, id string, document *DocType) (err error) {
+	ctx, span := m.startSpan(ctx, "release", id)
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	// fields of the document are set, so the lease owner is kept
+	result, err := client.Database(m.DbName).Collection(m.Collection).
+		UpdateOne(ctx, bson.D{{Key: "id", Value: id}, {Key: "leaseowner", Value: owner}}, bson.D{{Key: "$set", Value: document}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
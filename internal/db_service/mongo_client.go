@@ -0,0 +1,187 @@
+package db_service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// MongoClient is connection to the database shared by services of several
+// collections, so they use one connection pool and can write documents of
+// different collections in one transaction. The connection is opened lazily
+// on first use and closed by Disconnect.
+type MongoClient struct {
+	config     MongoServiceConfig
+	client     atomic.Pointer[mongo.Client]
+	clientLock sync.Mutex
+	// set once the server rejected a transaction, e.g. standalone server without replica set
+	transactionsUnsupported atomic.Bool
+}
+
+// NewMongoClient returns client of the database. Empty fields of the
+// configuration are set to defaults, the collection is not used.
+func NewMongoClient(config MongoServiceConfig) *MongoClient {
+	if config.ServerHost == "" {
+		config.ServerHost = "localhost"
+	}
+
+	if config.ServerPort == 0 {
+		config.ServerPort = 27017
+	}
+
+	if config.DbName == "" {
+		config.DbName = "mdm-patient-management"
+	}
+
+	if config.Collection == "" {
+		config.Collection = "patients"
+	}
+
+	if config.OutboxCollection == "" {
+		config.OutboxCollection = "outbox"
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	slog.Debug("MongoDB config",
+		"uri", RedactUri(config.uri()),
+		"database", config.DbName,
+		"authenticated", config.UserName != "",
+	)
+	return &MongoClient{config: config}
+}
+
+func (c *MongoClient) connect(ctx context.Context) (*mongo.Client, error) {
+	// optimistic check
+	client := c.client.Load()
+	if client != nil {
+		return client, nil
+	}
+
+	c.clientLock.Lock()
+	defer c.clientLock.Unlock()
+	// pesimistic check
+	client = c.client.Load()
+	if client != nil {
+		return client, nil
+	}
+
+	ctx, contextCancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer contextCancel()
+
+	clientOptions, err := c.config.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Connecting to MongoDB", "uri", RedactUri(c.config.uri()))
+
+	if client, err := mongo.Connect(ctx, clientOptions.SetConnectTimeout(10*time.Second)); err != nil {
+		return nil, err
+	} else {
+		c.client.Store(client)
+		return client, nil
+	}
+}
+
+// Database returns the database for operations not covered by DbService,
+// e.g. schema migrations
+func (c *MongoClient) Database(ctx context.Context) (*mongo.Database, error) {
+	client, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.Database(c.config.DbName), nil
+}
+
+// Ping connects to the server if not connected yet and verifies it responds
+func (c *MongoClient) Ping(ctx context.Context) error {
+	ctx, contextCancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer contextCancel()
+	client, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	return client.Ping(ctx, readpref.Primary())
+}
+
+// Disconnect closes connections of all the services sharing the client,
+// a later operation connects again
+func (c *MongoClient) Disconnect(ctx context.Context) error {
+	client := c.client.Load()
+
+	if client != nil {
+		c.clientLock.Lock()
+		defer c.clientLock.Unlock()
+
+		client = c.client.Load()
+		defer c.client.Store(nil)
+		if client != nil {
+			if err := client.Disconnect(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TransactionRunner runs functions in one transaction, it is implemented by
+// MongoClient
+type TransactionRunner interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WithTransaction runs the function in one transaction. Operations of the
+// services sharing the client join the transaction when called with the
+// context passed to the function, so changes of several collections,
+// including their outbox messages, are committed or aborted together.
+//
+// The function is retried on transient errors, so it must not have side
+// effects outside of the database. The transaction including the retries
+// must complete within the timeout of the client. Nested calls join the
+// outer transaction.
+// Servers without transaction support (standalone deployments) run the
+// function without transaction.
+func (c *MongoClient) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if mongo.SessionFromContext(ctx) != nil || c.transactionsUnsupported.Load() {
+		return fn(ctx)
+	}
+
+	ctx, contextCancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer contextCancel()
+
+	client, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	var lastErr error
+	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (interface{}, error) {
+		// the driver retries transient errors, e.g. of unreachable server, for
+		// two minutes regardless of the context, so the retries are stopped
+		// by an error without the transient label
+		if err := sessionCtx.Err(); err != nil {
+			return nil, fmt.Errorf("transaction failed: %w, last error: %v", err, lastErr)
+		}
+		lastErr = fn(sessionCtx)
+		return nil, lastErr
+	})
+	if !isTransactionUnsupported(err) {
+		return err
+	}
+	slog.WarnContext(ctx, "MongoDB server does not support transactions, writes are done without transaction")
+	c.transactionsUnsupported.Store(true)
+	return fn(ctx)
+}
@@ -0,0 +1,147 @@
+package db_service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// uri returns the configured connection string or builds it from the parts,
+// escaping the credentials, so they may contain any characters
+func (c MongoServiceConfig) uri() string {
+	if c.Uri != "" {
+		return c.Uri
+	}
+
+	uri := url.URL{Scheme: "mongodb", Host: net.JoinHostPort(c.ServerHost, strconv.Itoa(c.ServerPort)), Path: "/"}
+	if c.Srv {
+		uri.Scheme = "mongodb+srv"
+		uri.Host = c.ServerHost
+	}
+	if c.UserName != "" {
+		uri.User = url.UserPassword(c.UserName, c.Password)
+	}
+	return uri.String()
+}
+
+// clientOptions returns options of the client connecting to the server.
+// Options given explicitly in the configuration override those of the URI.
+func (c MongoServiceConfig) clientOptions() (*options.ClientOptions, error) {
+	clientOptions := options.Client().ApplyURI(c.uri())
+	if err := clientOptions.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to apply MongoDB connection string %s: %w", RedactUri(c.uri()), err)
+	}
+
+	if c.Uri != "" && c.UserName != "" {
+		credential := options.Credential{}
+		if clientOptions.Auth != nil {
+			credential = *clientOptions.Auth
+		}
+		credential.Username = c.UserName
+		credential.Password = c.Password
+		credential.PasswordSet = true
+		clientOptions.SetAuth(credential)
+	}
+	if c.AuthSource != "" && clientOptions.Auth != nil {
+		clientOptions.Auth.AuthSource = c.AuthSource
+	}
+	if c.ReplicaSet != "" {
+		clientOptions.SetReplicaSet(c.ReplicaSet)
+	}
+
+	if c.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(c.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		readPreference, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetReadPreference(readPreference)
+	}
+
+	if c.Tls || c.TlsCaFile != "" || c.TlsCertificateKeyFile != "" || c.TlsInsecure {
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+
+	if c.MaxPoolSize != 0 {
+		clientOptions.SetMaxPoolSize(c.MaxPoolSize)
+	}
+	if c.MinPoolSize != 0 {
+		clientOptions.SetMinPoolSize(c.MinPoolSize)
+	}
+	if c.MaxConnIdleTime != 0 {
+		clientOptions.SetMaxConnIdleTime(c.MaxConnIdleTime)
+	}
+	return clientOptions, nil
+}
+
+func (c MongoServiceConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.TlsInsecure,
+	}
+
+	if c.TlsCaFile != "" {
+		pem, err := os.ReadFile(c.TlsCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read MongoDB CA file: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in MongoDB CA file %s", c.TlsCaFile)
+		}
+	}
+
+	if c.TlsCertificateKeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.TlsCertificateKeyFile, c.TlsCertificateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load MongoDB client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// RedactUri hides password in the connection string, so it can be logged.
+// Strings which are not connection strings are hidden entirely.
+func RedactUri(uri string) string {
+	scheme, rest, ok := strings.Cut(uri, "://")
+	if !ok {
+		return "[REDACTED]"
+	}
+
+	hosts, query, hasQuery := strings.Cut(rest, "?")
+	if at := strings.LastIndex(hosts, "@"); at >= 0 {
+		user, _, _ := strings.Cut(hosts[:at], ":")
+		hosts = user + ":xxxxx" + hosts[at:]
+	}
+	redacted := scheme + "://" + hosts
+	if hasQuery {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return redacted + "?[REDACTED]"
+		}
+		for key := range values {
+			// e.g. AWS session token in authMechanismProperties
+			if strings.EqualFold(key, "authMechanismProperties") || strings.Contains(strings.ToLower(key), "password") {
+				values.Set(key, "xxxxx")
+			}
+		}
+		redacted += "?" + values.Encode()
+	}
+	return redacted
+}
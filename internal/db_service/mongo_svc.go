@@ -2,17 +2,14 @@ package db_service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"sync"
-	"sync/atomic"
+	"log/slog"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 type DbService[DocType interface{}] interface {
@@ -20,31 +17,64 @@ type DbService[DocType interface{}] interface {
 	FindAllDocuments(ctx context.Context) ([]DocType, error) 
 	FindDocument(ctx context.Context, id string) (*DocType, error)
 	FindDocumentsByCondition(ctx context.Context, filter bson.M) ([]DocType, error)
+	// CountDocumentsByField counts the documents by the values of the field
+	// as stored in the database, documents without the value are counted
+	// under the empty string
+	CountDocumentsByField(ctx context.Context, field string) (map[string]int, error)
 	UpdateDocument(ctx context.Context, id string, document *DocType) error
 	DeleteDocument(ctx context.Context, id string) error
+	WatchInsertedDocuments(ctx context.Context) (<-chan DocType, error)
+	EnsureIndexes(ctx context.Context, indexes ...Index) error
+	Ping(ctx context.Context) error
 	Disconnect(ctx context.Context) error
 }
 
 var ErrNotFound = fmt.Errorf("document not found")
 var ErrConflict = fmt.Errorf("conflict: document already exists")
+var ErrWatchUnsupported = fmt.Errorf("change streams are not supported by the server")
 
 type MongoServiceConfig struct {
+	// connection string, when set the server host and port are not used and
+	// the user name and password override credentials of the URI if set
+	Uri        string
 	ServerHost string
 	ServerPort int
+	// host is resolved by DNS SRV record, the port is not used
+	Srv        bool
 	UserName   string
 	Password   string
-	DbName     string
-	Collection string
-	Timeout    time.Duration
+	AuthSource string
+	ReplicaSet string
+	// primary, primaryPreferred, secondary, secondaryPreferred or nearest
+	ReadPreference string
+	Tls            bool
+	// PEM file with certificate authorities verifying the server
+	TlsCaFile string
+	// PEM file with client certificate and private key
+	TlsCertificateKeyFile string
+	// skips verification of the server certificate, for development only
+	TlsInsecure     bool
+	MaxPoolSize     uint64
+	MinPoolSize     uint64
+	MaxConnIdleTime time.Duration
+	DbName          string
+	Collection      string
+	Timeout         time.Duration
+	// collection receiving outbox messages attached to the context of write operations
+	OutboxCollection string
 }
 
 type mongoSvc[DocType interface{}] struct {
 	MongoServiceConfig
-	client     atomic.Pointer[mongo.Client]
-	clientLock sync.Mutex
+	client *MongoClient
+	// the client is disconnected with the service unless it is shared
+	ownsClient bool
 }
 
-func (m *mongoSvc[DocType]) FindDocumentsByCondition(ctx context.Context, filter bson.M) ([]DocType, error) {
+func (m *mongoSvc[DocType]) FindDocumentsByCondition(ctx context.Context, filter bson.M) (_ []DocType, err error) {
+	ctx, span := m.startSpan(ctx, "find", "")
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	
@@ -77,120 +107,101 @@ func (m *mongoSvc[DocType]) FindDocumentsByCondition(ctx context.Context, filter
 	return documents, nil
 }
 
-func NewMongoService[DocType interface{}](config MongoServiceConfig) DbService[DocType] {
-	enviro := func(name string, defaultValue string) string {
-		if value, ok := os.LookupEnv(name); ok {
-			return value
-		}
-		return defaultValue
-	}
+func (m *mongoSvc[DocType]) CountDocumentsByField(ctx context.Context, field string) (_ map[string]int, err error) {
+	ctx, span := m.startSpan(ctx, "aggregate", "")
+	defer func() { endSpan(span, err) }()
 
-	svc := &mongoSvc[DocType]{}
-	svc.MongoServiceConfig = config
-
-	if svc.ServerHost == "" {
-		svc.ServerHost = enviro("MDM_API_MONGODB_HOST", "localhost")
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	if svc.ServerPort == 0 {
-		port := enviro("MDM_API_MONGODB_PORT", "27017")
-		if port, err := strconv.Atoi(port); err == nil {
-			svc.ServerPort = port
-		} else {
-			log.Printf("Invalid port value: %v", port)
-			svc.ServerPort = 27017
-		}
+	// the server counts, so only the distinct values are transferred
+	cursor, err := client.Database(m.DbName).Collection(m.Collection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$" + field, ""}}}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	if svc.UserName == "" {
-		svc.UserName = enviro("MDM_API_MONGODB_USERNAME", "")
+	var groups []struct {
+		Value interface{} `bson:"_id"`
+		Count int         `bson:"count"`
 	}
-
-	if svc.Password == "" {
-		svc.Password = enviro("MDM_API_MONGODB_PASSWORD", "")
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, err
 	}
-
-	if svc.DbName == "" {
-		svc.DbName = enviro("MDM_API_MONGODB_DATABASE", "mdm-patient-management")
+	counts := make(map[string]int, len(groups))
+	for _, group := range groups {
+		value, ok := group.Value.(string)
+		if !ok {
+			value = fmt.Sprint(group.Value)
+		}
+		counts[value] += group.Count
 	}
+	return counts, nil
+}
 
-	if svc.Collection == "" {
-		svc.Collection = enviro("MDM_API_MONGODB_COLLECTION", "patients")
-	}
+// NewMongoService returns service of the collection with its own client.
+// Empty fields of the configuration are set to defaults, the values are
+// provided by the config package.
+func NewMongoService[DocType interface{}](config MongoServiceConfig) DbService[DocType] {
+	svc := NewCollectionService[DocType](NewMongoClient(config), config.Collection).(*mongoSvc[DocType])
+	svc.ownsClient = true
+	return svc
+}
 
-	if svc.Timeout == 0 {
-		seconds := enviro("MDM_API_MONGODB_TIMEOUT_SECONDS", "10")
-		if seconds, err := strconv.Atoi(seconds); err == nil {
-			svc.Timeout = time.Duration(seconds) * time.Second
-		} else {
-			log.Printf("Invalid timeout value: %v", seconds)
-			svc.Timeout = 10 * time.Second
-		}
+// NewCollectionService returns service of the collection using the shared
+// client. Disconnecting the service leaves the client connected.
+func NewCollectionService[DocType interface{}](client *MongoClient, collection string) DbService[DocType] {
+	svc := &mongoSvc[DocType]{client: client}
+	svc.MongoServiceConfig = client.config
+	if collection != "" {
+		svc.Collection = collection
 	}
 
-	log.Printf(
-		"MongoDB config: //%v@%v:%v/%v/%v",
-		svc.UserName,
-		svc.ServerHost,
-		svc.ServerPort,
-		svc.DbName,
-		svc.Collection,
+	slog.Debug("MongoDB collection",
+		"database", svc.DbName,
+		"collection", svc.Collection,
 	)
 	return svc
 }
 
 func (m *mongoSvc[DocType]) connect(ctx context.Context) (*mongo.Client, error) {
-	// optimistic check
-	client := m.client.Load()
-	if client != nil {
-		return client, nil
-	}
+	return m.client.connect(ctx)
+}
 
-	m.clientLock.Lock()
-	defer m.clientLock.Unlock()
-	// pesimistic check
-	client = m.client.Load()
-	if client != nil {
-		return client, nil
-	}
+// Ping connects to the server if not connected yet and verifies it responds
+func (m *mongoSvc[DocType]) Ping(ctx context.Context) (err error) {
+	ctx, span := m.startSpan(ctx, "ping", "")
+	defer func() { endSpan(span, err) }()
 
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
-
-	var uri = fmt.Sprintf("mongodb://%v:%v", m.ServerHost, m.ServerPort)
-	log.Printf("%s", "Using URI: "+uri)
-
-	if len(m.UserName) != 0 {
-		uri = fmt.Sprintf("mongodb://%v:%v@%v:%v", m.UserName, m.Password, m.ServerHost, m.ServerPort)
-	}
-
-	if client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetConnectTimeout(10*time.Second)); err != nil {
-		return nil, err
-	} else {
-		m.client.Store(client)
-		return client, nil
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
 	}
+	return client.Ping(ctx, readpref.Primary())
 }
 
 func (m *mongoSvc[DocType]) Disconnect(ctx context.Context) error {
-	client := m.client.Load()
-
-	if client != nil {
-		m.clientLock.Lock()
-		defer m.clientLock.Unlock()
-
-		client = m.client.Load()
-		defer m.client.Store(nil)
-		if client != nil {
-			if err := client.Disconnect(ctx); err != nil {
-				return err
-			}
-		}
+	if !m.ownsClient {
+		return nil
 	}
-	return nil
+	return m.client.Disconnect(ctx)
 }
 
-func (m *mongoSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+func (m *mongoSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) (err error) {
+	ctx, span := m.startSpan(ctx, "insert", id)
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	client, err := m.connect(ctx)
@@ -199,21 +210,26 @@ func (m *mongoSvc[DocType]) CreateDocument(ctx context.Context, id string, docum
 	}
 	db := client.Database(m.DbName)
 	collection := db.Collection(m.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
-	switch result.Err() {
-	case nil: // no error means there is conflicting document
-		return ErrConflict
-	case mongo.ErrNoDocuments:
-		// do nothing, this is expected
-	default: // other errors - return them
-		return result.Err()
-	}
+	return m.withOutbox(ctx, client, func(ctx context.Context) error {
+		result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+		switch result.Err() {
+		case nil: // no error means there is conflicting document
+			return ErrConflict
+		case mongo.ErrNoDocuments:
+			// do nothing, this is expected
+		default: // other errors - return them
+			return result.Err()
+		}
 
-	_, err = collection.InsertOne(ctx, document)
-	return err
+		_, err := collection.InsertOne(ctx, document)
+		return conflictError(err)
+	})
 }
 
-func (m *mongoSvc[DocType]) FindAllDocuments(ctx context.Context) ([]DocType, error) {
+func (m *mongoSvc[DocType]) FindAllDocuments(ctx context.Context) (_ []DocType, err error) {
+	ctx, span := m.startSpan(ctx, "find", "")
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	
@@ -246,7 +262,10 @@ func (m *mongoSvc[DocType]) FindAllDocuments(ctx context.Context) ([]DocType, er
 	return documents, nil
 }
 
-func (m *mongoSvc[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
+func (m *mongoSvc[DocType]) FindDocument(ctx context.Context, id string) (_ *DocType, err error) {
+	ctx, span := m.startSpan(ctx, "find", id)
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	client, err := m.connect(ctx)
@@ -270,7 +289,10 @@ func (m *mongoSvc[DocType]) FindDocument(ctx context.Context, id string) (*DocTy
 	return document, nil
 }
 
-func (m *mongoSvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+func (m *mongoSvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) (err error) {
+	ctx, span := m.startSpan(ctx, "replace", id)
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	client, err := m.connect(ctx)
@@ -279,19 +301,24 @@ func (m *mongoSvc[DocType]) UpdateDocument(ctx context.Context, id string, docum
 	}
 	db := client.Database(m.DbName)
 	collection := db.Collection(m.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
-	switch result.Err() {
-	case nil:
-	case mongo.ErrNoDocuments:
-		return ErrNotFound
-	default: // other errors - return them
-		return result.Err()
-	}
-	_, err = collection.ReplaceOne(ctx, bson.D{{Key: "id", Value: id}}, document)
-	return err
+	return m.withOutbox(ctx, client, func(ctx context.Context) error {
+		result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+		switch result.Err() {
+		case nil:
+		case mongo.ErrNoDocuments:
+			return ErrNotFound
+		default: // other errors - return them
+			return result.Err()
+		}
+		_, err := collection.ReplaceOne(ctx, bson.D{{Key: "id", Value: id}}, document)
+		return conflictError(err)
+	})
 }
 
-func (m *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+func (m *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) (err error) {
+	ctx, span := m.startSpan(ctx, "delete", id)
+	defer func() { endSpan(span, err) }()
+
 	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
 	defer contextCancel()
 	client, err := m.connect(ctx)
@@ -300,14 +327,116 @@ func (m *mongoSvc[DocType]) DeleteDocument(ctx context.Context, id string) error
 	}
 	db := client.Database(m.DbName)
 	collection := db.Collection(m.Collection)
-	result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
-	switch result.Err() {
-	case nil:
-	case mongo.ErrNoDocuments:
-		return ErrNotFound
-	default: // other errors - return them
-		return result.Err()
+	return m.withOutbox(ctx, client, func(ctx context.Context) error {
+		result := collection.FindOne(ctx, bson.D{{Key: "id", Value: id}})
+		switch result.Err() {
+		case nil:
+		case mongo.ErrNoDocuments:
+			return ErrNotFound
+		default: // other errors - return them
+			return result.Err()
+		}
+		_, err := collection.DeleteOne(ctx, bson.D{{Key: "id", Value: id}})
+		return err
+	})
+}
+
+// withOutbox runs the write operation and stores outbox messages attached to
+// the context in one transaction. Servers without transaction support (standalone
+// deployments) get the document written first and the messages right after it.
+func (m *mongoSvc[DocType]) withOutbox(ctx context.Context, client *mongo.Client, write func(ctx context.Context) error) error {
+	messages := outboxMessages(ctx)
+	if len(messages) == 0 {
+		return write(ctx)
+	}
+
+	// joins the transaction of the context if there is one
+	return m.client.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return m.insertOutboxMessages(ctx, client, messages)
+	})
+}
+
+func (m *mongoSvc[DocType]) insertOutboxMessages(ctx context.Context, client *mongo.Client, messages []OutboxMessage) error {
+	documents := make([]interface{}, len(messages))
+	for i := range messages {
+		documents[i] = messages[i]
+	}
+	_, err := client.Database(m.DbName).Collection(m.OutboxCollection).InsertMany(ctx, documents)
+	return outboxConflictError(err)
+}
+
+// outboxConflictError translates duplicate key error of the outbox to
+// *ConflictError marked as conflict of the outbox. The messages keep their
+// ids when the write is retried, so the conflict means they were stored.
+func outboxConflictError(err error) error {
+	err = conflictError(err)
+	var conflict *ConflictError
+	if errors.As(err, &conflict) {
+		conflict.Outbox = true
 	}
-	_, err = collection.DeleteOne(ctx, bson.D{{Key: "id", Value: id}})
 	return err
+}
+
+// isTransactionUnsupported detects error returned by standalone servers
+// when a transaction is started
+func isTransactionUnsupported(err error) bool {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		// IllegalOperation - Transaction numbers are only allowed on a replica set member or mongos
+		return commandErr.Code == 20
+	}
+	return false
+}
+
+// WatchInsertedDocuments streams documents inserted into the collection using
+// change streams, which require replica set. The channel is closed when the
+// context is cancelled or the change stream fails.
+func (m *mongoSvc[DocType]) WatchInsertedDocuments(ctx context.Context) (<-chan DocType, error) {
+	connectCtx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(connectCtx)
+	if err != nil {
+		return nil, err
+	}
+	db := client.Database(m.DbName)
+	collection := db.Collection(m.Collection)
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.D{{Key: "operationType", Value: "insert"}}}}}
+	stream, err := collection.Watch(ctx, pipeline)
+	if err != nil {
+		var commandErr mongo.CommandError
+		// 40573 - The $changeStream stage is only supported on replica sets
+		if errors.As(err, &commandErr) && (commandErr.Code == 40573 || commandErr.Code == 20) {
+			return nil, ErrWatchUnsupported
+		}
+		return nil, err
+	}
+
+	documents := make(chan DocType)
+	go func() {
+		defer close(documents)
+		defer stream.Close(context.Background())
+
+		for stream.Next(ctx) {
+			var change struct {
+				FullDocument DocType `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&change); err != nil {
+				slog.Error("Failed to decode change", "collection", m.Collection, "error", err)
+				continue
+			}
+			select {
+			case documents <- change.FullDocument:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if err := stream.Err(); err != nil && ctx.Err() == nil {
+			slog.Error("Change stream failed", "collection", m.Collection, "error", err)
+		}
+	}()
+	return documents, nil
 }
\ No newline at end of file
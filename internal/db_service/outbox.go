@@ -0,0 +1,171 @@
+package db_service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxMessage is a message stored in the outbox collection together with
+// the document change it describes. Messages are published by a relay and
+// marked as published afterwards.
+type OutboxMessage struct {
+	Id          string
+	Topic       string
+	Key         string
+	Payload     []byte
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+	Attempts    int
+	LastError   string
+	// set when the message is not going to be published, e.g. when it
+	// failed too many times, dead-lettered messages are kept for inspection
+	DeadLetteredAt *time.Time
+	// relay which claimed the message last
+	LeaseOwner string
+	// the message cannot be claimed until the time, the lease of a relay or
+	// the backoff of a failed message
+	LeaseUntil *time.Time
+}
+
+// OutboxService reads the outbox collection for the relays. Messages are
+// claimed by a lease before they are published, so relays of several
+// replicas of the service do not publish the same message. Messages of a
+// relay which stopped are claimed by another one once their lease expires.
+type OutboxService interface {
+	DbService[OutboxMessage]
+	// ClaimMessages leases at most limit oldest messages which are neither
+	// published, dead-lettered nor leased to the owner for the duration,
+	// ordered by the creation
+	ClaimMessages(ctx context.Context, owner string, limit int, lease time.Duration) ([]OutboxMessage, error)
+	// ReleaseMessage stores the message claimed by the owner, which sets
+	// the outcome of the publishing and ends or extends the lease. The owner
+	// of the message is kept, so the release can be repeated. ErrNotFound is
+	// returned when the message was claimed by another owner meanwhile.
+	ReleaseMessage(ctx context.Context, owner string, message *OutboxMessage) error
+}
+
+type outboxSvc struct {
+	*mongoSvc[OutboxMessage]
+}
+
+// NewOutboxService returns service of the outbox collection using the shared
+// client
+func NewOutboxService(client *MongoClient, collection string) OutboxService {
+	return &outboxSvc{mongoSvc: NewCollectionService[OutboxMessage](client, collection).(*mongoSvc[OutboxMessage])}
+}
+
+// claimableFilter matches messages waiting for publishing whose lease is
+// not held
+func claimableFilter(now time.Time) bson.D {
+	return bson.D{
+		{Key: "publishedat", Value: nil},
+		{Key: "deadletteredat", Value: nil},
+		{Key: "$or", Value: bson.A{
+			bson.D{{Key: "leaseuntil", Value: nil}},
+			bson.D{{Key: "leaseuntil", Value: bson.D{{Key: "$lte", Value: now}}}},
+		}},
+	}
+}
+
+func (m *outboxSvc) ClaimMessages(ctx context.Context, owner string, limit int, lease time.Duration) (_ []OutboxMessage, err error) {
+	ctx, span := m.startSpan(ctx, "claim", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	collection := client.Database(m.DbName).Collection(m.Collection)
+
+	now := time.Now()
+	cursor, err := collection.Find(ctx, claimableFilter(now), options.Find().
+		SetSort(bson.D{{Key: "createdat", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.D{{Key: "id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var candidates []struct {
+		Id string `bson:"id"`
+	}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return []OutboxMessage{}, nil
+	}
+	ids := make(bson.A, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.Id
+	}
+
+	// candidates claimed meanwhile by another owner no longer match, the
+	// lease time stored in milliseconds identifies this claim
+	leaseUntil := now.Add(lease).Truncate(time.Millisecond)
+	filter := append(claimableFilter(now), bson.E{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}})
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "leaseowner", Value: owner}, {Key: "leaseuntil", Value: leaseUntil}}}}
+	if _, err := collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+
+	cursor, err = collection.Find(ctx, bson.D{
+		{Key: "id", Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: "leaseowner", Value: owner},
+		{Key: "leaseuntil", Value: leaseUntil},
+	}, options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	messages := []OutboxMessage{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (m *outboxSvc) ReleaseMessage(ctx context.Context, owner string, message *OutboxMessage) (err error) {
+	ctx, span := m.startSpan(ctx, "release", message.Id)
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := client.Database(m.DbName).Collection(m.Collection).
+		ReplaceOne(ctx, bson.D{{Key: "id", Value: message.Id}, {Key: "leaseowner", Value: owner}}, message)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+type outboxContextKey struct{}
+
+// WithOutboxMessages returns context carrying the messages. Create, update and
+// delete operations called with such context write the messages to the outbox
+// collection in the same transaction as the document.
+func WithOutboxMessages(ctx context.Context, messages ...OutboxMessage) context.Context {
+	if len(messages) == 0 {
+		return ctx
+	}
+	existing := outboxMessages(ctx)
+	combined := make([]OutboxMessage, 0, len(existing)+len(messages))
+	combined = append(combined, existing...)
+	combined = append(combined, messages...)
+	return context.WithValue(ctx, outboxContextKey{}, combined)
+}
+
+func outboxMessages(ctx context.Context) []OutboxMessage {
+	messages, _ := ctx.Value(outboxContextKey{}).([]OutboxMessage)
+	return messages
+}
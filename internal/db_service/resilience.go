@@ -0,0 +1,389 @@
+package db_service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+type ResilienceConfig struct {
+	// attempts of an operation including the first one
+	MaxAttempts int
+	// backoff before the first retry, doubled for every next retry
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// shared by services of the same server, a new breaker is created if nil
+	Breaker *CircuitBreaker
+}
+
+// resilientSvc retries operations of the wrapped service failing with
+// transient errors and fails fast while the circuit breaker is open
+type resilientSvc[DocType interface{}] struct {
+	ResilienceConfig
+	inner DbService[DocType]
+}
+
+// NewResilientService returns DbService retrying the operations of the inner
+// service with jittered exponential backoff when the database fails
+// transiently, e.g. during failover of the replica set. Reads are always
+// safe to retry, writes are retried so that a write applied by an attempt
+// whose response was lost is not reported as failed:
+//
+//   - create failing with ErrConflict after a retry succeeds if the stored
+//     document equals the created one
+//   - update replaces the whole document, so it is idempotent; update
+//     whose outbox messages are already stored after a retry succeeds
+//   - delete failing with ErrNotFound after a retry succeeds
+//
+// Operations called within a transaction are not retried, the transaction is
+// retried as a whole by MongoClient.WithTransaction. When the retries are
+// exhausted or the circuit is open, *UnavailableError is returned.
+func NewResilientService[DocType interface{}](inner DbService[DocType], config ResilienceConfig) DbService[DocType] {
+	return &resilientSvc[DocType]{ResilienceConfig: config.withDefaults(), inner: inner}
+}
+
+func (c ResilienceConfig) withDefaults() ResilienceConfig {
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 3
+	}
+	if c.BaseDelay == 0 {
+		c.BaseDelay = 100 * time.Millisecond
+	}
+	if c.MaxDelay == 0 {
+		c.MaxDelay = 2 * time.Second
+	}
+	if c.Breaker == nil {
+		c.Breaker = NewCircuitBreaker(CircuitBreakerConfig{})
+	}
+	return c
+}
+
+// run calls the operation until it succeeds, fails with an error which is
+// not transient, or the attempts are exhausted
+func (s *ResilienceConfig) run(ctx context.Context, operation func(attempt int) error) error {
+	attempts := s.MaxAttempts
+	if mongo.SessionFromContext(ctx) != nil {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err := s.Breaker.Allow(); err != nil {
+			return err
+		}
+		err = operation(attempt)
+		s.Breaker.Record(err)
+		if !IsRetryable(err) {
+			return err
+		}
+		if attempt >= attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(s.backoff(attempt)):
+		}
+	}
+	return &UnavailableError{RetryAfter: s.MaxDelay, Err: err}
+}
+
+// backoff returns random delay up to the exponential bound ("full jitter"),
+// so clients failed by the same outage do not retry in lockstep
+func (s *ResilienceConfig) backoff(attempt int) time.Duration {
+	bound := s.BaseDelay << (attempt - 1)
+	if bound > s.MaxDelay || bound <= 0 {
+		bound = s.MaxDelay
+	}
+	return rand.N(bound) + 1
+}
+
+func (s *resilientSvc[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	return s.run(ctx, func(attempt int) error {
+		err := s.inner.CreateDocument(ctx, id, document)
+		if errors.Is(err, ErrConflict) && attempt > 1 && s.isStored(ctx, id, document) {
+			return nil
+		}
+		return err
+	})
+}
+
+// isStored reports whether the stored document equals the document, compared
+// in the form stored in the database
+func (s *resilientSvc[DocType]) isStored(ctx context.Context, id string, document *DocType) bool {
+	stored, err := s.inner.FindDocument(ctx, id)
+	if err != nil {
+		return false
+	}
+
+	// round trip of the document drops what is not stored, e.g. nanoseconds of times
+	encoded, err := bson.Marshal(document)
+	if err != nil {
+		return false
+	}
+	var decoded DocType
+	if err := bson.Unmarshal(encoded, &decoded); err != nil {
+		return false
+	}
+	expected, err := bson.Marshal(&decoded)
+	if err != nil {
+		return false
+	}
+	actual, err := bson.Marshal(stored)
+	return err == nil && bytes.Equal(expected, actual)
+}
+
+func (s *resilientSvc[DocType]) FindAllDocuments(ctx context.Context) (documents []DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		documents, err = s.inner.FindAllDocuments(ctx)
+		return err
+	})
+	return documents, err
+}
+
+func (s *resilientSvc[DocType]) FindDocument(ctx context.Context, id string) (document *DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		document, err = s.inner.FindDocument(ctx, id)
+		return err
+	})
+	return document, err
+}
+
+func (s *resilientSvc[DocType]) FindDocumentsByCondition(ctx context.Context, filter bson.M) (documents []DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		documents, err = s.inner.FindDocumentsByCondition(ctx, filter)
+		return err
+	})
+	return documents, err
+}
+
+func (s *resilientSvc[DocType]) CountDocumentsByField(ctx context.Context, field string) (counts map[string]int, err error) {
+	err = s.run(ctx, func(int) error {
+		counts, err = s.inner.CountDocumentsByField(ctx, field)
+		return err
+	})
+	return counts, err
+}
+
+func (s *resilientSvc[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	return s.run(ctx, func(attempt int) error {
+		err := s.inner.UpdateDocument(ctx, id, document)
+		if attempt > 1 && isOutboxConflict(err) {
+			// outbox messages of the update are stored with the document, so
+			// an earlier attempt whose response was lost applied the update
+			return nil
+		}
+		return err
+	})
+}
+
+func isOutboxConflict(err error) bool {
+	var conflict *ConflictError
+	return errors.As(err, &conflict) && conflict.Outbox
+}
+
+func (s *resilientSvc[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	return s.run(ctx, func(attempt int) error {
+		err := s.inner.DeleteDocument(ctx, id)
+		if err == ErrNotFound && attempt > 1 {
+			return nil
+		}
+		return err
+	})
+}
+
+// WatchInsertedDocuments is not retried, callers restart the stream
+func (s *resilientSvc[DocType]) WatchInsertedDocuments(ctx context.Context) (<-chan DocType, error) {
+	if err := s.Breaker.Allow(); err != nil {
+		return nil, err
+	}
+	documents, err := s.inner.WatchInsertedDocuments(ctx)
+	s.Breaker.Record(err)
+	return documents, err
+}
+
+func (s *resilientSvc[DocType]) EnsureIndexes(ctx context.Context, indexes ...Index) error {
+	return s.run(ctx, func(int) error {
+		return s.inner.EnsureIndexes(ctx, indexes...)
+	})
+}
+
+// Ping bypasses the breaker, so the readiness reflects the actual state
+func (s *resilientSvc[DocType]) Ping(ctx context.Context) error {
+	return s.inner.Ping(ctx)
+}
+
+func (s *resilientSvc[DocType]) Disconnect(ctx context.Context) error {
+	return s.inner.Disconnect(ctx)
+}
+
+// resilientTimeSeriesSvc retries reads of the wrapped time-series service
+type resilientTimeSeriesSvc[DocType interface{}] struct {
+	ResilienceConfig
+	inner TimeSeriesService[DocType]
+}
+
+// NewResilientTimeSeriesService returns TimeSeriesService retrying reads of
+// the inner service like NewResilientService. Inserts are not retried, since
+// measurements have no identity to detect a batch inserted by an attempt
+// whose response was lost, they only fail fast while the circuit is open.
+func NewResilientTimeSeriesService[DocType interface{}](inner TimeSeriesService[DocType], config ResilienceConfig) TimeSeriesService[DocType] {
+	return &resilientTimeSeriesSvc[DocType]{ResilienceConfig: config.withDefaults(), inner: inner}
+}
+
+func (s *resilientTimeSeriesSvc[DocType]) InsertMeasurements(ctx context.Context, measurements []DocType) error {
+	if err := s.Breaker.Allow(); err != nil {
+		return err
+	}
+	err := s.inner.InsertMeasurements(ctx, measurements)
+	s.Breaker.Record(err)
+	if IsRetryable(err) {
+		return &UnavailableError{RetryAfter: s.MaxDelay, Err: err}
+	}
+	return err
+}
+
+func (s *resilientTimeSeriesSvc[DocType]) FindMeasurements(ctx context.Context, filter bson.M, from time.Time, to time.Time, limit int) (measurements []DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		measurements, err = s.inner.FindMeasurements(ctx, filter, from, to, limit)
+		return err
+	})
+	return measurements, err
+}
+
+func (s *resilientTimeSeriesSvc[DocType]) Downsample(ctx context.Context, filter bson.M, from time.Time, to time.Time, interval time.Duration) (buckets []Bucket, err error) {
+	err = s.run(ctx, func(int) error {
+		buckets, err = s.inner.Downsample(ctx, filter, from, to, interval)
+		return err
+	})
+	return buckets, err
+}
+
+func (s *resilientTimeSeriesSvc[DocType]) Latest(ctx context.Context, filter bson.M, since time.Time) (documents []DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		documents, err = s.inner.Latest(ctx, filter, since)
+		return err
+	})
+	return documents, err
+}
+
+// Ping bypasses the breaker, so the readiness reflects the actual state
+func (s *resilientTimeSeriesSvc[DocType]) Ping(ctx context.Context) error {
+	return s.inner.Ping(ctx)
+}
+
+func (s *resilientTimeSeriesSvc[DocType]) Disconnect(ctx context.Context) error {
+	return s.inner.Disconnect(ctx)
+}
+
+// resilientOutboxSvc retries operations of the wrapped outbox service
+type resilientOutboxSvc struct {
+	DbService[OutboxMessage]
+	ResilienceConfig
+	inner OutboxService
+}
+
+// NewResilientOutboxService returns OutboxService retrying the operations of
+// the inner service like NewResilientService. Claims whose response was lost
+// leave the messages leased until the lease expires, releases are repeatable.
+func NewResilientOutboxService(inner OutboxService, config ResilienceConfig) OutboxService {
+	config = config.withDefaults()
+	return &resilientOutboxSvc{
+		DbService:        NewResilientService[OutboxMessage](inner, config),
+		ResilienceConfig: config,
+		inner:            inner,
+	}
+}
+
+func (s *resilientOutboxSvc) ClaimMessages(ctx context.Context, owner string, limit int, lease time.Duration) (messages []OutboxMessage, err error) {
+	err = s.run(ctx, func(int) error {
+		messages, err = s.inner.ClaimMessages(ctx, owner, limit, lease)
+		return err
+	})
+	return messages, err
+}
+
+func (s *resilientOutboxSvc) ReleaseMessage(ctx context.Context, owner string, message *OutboxMessage) error {
+	return s.run(ctx, func(int) error {
+		return s.inner.ReleaseMessage(ctx, owner, message)
+	})
+}
+
+// resilientLeaseSvc retries operations of the wrapped lease service
+type resilientLeaseSvc[DocType interface{}] struct {
+	DbService[DocType]
+	ResilienceConfig
+	inner LeaseService[DocType]
+}
+
+// NewResilientLeaseService returns LeaseService retrying the operations of
+// the inner service like NewResilientOutboxService
+func NewResilientLeaseService[DocType interface{}](inner LeaseService[DocType], config ResilienceConfig) LeaseService[DocType] {
+	config = config.withDefaults()
+	return &resilientLeaseSvc[DocType]{
+		DbService:        NewResilientService[DocType](inner, config),
+		ResilienceConfig: config,
+		inner:            inner,
+	}
+}
+
+func (s *resilientLeaseSvc[DocType]) ClaimDocuments(ctx context.Context, filter bson.M, owner string, limit int, lease time.Duration) (documents []DocType, err error) {
+	err = s.run(ctx, func(int) error {
+		documents, err = s.inner.ClaimDocuments(ctx, filter, owner, limit, lease)
+		return err
+	})
+	return documents, err
+}
+
+func (s *resilientLeaseSvc[DocType]) ReleaseDocument(ctx context.Context, owner string, id string, document *DocType) error {
+	return s.run(ctx, func(int) error {
+		return s.inner.ReleaseDocument(ctx, owner, id, document)
+	})
+}
+
+// retryableCodes are codes of server errors during elections and shutdowns
+var retryableCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	262,   // ExceededTimeLimit
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+func isTransientMongoError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var selectionErr topology.ServerSelectionError
+	if errors.As(err, &selectionErr) {
+		return true
+	}
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if serverErr.HasErrorLabel("RetryableWriteError") || serverErr.HasErrorLabel("TransientTransactionError") {
+			return true
+		}
+		for _, code := range retryableCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+	}
+	return mongo.IsNetworkError(err) || mongo.IsTimeout(err)
+}
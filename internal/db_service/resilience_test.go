@@ -0,0 +1,421 @@
+package db_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type testDocument struct {
+	Id   string
+	Name string
+}
+
+// faultyDb keeps documents in memory and injects faults. Errors queued for an
+// operation are returned by its next calls; applied errors are returned after
+// the operation was applied, as when its response is lost. Updates store the
+// outbox messages of the context like the Mongo implementation.
+type faultyDb struct {
+	DbService[testDocument]
+	documents map[string]testDocument
+	outbox    map[string]bool
+	errors    map[string][]error
+	applied   map[string][]error
+	calls     map[string]int
+}
+
+func newFaultyDb() *faultyDb {
+	return &faultyDb{
+		documents: map[string]testDocument{},
+		outbox:    map[string]bool{},
+		errors:    map[string][]error{},
+		applied:   map[string][]error{},
+		calls:     map[string]int{},
+	}
+}
+
+// fault returns the error queued for the operation, the operation is not
+// applied when it is set
+func (f *faultyDb) fault(operation string) error {
+	f.calls[operation]++
+	if queued := f.errors[operation]; len(queued) > 0 {
+		f.errors[operation] = queued[1:]
+		return queued[0]
+	}
+	return nil
+}
+
+// lostResponse returns the error queued for the applied operation
+func (f *faultyDb) lostResponse(operation string) error {
+	if queued := f.applied[operation]; len(queued) > 0 {
+		f.applied[operation] = queued[1:]
+		return queued[0]
+	}
+	return nil
+}
+
+func (f *faultyDb) CreateDocument(ctx context.Context, id string, document *testDocument) error {
+	if err := f.fault("create"); err != nil {
+		return err
+	}
+	if _, ok := f.documents[id]; ok {
+		return &ConflictError{Index: "id", Fields: []string{"id"}}
+	}
+	f.documents[id] = *document
+	return f.lostResponse("create")
+}
+
+func (f *faultyDb) FindDocument(ctx context.Context, id string) (*testDocument, error) {
+	if err := f.fault("find"); err != nil {
+		return nil, err
+	}
+	document, ok := f.documents[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &document, nil
+}
+
+func (f *faultyDb) UpdateDocument(ctx context.Context, id string, document *testDocument) error {
+	if err := f.fault("update"); err != nil {
+		return err
+	}
+	if _, ok := f.documents[id]; !ok {
+		return ErrNotFound
+	}
+	messages := outboxMessages(ctx)
+	for _, message := range messages {
+		if f.outbox[message.Id] {
+			return &ConflictError{Index: "id", Fields: []string{"id"}, Outbox: true}
+		}
+	}
+	f.documents[id] = *document
+	for _, message := range messages {
+		f.outbox[message.Id] = true
+	}
+	return f.lostResponse("update")
+}
+
+func (f *faultyDb) DeleteDocument(ctx context.Context, id string) error {
+	if err := f.fault("delete"); err != nil {
+		return err
+	}
+	if _, ok := f.documents[id]; !ok {
+		return ErrNotFound
+	}
+	delete(f.documents, id)
+	return f.lostResponse("delete")
+}
+
+// transientError is failure of the server during election of a new primary
+func transientError() error {
+	return mongo.CommandError{Code: 189, Name: "PrimarySteppedDown", Message: "primary stepped down"}
+}
+
+func newTestResilientService(inner DbService[testDocument], attempts int) DbService[testDocument] {
+	return NewResilientService(inner, ResilienceConfig{
+		MaxAttempts: attempts,
+		BaseDelay:   time.Microsecond,
+		MaxDelay:    time.Millisecond,
+		// keeps the circuit closed while the retries are tested
+		Breaker: NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 100}),
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{transientError(), true},
+		{mongo.CommandError{Code: 11000, Message: "duplicate key"}, false},
+		{ErrNotFound, false},
+		{&ConflictError{Index: "id"}, false},
+		{context.Canceled, false},
+		{&UnavailableError{Err: transientError()}, false},
+	}
+	for _, c := range cases {
+		if got := IsRetryable(c.err); got != c.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestRetriesTransientErrorsWithinAttempts(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "first"}
+	inner.errors["find"] = []error{transientError(), transientError()}
+	db := newTestResilientService(inner, 3)
+
+	document, err := db.FindDocument(context.Background(), "doc1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if document.Name != "first" || inner.calls["find"] != 3 {
+		t.Fatalf("found %+v in %d calls, want 3", document, inner.calls["find"])
+	}
+}
+
+func TestReturnsUnavailableWhenAttemptsExhausted(t *testing.T) {
+	inner := newFaultyDb()
+	inner.errors["find"] = []error{transientError(), transientError(), transientError(), transientError()}
+	db := newTestResilientService(inner, 3)
+
+	_, err := db.FindDocument(context.Background(), "doc1")
+	var unavailable *UnavailableError
+	if !errors.As(err, &unavailable) || !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("error %v, want *UnavailableError", err)
+	}
+	var commandErr mongo.CommandError
+	if !errors.As(err, &commandErr) || commandErr.Code != 189 {
+		t.Fatalf("error %v does not wrap the last failure", err)
+	}
+	if unavailable.RetryAfter <= 0 {
+		t.Fatalf("retry after %s", unavailable.RetryAfter)
+	}
+	if inner.calls["find"] != 3 {
+		t.Fatalf("find called %d times, want 3", inner.calls["find"])
+	}
+}
+
+func TestDoesNotRetryNonRetryableErrors(t *testing.T) {
+	failure := errors.New("document failed validation")
+	cases := map[string]func(db DbService[testDocument]) error{
+		"find": func(db DbService[testDocument]) error {
+			_, err := db.FindDocument(context.Background(), "doc1")
+			return err
+		},
+		"create": func(db DbService[testDocument]) error {
+			return db.CreateDocument(context.Background(), "doc1", &testDocument{Id: "doc1"})
+		},
+		"update": func(db DbService[testDocument]) error {
+			return db.UpdateDocument(context.Background(), "doc1", &testDocument{Id: "doc1"})
+		},
+		"delete": func(db DbService[testDocument]) error {
+			return db.DeleteDocument(context.Background(), "doc1")
+		},
+	}
+	for operation, call := range cases {
+		t.Run(operation, func(t *testing.T) {
+			inner := newFaultyDb()
+			inner.errors[operation] = []error{failure, failure, failure}
+			db := newTestResilientService(inner, 3)
+
+			if err := call(db); err != failure {
+				t.Fatalf("error %v, want the failure as is", err)
+			}
+			if inner.calls[operation] != 1 {
+				t.Fatalf("%s called %d times, want 1", operation, inner.calls[operation])
+			}
+		})
+	}
+}
+
+func TestDoesNotRetryNotFound(t *testing.T) {
+	inner := newFaultyDb()
+	db := newTestResilientService(inner, 3)
+
+	if err := db.DeleteDocument(context.Background(), "doc1"); err != ErrNotFound {
+		t.Fatalf("error %v, want ErrNotFound", err)
+	}
+	if inner.calls["delete"] != 1 {
+		t.Fatalf("delete called %d times, want 1", inner.calls["delete"])
+	}
+}
+
+func TestCreateWithLostResponseIsStored(t *testing.T) {
+	inner := newFaultyDb()
+	inner.applied["create"] = []error{transientError()}
+	db := newTestResilientService(inner, 3)
+
+	document := &testDocument{Id: "doc1", Name: "first"}
+	if err := db.CreateDocument(context.Background(), "doc1", document); err != nil {
+		t.Fatalf("create applied by the first attempt failed: %v", err)
+	}
+	if inner.calls["create"] != 2 {
+		t.Fatalf("create called %d times, want 2", inner.calls["create"])
+	}
+}
+
+func TestCreateConflictingWithOtherDocumentFails(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "other"}
+	inner.errors["create"] = []error{transientError()}
+	db := newTestResilientService(inner, 3)
+
+	err := db.CreateDocument(context.Background(), "doc1", &testDocument{Id: "doc1", Name: "first"})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("error %v, want ErrConflict", err)
+	}
+}
+
+func TestCreateConflictOnFirstAttemptFails(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "first"}
+	db := newTestResilientService(inner, 3)
+
+	// the same document stored before is a conflict unless a retry stored it
+	err := db.CreateDocument(context.Background(), "doc1", &testDocument{Id: "doc1", Name: "first"})
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("error %v, want ErrConflict", err)
+	}
+	if inner.calls["find"] != 0 {
+		t.Fatal("stored document was compared on the first attempt")
+	}
+}
+
+func TestDeleteWithLostResponseSucceeds(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1"}
+	inner.applied["delete"] = []error{transientError()}
+	db := newTestResilientService(inner, 3)
+
+	if err := db.DeleteDocument(context.Background(), "doc1"); err != nil {
+		t.Fatalf("delete applied by the first attempt failed: %v", err)
+	}
+	if _, ok := inner.documents["doc1"]; ok || inner.calls["delete"] != 2 {
+		t.Fatalf("document kept or delete called %d times, want 2", inner.calls["delete"])
+	}
+}
+
+func TestUpdateWithLostResponseSucceeds(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "first"}
+	inner.applied["update"] = []error{transientError()}
+	db := newTestResilientService(inner, 3)
+
+	if err := db.UpdateDocument(context.Background(), "doc1", &testDocument{Id: "doc1", Name: "second"}); err != nil {
+		t.Fatal(err)
+	}
+	if inner.documents["doc1"].Name != "second" || inner.calls["update"] != 2 {
+		t.Fatalf("stored %+v after %d calls", inner.documents["doc1"], inner.calls["update"])
+	}
+}
+
+func TestUpdateWithOutboxAndLostResponseSucceeds(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "first"}
+	inner.applied["update"] = []error{transientError()}
+	db := newTestResilientService(inner, 3)
+
+	ctx := WithOutboxMessages(context.Background(), OutboxMessage{Id: "event1"})
+	if err := db.UpdateDocument(ctx, "doc1", &testDocument{Id: "doc1", Name: "second"}); err != nil {
+		t.Fatalf("update applied by the first attempt failed: %v", err)
+	}
+	if inner.documents["doc1"].Name != "second" || inner.calls["update"] != 2 {
+		t.Fatalf("stored %+v after %d calls", inner.documents["doc1"], inner.calls["update"])
+	}
+}
+
+func TestUpdateWithStoredOutboxMessageOnFirstAttemptFails(t *testing.T) {
+	inner := newFaultyDb()
+	inner.documents["doc1"] = testDocument{Id: "doc1", Name: "first"}
+	inner.outbox["event1"] = true
+	db := newTestResilientService(inner, 3)
+
+	ctx := WithOutboxMessages(context.Background(), OutboxMessage{Id: "event1"})
+	err := db.UpdateDocument(ctx, "doc1", &testDocument{Id: "doc1", Name: "second"})
+	if !errors.Is(err, ErrConflict) || !isOutboxConflict(err) {
+		t.Fatalf("error %v, want conflict of the outbox", err)
+	}
+}
+
+func TestOutboxConflictError(t *testing.T) {
+	duplicate := mongo.WriteException{WriteErrors: []mongo.WriteError{{
+		Code:    11000,
+		Message: `E11000 duplicate key error collection: mdm.outbox index: id dup key: { id: "event1" }`,
+	}}}
+	err := outboxConflictError(duplicate)
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrConflict) || !conflict.Outbox || conflict.Index != "id" {
+		t.Fatalf("error %v, want *ConflictError of the outbox id index", err)
+	}
+
+	failure := errors.New("not primary")
+	if err := outboxConflictError(failure); err != failure {
+		t.Fatalf("error %v, want the failure as is", err)
+	}
+	if err := outboxConflictError(nil); err != nil {
+		t.Fatalf("error %v, want nil", err)
+	}
+}
+
+func TestOpenBreakerFailsFast(t *testing.T) {
+	inner := newFaultyDb()
+	inner.errors["find"] = []error{transientError(), transientError()}
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+	db := NewResilientService[testDocument](inner, ResilienceConfig{
+		MaxAttempts: 1,
+		BaseDelay:   time.Microsecond,
+		MaxDelay:    time.Millisecond,
+		Breaker:     breaker,
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := db.FindDocument(context.Background(), "doc1"); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("error %v, want ErrUnavailable", err)
+		}
+	}
+
+	now = now.Add(20 * time.Second)
+	_, err := db.FindDocument(context.Background(), "doc1")
+	var unavailable *UnavailableError
+	if !errors.As(err, &unavailable) || unavailable.Err != nil {
+		t.Fatalf("error %v, want *UnavailableError of the open circuit", err)
+	}
+	if unavailable.RetryAfter != 40*time.Second {
+		t.Fatalf("retry after %s, want the rest of the open timeout", unavailable.RetryAfter)
+	}
+	if inner.calls["find"] != 2 {
+		t.Fatalf("find called %d times while the circuit was open", inner.calls["find"])
+	}
+
+	// the probe after the open timeout closes the circuit
+	now = now.Add(time.Minute)
+	if _, err := db.FindDocument(context.Background(), "doc1"); err != ErrNotFound {
+		t.Fatalf("probe returned %v, want ErrNotFound", err)
+	}
+	if _, err := db.FindDocument(context.Background(), "doc1"); err != ErrNotFound {
+		t.Fatalf("error %v after the circuit closed, want ErrNotFound", err)
+	}
+}
+
+func TestHalfOpenBreakerLetsOneProbeThrough(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	breaker.Record(transientError())
+	if err := breaker.Allow(); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("open circuit allowed the operation: %v", err)
+	}
+
+	now = now.Add(time.Second)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("probe rejected: %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("second operation allowed during the probe: %v", err)
+	}
+
+	// failed probe opens the circuit again
+	breaker.Record(transientError())
+	if err := breaker.Allow(); !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("circuit did not open after the failed probe: %v", err)
+	}
+}
+
+func TestBreakerIgnoresNonRetryableErrors(t *testing.T) {
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1})
+	breaker.Record(ErrNotFound)
+	breaker.Record(&ConflictError{Index: "id"})
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("circuit opened on errors of the operations: %v", err)
+	}
+}
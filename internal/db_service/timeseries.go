@@ -0,0 +1,244 @@
+package db_service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// TimeSeriesService stores measurements in a time-series collection, which
+// buckets the documents by their series and time. Measurements are inserted
+// in batches and read by ranges of time, either as they were measured or
+// downsampled to buckets of fixed interval, e.g. for charts.
+//
+// Time-series collections cannot be written in transactions, so outbox
+// messages attached to the context of the insert are written right after the
+// measurements.
+type TimeSeriesService[DocType interface{}] interface {
+	InsertMeasurements(ctx context.Context, measurements []DocType) error
+	// FindMeasurements returns measurements matching the filter taken from
+	// the from time, inclusive, to the to time, exclusive, ordered by time
+	// and at most limit of them if limit is positive
+	FindMeasurements(ctx context.Context, filter bson.M, from time.Time, to time.Time, limit int) ([]DocType, error)
+	// Downsample summarizes values of the measurements matching the filter
+	// by groups and intervals, ordered by group and time
+	Downsample(ctx context.Context, filter bson.M, from time.Time, to time.Time, interval time.Duration) ([]Bucket, error)
+	// Latest returns the latest measurement of each group of each series
+	// matching the filter taken since the time, e.g. the latest heart rate
+	// of every patient
+	Latest(ctx context.Context, filter bson.M, since time.Time) ([]DocType, error)
+	Ping(ctx context.Context) error
+	Disconnect(ctx context.Context) error
+}
+
+// TimeSeries names the fields of the measurements as stored in the database
+type TimeSeries struct {
+	// time of the measurement
+	TimeField string
+	// identity of the series, e.g. the patient
+	MetaField string
+	// downsampled measurements are summarized by the groups, e.g. by the
+	// kind of the measurement
+	GroupField string
+	// numeric value summarized by the downsampling
+	ValueField string
+	// granularity of the buckets, "seconds", "minutes" or "hours"
+	Granularity string
+}
+
+// CollectionOptions returns options creating the time-series collection
+func (s TimeSeries) CollectionOptions() *options.CreateCollectionOptions {
+	timeSeries := options.TimeSeries().SetTimeField(s.TimeField)
+	if s.MetaField != "" {
+		timeSeries.SetMetaField(s.MetaField)
+	}
+	if s.Granularity != "" {
+		timeSeries.SetGranularity(s.Granularity)
+	}
+	return options.CreateCollection().SetTimeSeriesOptions(timeSeries)
+}
+
+// Bucket summarizes values of measurements of the group taken within the
+// interval starting at Start
+type Bucket struct {
+	Group   string    `bson:"group"`
+	Start   time.Time `bson:"start"`
+	Count   int       `bson:"count"`
+	Average float64   `bson:"average"`
+	Min     float64   `bson:"min"`
+	Max     float64   `bson:"max"`
+}
+
+type timeSeriesSvc[DocType interface{}] struct {
+	*mongoSvc[DocType]
+	TimeSeries
+}
+
+// NewTimeSeriesService returns service of the time-series collection using
+// the shared client. The collection is created by the migrations.
+func NewTimeSeriesService[DocType interface{}](client *MongoClient, collection string, series TimeSeries) TimeSeriesService[DocType] {
+	return &timeSeriesSvc[DocType]{
+		mongoSvc:   NewCollectionService[DocType](client, collection).(*mongoSvc[DocType]),
+		TimeSeries: series,
+	}
+}
+
+func (m *timeSeriesSvc[DocType]) InsertMeasurements(ctx context.Context, measurements []DocType) (err error) {
+	ctx, span := m.startSpan(ctx, "insert_many", "")
+	defer func() { endSpan(span, err) }()
+
+	if len(measurements) == 0 {
+		return nil
+	}
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	documents := make([]interface{}, len(measurements))
+	for i := range measurements {
+		documents[i] = &measurements[i]
+	}
+	if _, err = client.Database(m.DbName).Collection(m.Collection).InsertMany(ctx, documents); err != nil {
+		return err
+	}
+	if messages := outboxMessages(ctx); len(messages) > 0 {
+		return m.insertOutboxMessages(ctx, client, messages)
+	}
+	return nil
+}
+
+func (m *timeSeriesSvc[DocType]) FindMeasurements(ctx context.Context, filter bson.M, from time.Time, to time.Time, limit int) (_ []DocType, err error) {
+	ctx, span := m.startSpan(ctx, "find", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: m.TimeField, Value: 1}})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+	cursor, err := client.Database(m.DbName).Collection(m.Collection).Find(ctx, m.rangeFilter(filter, from, to), findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	documents := []DocType{}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+func (m *timeSeriesSvc[DocType]) Downsample(ctx context.Context, filter bson.M, from time.Time, to time.Time, interval time.Duration) (_ []Bucket, err error) {
+	ctx, span := m.startSpan(ctx, "aggregate", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	value := "$" + m.ValueField
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: m.rangeFilter(filter, from, to)}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "group", Value: "$" + m.GroupField},
+				// bins are aligned to whole multiples of the interval since 2000-01-01
+				{Key: "start", Value: bson.D{{Key: "$dateTrunc", Value: bson.D{
+					{Key: "date", Value: "$" + m.TimeField},
+					{Key: "unit", Value: "millisecond"},
+					{Key: "binSize", Value: interval.Milliseconds()},
+				}}}},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "average", Value: bson.D{{Key: "$avg", Value: value}}},
+			{Key: "min", Value: bson.D{{Key: "$min", Value: value}}},
+			{Key: "max", Value: bson.D{{Key: "$max", Value: value}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: 0},
+			{Key: "group", Value: "$_id.group"},
+			{Key: "start", Value: "$_id.start"},
+			{Key: "count", Value: 1},
+			{Key: "average", Value: 1},
+			{Key: "min", Value: 1},
+			{Key: "max", Value: 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "group", Value: 1}, {Key: "start", Value: 1}}}},
+	}
+	cursor, err := client.Database(m.DbName).Collection(m.Collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	buckets := []Bucket{}
+	if err = cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+func (m *timeSeriesSvc[DocType]) Latest(ctx context.Context, filter bson.M, since time.Time) (_ []DocType, err error) {
+	ctx, span := m.startSpan(ctx, "aggregate", "")
+	defer func() { endSpan(span, err) }()
+
+	ctx, contextCancel := context.WithTimeout(ctx, m.Timeout)
+	defer contextCancel()
+	client, err := m.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	match := bson.M{}
+	for key, value := range filter {
+		match[key] = value
+	}
+	match[m.TimeField] = bson.M{"$gte": since}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: m.MetaField, Value: 1}, {Key: m.GroupField, Value: 1}, {Key: m.TimeField, Value: -1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "series", Value: "$" + m.MetaField}, {Key: "group", Value: "$" + m.GroupField}}},
+			{Key: "latest", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}},
+		}}},
+		{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$latest"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: m.MetaField, Value: 1}, {Key: m.GroupField, Value: 1}}}},
+	}
+	cursor, err := client.Database(m.DbName).Collection(m.Collection).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	documents := []DocType{}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// rangeFilter adds the range of time to a copy of the filter
+func (m *timeSeriesSvc[DocType]) rangeFilter(filter bson.M, from time.Time, to time.Time) bson.M {
+	ranged := bson.M{}
+	for key, value := range filter {
+		ranged[key] = value
+	}
+	ranged[m.TimeField] = bson.M{"$gte": from, "$lt": to}
+	return ranged
+}
@@ -0,0 +1,61 @@
+package db_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/samsvi/mdm-webapi/internal/db_service")
+
+// startSpan starts span of the operation on the collection. Only identifiers
+// are recorded, never filters or documents, which may carry patient data.
+func (m *mongoSvc[DocType]) startSpan(ctx context.Context, operation string, id string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.namespace", m.DbName),
+		attribute.String("db.collection.name", m.Collection),
+		attribute.String("db.operation.name", operation),
+	}
+	if id != "" {
+		attributes = append(attributes, attribute.String("mdm.document.id", id))
+	}
+	return tracer.Start(ctx, operation+" "+m.Collection,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// endSpan records outcome of the operation. Error messages are not recorded
+// since errors of the server may quote document values, e.g. duplicate keys.
+func endSpan(span trace.Span, err error) {
+	switch {
+	case err == nil:
+	case err == ErrNotFound:
+		span.SetAttributes(attribute.String("error.type", "not_found"))
+	case errors.Is(err, ErrConflict):
+		span.SetAttributes(attribute.String("error.type", "conflict"))
+	default:
+		errorType := fmt.Sprintf("%T", err)
+		var commandErr mongo.CommandError
+		switch {
+		case mongo.IsDuplicateKeyError(err):
+			errorType = "duplicate_key"
+		case mongo.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+			errorType = "timeout"
+		case mongo.IsNetworkError(err):
+			errorType = "network"
+		case errors.As(err, &commandErr):
+			errorType = fmt.Sprintf("mongo.%d", commandErr.Code)
+		}
+		span.SetAttributes(attribute.String("error.type", errorType))
+		span.SetStatus(codes.Error, errorType)
+	}
+	span.End()
+}
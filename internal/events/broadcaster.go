@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// Filter selects events streamed to a subscriber. Empty fields match everything.
+type Filter struct {
+	PatientIds []string
+	// statuses of patients, applies only to patient events
+	Statuses []string
+}
+
+func (f Filter) Matches(event Event) bool {
+	if len(f.PatientIds) > 0 && !slices.Contains(f.PatientIds, event.PatientId) {
+		return false
+	}
+
+	if len(f.Statuses) > 0 {
+		var patient struct {
+			Status string `json:"status"`
+		}
+		if event.RecordId != "" || json.Unmarshal(event.Data, &patient) != nil {
+			return false
+		}
+		return slices.Contains(f.Statuses, patient.Status)
+	}
+	return true
+}
+
+type subscription struct {
+	filter Filter
+	events chan Event
+}
+
+// Broadcaster fans events out to many short lived subscribers, e.g. clients
+// of the live event stream. Events are dropped for subscribers not keeping up.
+type Broadcaster struct {
+	lock          sync.RWMutex
+	subscriptions map[*subscription]struct{}
+	bufferSize    int
+	closed        bool
+}
+
+func NewBroadcaster() *Broadcaster {
+	return &Broadcaster{
+		subscriptions: map[*subscription]struct{}{},
+		bufferSize:    64,
+	}
+}
+
+// Subscribe returns channel of events matching the filter and function
+// to be called once the subscriber is no longer interested. The channel is
+// closed when the broadcaster is closed.
+func (b *Broadcaster) Subscribe(filter Filter) (<-chan Event, func()) {
+	s := &subscription{
+		filter: filter,
+		events: make(chan Event, b.bufferSize),
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		close(s.events)
+		return s.events, func() {}
+	}
+	b.subscriptions[s] = struct{}{}
+
+	return s.events, func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		if _, ok := b.subscriptions[s]; ok {
+			delete(b.subscriptions, s)
+			close(s.events)
+		}
+	}
+}
+
+// Close ends streams of all subscribers, so long lived connections do not
+// hold the server from shutting down
+func (b *Broadcaster) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.closed = true
+	for s := range b.subscriptions {
+		delete(b.subscriptions, s)
+		close(s.events)
+	}
+}
+
+func (b *Broadcaster) Publish(ctx context.Context, event Event) error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for s := range b.subscriptions {
+		if !s.filter.Matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			slog.Warn("Event stream subscriber is not keeping up, dropping event", "event_id", event.Id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBroadcasterDropsEventsOfSlowSubscriber(t *testing.T) {
+	broadcaster := NewBroadcaster()
+	defer broadcaster.Close()
+
+	// the slow subscriber never reads its events
+	slow, unsubscribeSlow := broadcaster.Subscribe(Filter{})
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := broadcaster.Subscribe(Filter{})
+	defer unsubscribeFast()
+
+	// the fast subscriber reads every event before the next one is published
+	count := 3 * broadcaster.bufferSize
+	done := make(chan error)
+	go func() {
+		for i := 0; i < count; i++ {
+			broadcaster.Publish(context.Background(), Event{Id: fmt.Sprintf("evt%d", i)})
+			if event := <-fast; event.Id != fmt.Sprintf("evt%d", i) {
+				done <- fmt.Errorf("fast subscriber received %s, want evt%d", event.Id, i)
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing is blocked by the slow subscriber")
+	}
+
+	if len(slow) != broadcaster.bufferSize {
+		t.Fatalf("slow subscriber holds %d events, want its full buffer of %d", len(slow), broadcaster.bufferSize)
+	}
+	if event := <-slow; event.Id != "evt0" {
+		t.Fatalf("slow subscriber received %s first, want the events published before its buffer filled", event.Id)
+	}
+}
+
+func TestBroadcasterFiltersEvents(t *testing.T) {
+	broadcaster := NewBroadcaster()
+	events, unsubscribe := broadcaster.Subscribe(Filter{PatientIds: []string{"pat1"}})
+
+	broadcaster.Publish(context.Background(), Event{Id: "evt1", PatientId: "pat2"})
+	broadcaster.Publish(context.Background(), Event{Id: "evt2", PatientId: "pat1"})
+	if event := <-events; event.Id != "evt2" {
+		t.Fatalf("subscriber received %s, want only the events of its patient", event.Id)
+	}
+
+	unsubscribe()
+	broadcaster.Publish(context.Background(), Event{Id: "evt3", PatientId: "pat1"})
+	if _, open := <-events; open {
+		t.Fatal("stream of unsubscribed subscriber is open")
+	}
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Bus is an in-process broker delivering events synchronously to all
+// subscribers. It is used when no external broker is configured and as
+// a stand-in for external brokers in tests.
+type Bus struct {
+	lock        sync.RWMutex
+	subscribers []Publisher
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(subscriber Publisher) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers the event to every subscriber and returns their combined
+// error, so the caller may retry the event
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.lock.RLock()
+	subscribers := b.subscribers
+	b.lock.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := subscriber.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bus) Close() error {
+	return nil
+}
+
+// Fanout publishes every event to all the brokers
+type Fanout []Broker
+
+func (f Fanout) Publish(ctx context.Context, event Event) error {
+	var errs []error
+	for _, broker := range f {
+		if err := broker.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f Fanout) Close() error {
+	var errs []error
+	for _, broker := range f {
+		errs = append(errs, broker.Close())
+	}
+	return errors.Join(errs...)
+}
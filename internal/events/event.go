@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// Types of events emitted on changes of patients and medical records
+const (
+	PatientCreated       = "patient.created"
+	PatientUpdated       = "patient.updated"
+	PatientDeleted       = "patient.deleted"
+	PatientStatusChanged = "patient.status-changed"
+	// early warning score of the patient reached the alert threshold
+	PatientEarlyWarning  = "patient.early-warning"
+	MedicalRecordCreated = "medical-record.created"
+	MedicalRecordUpdated = "medical-record.updated"
+	MedicalRecordDeleted = "medical-record.deleted"
+)
+
+// Event describes a change of a patient or of a medical record. Data holds
+// JSON encoded resource as it was after the change.
+type Event struct {
+	Id         string          `json:"id"`
+	Type       string          `json:"type"`
+	PatientId  string          `json:"patientId"`
+	RecordId   string          `json:"recordId,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Broker delivers events to consumers, e.g. message broker adapters
+type Broker interface {
+	Publisher
+	Close() error
+}
+
+func NewEvent(eventType string, patientId string, recordId string, data interface{}) (Event, error) {
+	event := Event{
+		Id:         uuid.NewString(),
+		Type:       eventType,
+		PatientId:  patientId,
+		RecordId:   recordId,
+		OccurredAt: time.Now().UTC(),
+	}
+	if data != nil {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return Event{}, err
+		}
+		event.Data = payload
+	}
+	return event, nil
+}
+
+// IsKnownType reports whether the event type is one of the types emitted by the service
+func IsKnownType(eventType string) bool {
+	switch eventType {
+	case PatientCreated, PatientUpdated, PatientDeleted, PatientStatusChanged, PatientEarlyWarning,
+		MedicalRecordCreated, MedicalRecordUpdated, MedicalRecordDeleted:
+		return true
+	}
+	return false
+}
+
+// OutboxMessage converts the event to message stored in the outbox collection
+func (e Event) OutboxMessage() (db_service.OutboxMessage, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return db_service.OutboxMessage{}, err
+	}
+	return db_service.OutboxMessage{
+		Id:        e.Id,
+		Topic:     e.Type,
+		Key:       e.PatientId,
+		Payload:   payload,
+		CreatedAt: e.OccurredAt,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// FeedBroadcaster streams events to the broadcaster from change stream of the
+// outbox collection, so every instance sees changes made through any replica as
+// soon as they are committed. When the server does not support change streams,
+// the broadcaster is subscribed to events relayed to the in-process bus instead.
+func FeedBroadcaster(
+	ctx context.Context,
+	outbox db_service.DbService[db_service.OutboxMessage],
+	bus *Bus,
+	broadcaster *Broadcaster,
+) {
+	const retryDelay = 5 * time.Second
+
+	for {
+		messages, err := outbox.WatchInsertedDocuments(ctx)
+		switch err {
+		case nil:
+			for message := range messages {
+				var event Event
+				if err := json.Unmarshal(message.Payload, &event); err != nil {
+					slog.Error("Failed to decode outbox message", "message_id", message.Id, "error", err)
+					continue
+				}
+				broadcaster.Publish(ctx, event)
+			}
+		case db_service.ErrWatchUnsupported:
+			slog.Info("Change streams are not supported, streaming events relayed in-process")
+			bus.Subscribe(broadcaster)
+			return
+		default:
+			if ctx.Err() == nil {
+				slog.Error("Failed to watch outbox collection", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"github.com/segmentio/kafka-go"
+)
+
+type Config struct {
+	Brokers []string
+	Topic   string
+}
+
+// broker publishes events to a Kafka topic keyed by patient id, so events
+// of one patient keep their order within a partition
+type broker struct {
+	brokers []string
+	writer  *kafka.Writer
+}
+
+func NewBroker(config Config) events.Broker {
+	if config.Topic == "" {
+		config.Topic = "mdm-events"
+	}
+
+	return &broker{
+		brokers: config.Brokers,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(config.Brokers...),
+			Topic:                  config.Topic,
+			Balancer:               &kafka.Hash{},
+			RequiredAcks:           kafka.RequireAll,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+func (b *broker) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return b.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.PatientId),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "event-id", Value: []byte(event.Id)},
+			{Key: "event-type", Value: []byte(event.Type)},
+		},
+	})
+}
+
+// Ping verifies at least one of the brokers is reachable and responds
+func (b *broker) Ping(ctx context.Context) error {
+	var errs []error
+	for _, address := range b.brokers {
+		connection, err := kafka.DialContext(ctx, "tcp", address)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			connection.SetDeadline(deadline)
+		}
+		_, err = connection.Brokers()
+		connection.Close()
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return errors.New("no brokers configured")
+	}
+	return errors.Join(errs...)
+}
+
+func (b *broker) Close() error {
+	return b.writer.Close()
+}
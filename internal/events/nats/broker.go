@@ -0,0 +1,90 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/samsvi/mdm-webapi/internal/events"
+)
+
+type Config struct {
+	Url string
+	// events are published to subject <SubjectPrefix>.<event type>
+	SubjectPrefix string
+	// when not empty, the stream capturing all event subjects is created or updated on start
+	Stream string
+}
+
+// broker publishes events to NATS JetStream. The event id is used as message id,
+// so the server discards duplicates published by retries of the outbox relay.
+type broker struct {
+	Config
+	connection *nats.Conn
+	jetStream  jetstream.JetStream
+}
+
+func NewBroker(ctx context.Context, config Config) (events.Broker, error) {
+	if config.Url == "" {
+		config.Url = nats.DefaultURL
+	}
+	if config.SubjectPrefix == "" {
+		config.SubjectPrefix = "mdm"
+	}
+
+	connection, err := nats.Connect(config.Url, nats.Name("mdm-webapi"))
+	if err != nil {
+		return nil, err
+	}
+
+	jetStream, err := jetstream.New(connection)
+	if err != nil {
+		connection.Close()
+		return nil, err
+	}
+
+	if config.Stream != "" {
+		_, err := jetStream.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+			Name:     config.Stream,
+			Subjects: []string{config.SubjectPrefix + ".>"},
+		})
+		if err != nil {
+			connection.Close()
+			return nil, err
+		}
+	}
+
+	return &broker{
+		Config:     config,
+		connection: connection,
+		jetStream:  jetStream,
+	}, nil
+}
+
+func (b *broker) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	message := nats.NewMsg(b.SubjectPrefix + "." + event.Type)
+	message.Data = payload
+	message.Header.Set("Content-Type", "application/json")
+
+	_, err = b.jetStream.PublishMsg(ctx, message, jetstream.WithMsgID(event.Id))
+	return err
+}
+
+// Ping verifies the connection to the server is established and responsive
+func (b *broker) Ping(ctx context.Context) error {
+	if !b.connection.IsConnected() {
+		return fmt.Errorf("not connected to %s: %v", b.Url, b.connection.Status())
+	}
+	return b.connection.FlushWithContext(ctx)
+}
+
+func (b *broker) Close() error {
+	return b.connection.Drain()
+}
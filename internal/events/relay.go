@@ -0,0 +1,159 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+type RelayConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// time the relay holds claimed messages before other relays can claim them
+	Lease time.Duration
+	// attempts to publish a message before it is dead-lettered
+	MaxAttempts int
+	// backoff after the first failed attempt, doubled for every next one
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+}
+
+// Relay publishes messages written to the outbox collection to the broker.
+// A message is marked as published only after the broker accepted it, so
+// every event is delivered at least once. Consumers deduplicate by event id.
+//
+// Messages are claimed in the order of creation. A message failing to be
+// published is retried with exponential backoff while the following messages
+// are published, and dead-lettered once the attempts are exhausted. Messages
+// which cannot be decoded are dead-lettered at once.
+type Relay struct {
+	RelayConfig
+	outbox db_service.OutboxService
+	broker Publisher
+	// identifies the leases of this relay among replicas of the service
+	owner string
+}
+
+func NewRelay(outbox db_service.OutboxService, broker Publisher, config RelayConfig) *Relay {
+	r := &Relay{
+		RelayConfig: config,
+		outbox:      outbox,
+		broker:      broker,
+		owner:       uuid.NewString(),
+	}
+
+	if r.PollInterval == 0 {
+		r.PollInterval = time.Second
+	}
+	if r.BatchSize == 0 {
+		r.BatchSize = 100
+	}
+	if r.Lease == 0 {
+		r.Lease = time.Minute
+	}
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = 10
+	}
+	if r.RetryDelay == 0 {
+		r.RetryDelay = time.Second
+	}
+	if r.MaxRetryDelay == 0 {
+		r.MaxRetryDelay = 5 * time.Minute
+	}
+	return r
+}
+
+// Run relays outbox messages until the context is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		r.relayPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) relayPending(ctx context.Context) {
+	messages, err := r.outbox.ClaimMessages(ctx, r.owner, r.BatchSize, r.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Failed to claim outbox messages", "error", err)
+		}
+		return
+	}
+
+	// messages left unpublished are claimed again once their lease expires
+	for i := range messages {
+		if ctx.Err() != nil {
+			return
+		}
+		r.relay(ctx, &messages[i])
+	}
+}
+
+func (r *Relay) relay(ctx context.Context, message *db_service.OutboxMessage) {
+	now := time.Now()
+	var event Event
+	if err := json.Unmarshal(message.Payload, &event); err != nil {
+		slog.Error("Dead-lettering outbox message which cannot be decoded", "message_id", message.Id, "error", err)
+		message.LastError = err.Error()
+		message.DeadLetteredAt = &now
+		message.LeaseUntil = nil
+		r.release(ctx, message)
+		return
+	}
+
+	err := r.broker.Publish(ctx, event)
+	now = time.Now()
+	switch {
+	case err == nil:
+		message.PublishedAt = &now
+		message.LeaseUntil = nil
+		message.LastError = ""
+	case ctx.Err() != nil:
+		// cancelled by the shutdown, the message is claimed again after the lease
+		return
+	default:
+		message.Attempts++
+		message.LastError = err.Error()
+		if message.Attempts >= r.MaxAttempts {
+			slog.Error("Dead-lettering outbox message", "message_id", message.Id, "attempts", message.Attempts, "error", err)
+			message.DeadLetteredAt = &now
+			message.LeaseUntil = nil
+		} else {
+			slog.Warn("Failed to publish outbox message", "message_id", message.Id, "attempts", message.Attempts, "error", err)
+			retryAt := now.Add(r.retryDelay(message.Attempts))
+			message.LeaseUntil = &retryAt
+		}
+	}
+	r.release(ctx, message)
+}
+
+func (r *Relay) release(ctx context.Context, message *db_service.OutboxMessage) {
+	switch err := r.outbox.ReleaseMessage(ctx, r.owner, message); err {
+	case nil:
+	case db_service.ErrNotFound:
+		slog.Warn("Lease of outbox message expired before it was released", "message_id", message.Id)
+	default:
+		slog.Error("Failed to update outbox message", "message_id", message.Id, "error", err)
+	}
+}
+
+// retryDelay returns backoff after the given number of failed attempts
+func (r *Relay) retryDelay(attempts int) time.Duration {
+	delay := r.RetryDelay << (attempts - 1)
+	if delay > r.MaxRetryDelay || delay <= 0 {
+		return r.MaxRetryDelay
+	}
+	return delay
+}
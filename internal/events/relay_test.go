@@ -0,0 +1,156 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// memoryOutbox keeps outbox messages in memory and claims them like the
+// Mongo implementation, other operations of DbService are not used by the
+// relay
+type memoryOutbox struct {
+	db_service.DbService[db_service.OutboxMessage]
+	messages map[string]*db_service.OutboxMessage
+	now      time.Time
+}
+
+func newMemoryOutbox(t *testing.T, events ...Event) *memoryOutbox {
+	t.Helper()
+	outbox := &memoryOutbox{messages: map[string]*db_service.OutboxMessage{}, now: time.Now()}
+	for i, event := range events {
+		message, err := event.OutboxMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		message.CreatedAt = outbox.now.Add(time.Duration(i) * time.Millisecond)
+		outbox.messages[message.Id] = &message
+	}
+	return outbox
+}
+
+func (o *memoryOutbox) ClaimMessages(ctx context.Context, owner string, limit int, lease time.Duration) ([]db_service.OutboxMessage, error) {
+	claimable := []*db_service.OutboxMessage{}
+	for _, message := range o.messages {
+		if message.PublishedAt == nil && message.DeadLetteredAt == nil &&
+			(message.LeaseUntil == nil || !message.LeaseUntil.After(o.now)) {
+			claimable = append(claimable, message)
+		}
+	}
+	sort.Slice(claimable, func(i, j int) bool { return claimable[i].CreatedAt.Before(claimable[j].CreatedAt) })
+	if len(claimable) > limit {
+		claimable = claimable[:limit]
+	}
+
+	leaseUntil := o.now.Add(lease)
+	claimed := []db_service.OutboxMessage{}
+	for _, message := range claimable {
+		message.LeaseOwner = owner
+		message.LeaseUntil = &leaseUntil
+		claimed = append(claimed, *message)
+	}
+	return claimed, nil
+}
+
+func (o *memoryOutbox) ReleaseMessage(ctx context.Context, owner string, message *db_service.OutboxMessage) error {
+	stored, ok := o.messages[message.Id]
+	if !ok || stored.LeaseOwner != owner {
+		return db_service.ErrNotFound
+	}
+	released := *message
+	o.messages[message.Id] = &released
+	return nil
+}
+
+// failingPublisher fails to publish events of the given ids
+type failingPublisher struct {
+	failing   map[string]bool
+	published []string
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, event Event) error {
+	if p.failing[event.Id] {
+		return errors.New("broker rejected the event")
+	}
+	p.published = append(p.published, event.Id)
+	return nil
+}
+
+func TestRelayContinuesPastFailedMessage(t *testing.T) {
+	first := Event{Id: "first", Type: PatientCreated}
+	second := Event{Id: "second", Type: PatientUpdated}
+	outbox := newMemoryOutbox(t, first, second)
+	broker := &failingPublisher{failing: map[string]bool{"first": true}}
+	relay := NewRelay(outbox, broker, RelayConfig{MaxAttempts: 3})
+
+	relay.relayPending(context.Background())
+
+	if len(broker.published) != 1 || broker.published[0] != "second" {
+		t.Fatalf("published %v, want [second]", broker.published)
+	}
+	failed := outbox.messages["first"]
+	if failed.Attempts != 1 || failed.LastError == "" || failed.PublishedAt != nil {
+		t.Fatalf("failed message %+v, want one recorded attempt", failed)
+	}
+	if failed.LeaseUntil == nil || !failed.LeaseUntil.After(outbox.now) {
+		t.Fatalf("failed message is not backed off: %v", failed.LeaseUntil)
+	}
+	if outbox.messages["second"].PublishedAt == nil {
+		t.Fatal("second message is not marked as published")
+	}
+
+	// backoff keeps the failed message from being claimed right away
+	relay.relayPending(context.Background())
+	if failed := outbox.messages["first"]; failed.Attempts != 1 {
+		t.Fatalf("message retried during backoff, attempts %d", failed.Attempts)
+	}
+}
+
+func TestRelayDeadLettersMessage(t *testing.T) {
+	outbox := newMemoryOutbox(t, Event{Id: "first", Type: PatientCreated})
+	broker := &failingPublisher{failing: map[string]bool{"first": true}}
+	relay := NewRelay(outbox, broker, RelayConfig{MaxAttempts: 3})
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		relay.relayPending(context.Background())
+		// let the backoff elapse
+		outbox.now = outbox.now.Add(relay.MaxRetryDelay)
+	}
+
+	message := outbox.messages["first"]
+	if message.Attempts != 3 {
+		t.Fatalf("attempts %d, want 3", message.Attempts)
+	}
+	if message.DeadLetteredAt == nil || message.LeaseUntil != nil {
+		t.Fatalf("message %+v is not dead-lettered", message)
+	}
+}
+
+func TestRelayDeadLettersUndecodableMessage(t *testing.T) {
+	outbox := newMemoryOutbox(t)
+	outbox.messages["broken"] = &db_service.OutboxMessage{Id: "broken", Payload: []byte("{"), CreatedAt: outbox.now}
+	broker := &failingPublisher{}
+	relay := NewRelay(outbox, broker, RelayConfig{})
+
+	relay.relayPending(context.Background())
+
+	if message := outbox.messages["broken"]; message.DeadLetteredAt == nil {
+		t.Fatalf("message %+v is not dead-lettered", message)
+	}
+	if len(broker.published) != 0 {
+		t.Fatalf("published %v", broker.published)
+	}
+}
+
+func TestRelayRetryDelay(t *testing.T) {
+	relay := NewRelay(nil, nil, RelayConfig{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second})
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 100: 5 * time.Second} {
+		if got := relay.retryDelay(attempts); got != want {
+			t.Errorf("retryDelay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+type request struct {
+	Query         string                 `json:"query" form:"query"`
+	OperationName string                 `json:"operationName" form:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewHandler returns handler of the /graphql endpoint serving patients and
+// their medical records. Queries are served over GET and POST, mutations only
+// over POST, so that links and prefetching cannot change the data.
+func NewHandler(
+	patientsDb db_service.DbService[mdm.Patient],
+	medicalRecordsDb db_service.DbService[mdm.MedicalRecord],
+) (gin.HandlerFunc, error) {
+	patients := mdm.NewPatientsService(patientsDb)
+	records := mdm.NewMedicalRecordsService(medicalRecordsDb, patientsDb)
+
+	schema, err := newSchema(patients, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		var req request
+		if c.Request.Method == http.MethodGet {
+			req.Query = c.Query("query")
+			req.OperationName = c.Query("operationName")
+			if variables := c.Query("variables"); variables != "" {
+				if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{
+						"status":  "Bad Request",
+						"message": "Invalid variables",
+						"error":   err.Error(),
+					})
+					return
+				}
+			}
+		} else if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Invalid request body",
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		if req.Query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Query is required",
+			})
+			return
+		}
+
+		if c.Request.Method == http.MethodGet && isMutation(req.Query, req.OperationName) {
+			c.Header("Allow", http.MethodPost)
+			c.JSON(http.StatusMethodNotAllowed, gin.H{
+				"status":  "Method Not Allowed",
+				"message": "Mutations must be sent by POST",
+			})
+			return
+		}
+
+		ctx := context.WithValue(c.Request.Context(), loaderContextKey{}, newRecordsLoader(records))
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        ctx,
+		})
+
+		c.JSON(http.StatusOK, result)
+	}, nil
+}
+
+// isMutation reports whether the operation of the query selected by the name
+// is a mutation. Any mutation matches when the name is empty. Queries which
+// do not parse are not mutations, their errors are reported by the execution.
+func isMutation(query string, operationName string) bool {
+	document, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return false
+	}
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok || operation.Operation != ast.OperationTypeMutation {
+			continue
+		}
+		if operationName == "" || (operation.Name != nil && operation.Name.Value == operationName) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIsMutation(t *testing.T) {
+	cases := []struct {
+		query         string
+		operationName string
+		want          bool
+	}{
+		{`{ patients { id } }`, "", false},
+		{`query { patients { id } }`, "", false},
+		{`mutation { deletePatient(id: "1") }`, "", true},
+		{`query List { patients { id } } mutation Delete { deletePatient(id: "1") }`, "List", false},
+		{`query List { patients { id } } mutation Delete { deletePatient(id: "1") }`, "Delete", true},
+		{`query List { patients { id } } mutation Delete { deletePatient(id: "1") }`, "", true},
+		{`mutation {`, "", false},
+	}
+	for _, c := range cases {
+		if got := isMutation(c.query, c.operationName); got != c.want {
+			t.Errorf("isMutation(%q, %q) = %v, want %v", c.query, c.operationName, got, c.want)
+		}
+	}
+}
+
+func TestHandlerRejectsMutationOverGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, err := NewHandler(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := gin.New()
+	router.GET("/graphql", handler)
+
+	query := url.Values{"query": {`mutation { deletePatient(id: "1") }`}}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil))
+
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := recorder.Header().Get("Allow"); allow != http.MethodPost {
+		t.Fatalf("Allow %q, want POST", allow)
+	}
+}
@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+type loaderContextKey struct{}
+
+// recordsLoader loads medical records of all patients resolved within one
+// request with a single query. Resolvers of patient lists prime the loader
+// with ids of all the patients, so resolving records of the first patient
+// loads records of the others as well instead of querying once per patient.
+type recordsLoader struct {
+	service *mdm.MedicalRecordsService
+	lock    sync.Mutex
+	primed  map[string]struct{}
+	loaded  map[string][]mdm.MedicalRecord
+}
+
+func newRecordsLoader(service *mdm.MedicalRecordsService) *recordsLoader {
+	return &recordsLoader{
+		service: service,
+		primed:  map[string]struct{}{},
+		loaded:  map[string][]mdm.MedicalRecord{},
+	}
+}
+
+func loaderFromContext(ctx context.Context) *recordsLoader {
+	loader, _ := ctx.Value(loaderContextKey{}).(*recordsLoader)
+	return loader
+}
+
+func (l *recordsLoader) Prime(patientIds ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for _, patientId := range patientIds {
+		if _, ok := l.loaded[patientId]; !ok {
+			l.primed[patientId] = struct{}{}
+		}
+	}
+}
+
+// Load returns records of the patient ordered from the most recent visit
+func (l *recordsLoader) Load(ctx context.Context, patientId string) ([]mdm.MedicalRecord, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if records, ok := l.loaded[patientId]; ok {
+		return records, nil
+	}
+
+	l.primed[patientId] = struct{}{}
+	patientIds := make([]string, 0, len(l.primed))
+	for id := range l.primed {
+		patientIds = append(patientIds, id)
+	}
+
+	records, err := l.service.GetMedicalRecordsOfPatients(ctx, patientIds)
+	if err != nil {
+		return nil, err
+	}
+
+	mdm.SortByDateOfVisit(records)
+	for _, id := range patientIds {
+		l.loaded[id] = []mdm.MedicalRecord{}
+		delete(l.primed, id)
+	}
+	for _, record := range records {
+		l.loaded[record.PatientId] = append(l.loaded[record.PatientId], record)
+	}
+	return l.loaded[patientId], nil
+}
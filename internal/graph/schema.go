@@ -0,0 +1,258 @@
+package graph
+
+import (
+	"errors"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// resolverError carries error code to the extensions of GraphQL error
+type resolverError struct {
+	message string
+	code    string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// translateError maps errors of the services to the same outcomes as in REST API
+func translateError(err error) error {
+	var validationErr *mdm.ValidationError
+	var allergyErr *mdm.AllergyConflictError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &validationErr):
+		return &resolverError{message: validationErr.Message, code: "BAD_USER_INPUT"}
+	case errors.As(err, &allergyErr):
+		return &resolverError{message: allergyErr.Error(), code: "ALLERGY_CONFLICT"}
+	case err == mdm.ErrIdMismatch:
+		return &resolverError{message: err.Error(), code: "FORBIDDEN"}
+	case err == db_service.ErrNotFound:
+		return &resolverError{message: "Not found", code: "NOT_FOUND"}
+	case errors.Is(err, db_service.ErrConflict):
+		return &resolverError{message: mdm.ConflictMessage(err, "Already exists"), code: "CONFLICT"}
+	case errors.Is(err, db_service.ErrUnavailable):
+		return &resolverError{message: err.Error(), code: "SERVICE_UNAVAILABLE"}
+	default:
+		return &resolverError{message: err.Error(), code: "BAD_GATEWAY"}
+	}
+}
+
+func newSchema(patients *mdm.PatientsService, records *mdm.MedicalRecordsService) (graphql.Schema, error) {
+	types := newTypeRegistry()
+
+	medicalRecordType := types.object(mdm.MedicalRecord{}, nil)
+	medicationType := types.object(mdm.Medication{}, nil)
+	patientType := types.object(mdm.Patient{}, graphql.Fields{
+		"medicalRecords": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(medicalRecordType))),
+			Description: "Medical records of the patient ordered from the most recent visit",
+			Args: graphql.FieldConfigArgument{
+				"last": &graphql.ArgumentConfig{
+					Type:        graphql.Int,
+					Description: "Return only given number of the most recent records",
+				},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				patient := p.Source.(mdm.Patient)
+				patientRecords, err := loaderFromContext(p.Context).Load(p.Context, patient.Id)
+				if err != nil {
+					return nil, translateError(err)
+				}
+				if last, ok := p.Args["last"].(int); ok && last >= 0 && last < len(patientRecords) {
+					patientRecords = patientRecords[:last]
+				}
+				return patientRecords, nil
+			},
+		},
+		"activeMedications": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(medicationType))),
+			Description: "Medications of the patient whose treatment has not ended yet",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				patient := p.Source.(mdm.Patient)
+				patientRecords, err := loaderFromContext(p.Context).Load(p.Context, patient.Id)
+				if err != nil {
+					return nil, translateError(err)
+				}
+				return mdm.ActiveMedications(patientRecords, time.Now()), nil
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"patients": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(patientType))),
+				Description: "All patients, optionally only those in given status",
+				Args: graphql.FieldConfigArgument{
+					"status": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					all, err := patients.GetAllPatients(p.Context)
+					if err != nil {
+						return nil, translateError(err)
+					}
+					status, _ := p.Args["status"].(string)
+					result := []mdm.Patient{}
+					ids := []string{}
+					for _, patient := range all {
+						if status == "" || patient.Status == status {
+							result = append(result, patient)
+							ids = append(ids, patient.Id)
+						}
+					}
+					loaderFromContext(p.Context).Prime(ids...)
+					return result, nil
+				},
+			},
+			"patient": &graphql.Field{
+				Type:        patientType,
+				Description: "Patient with given ID, null if there is no such patient",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					patient, err := patients.GetPatient(p.Context, p.Args["id"].(string))
+					switch err {
+					case nil:
+						return *patient, nil
+					case db_service.ErrNotFound:
+						return nil, nil
+					default:
+						return nil, translateError(err)
+					}
+				},
+			},
+			"medicalRecords": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(medicalRecordType))),
+				Description: "Medical records of the patient ordered from the most recent visit",
+				Args: graphql.FieldConfigArgument{
+					"patientId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					patientRecords, err := loaderFromContext(p.Context).Load(p.Context, p.Args["patientId"].(string))
+					return patientRecords, translateError(err)
+				},
+			},
+		},
+	})
+
+	patientInput := types.input(mdm.Patient{})
+	medicalRecordInput := types.input(mdm.MedicalRecord{})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPatient": &graphql.Field{
+				Type: patientType,
+				Args: graphql.FieldConfigArgument{
+					"patient": &graphql.ArgumentConfig{Type: graphql.NewNonNull(patientInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var patient mdm.Patient
+					if err := decodeInput(p.Args["patient"], &patient); err != nil {
+						return nil, err
+					}
+					if err := patients.CreatePatient(p.Context, &patient); err != nil {
+						return nil, translateError(err)
+					}
+					return patient, nil
+				},
+			},
+			"updatePatient": &graphql.Field{
+				Type: patientType,
+				Args: graphql.FieldConfigArgument{
+					"id":      &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"patient": &graphql.ArgumentConfig{Type: graphql.NewNonNull(patientInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var patient mdm.Patient
+					if err := decodeInput(p.Args["patient"], &patient); err != nil {
+						return nil, err
+					}
+					if err := patients.UpdatePatient(p.Context, p.Args["id"].(string), &patient); err != nil {
+						return nil, translateError(err)
+					}
+					return patient, nil
+				},
+			},
+			"deletePatient": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if err := patients.DeletePatient(p.Context, p.Args["id"].(string)); err != nil {
+						return nil, translateError(err)
+					}
+					return true, nil
+				},
+			},
+			"createMedicalRecord": &graphql.Field{
+				Type: medicalRecordType,
+				Args: graphql.FieldConfigArgument{
+					"patientId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"record":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(medicalRecordInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var record mdm.MedicalRecord
+					if err := decodeInput(p.Args["record"], &record); err != nil {
+						return nil, err
+					}
+					if err := records.CreateMedicalRecord(p.Context, p.Args["patientId"].(string), &record); err != nil {
+						return nil, translateError(err)
+					}
+					return record, nil
+				},
+			},
+			"updateMedicalRecord": &graphql.Field{
+				Type: medicalRecordType,
+				Args: graphql.FieldConfigArgument{
+					"patientId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"recordId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"record":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(medicalRecordInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var record mdm.MedicalRecord
+					if err := decodeInput(p.Args["record"], &record); err != nil {
+						return nil, err
+					}
+					patientId, recordId := p.Args["patientId"].(string), p.Args["recordId"].(string)
+					if err := records.UpdateMedicalRecord(p.Context, patientId, recordId, &record); err != nil {
+						return nil, translateError(err)
+					}
+					return record, nil
+				},
+			},
+			"deleteMedicalRecord": &graphql.Field{
+				Type: graphql.Boolean,
+				Args: graphql.FieldConfigArgument{
+					"patientId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"recordId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					patientId, recordId := p.Args["patientId"].(string), p.Args["recordId"].(string)
+					if err := records.DeleteMedicalRecord(p.Context, patientId, recordId); err != nil {
+						return nil, translateError(err)
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:    query,
+		Mutation: mutation,
+	})
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeRegistry derives GraphQL object and input types from the API models,
+// so the schema follows changes of the OpenAPI specification. Field names are
+// taken from json tags, fields without omitempty are non-nullable.
+type typeRegistry struct {
+	objects map[reflect.Type]*graphql.Object
+	inputs  map[reflect.Type]*graphql.InputObject
+}
+
+func newTypeRegistry() *typeRegistry {
+	return &typeRegistry{
+		objects: map[reflect.Type]*graphql.Object{},
+		inputs:  map[reflect.Type]*graphql.InputObject{},
+	}
+}
+
+// object returns GraphQL object for the model, extra fields are added to the
+// derived ones on the first call for the model
+func (r *typeRegistry) object(model interface{}, extra graphql.Fields) *graphql.Object {
+	return r.objectOf(reflect.TypeOf(model), extra)
+}
+
+func (r *typeRegistry) objectOf(t reflect.Type, extra graphql.Fields) *graphql.Object {
+	if object, ok := r.objects[t]; ok {
+		return object
+	}
+
+	fields := graphql.Fields{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitempty := jsonName(field)
+		if name == "" {
+			continue
+		}
+
+		var fieldType graphql.Output = r.output(field.Type)
+		if !omitempty && field.Type.Kind() != reflect.Struct {
+			fieldType = graphql.NewNonNull(fieldType)
+		}
+
+		index := i
+		fields[name] = &graphql.Field{
+			Type: fieldType,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				value := reflect.Indirect(reflect.ValueOf(p.Source)).Field(index)
+				if value.Kind() == reflect.Struct && value.IsZero() {
+					return nil, nil
+				}
+				return value.Interface(), nil
+			},
+		}
+	}
+	for name, field := range extra {
+		fields[name] = field
+	}
+
+	object := graphql.NewObject(graphql.ObjectConfig{
+		Name:   t.Name(),
+		Fields: fields,
+	})
+	r.objects[t] = object
+	return object
+}
+
+func (r *typeRegistry) output(t reflect.Type) graphql.Output {
+	switch t.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	case reflect.Slice:
+		return graphql.NewList(graphql.NewNonNull(r.output(t.Elem())))
+	case reflect.Ptr:
+		return r.output(t.Elem())
+	case reflect.Struct:
+		if t == timeType {
+			return graphql.DateTime
+		}
+		return r.objectOf(t, nil)
+	default:
+		return graphql.String
+	}
+}
+
+// input returns GraphQL input object for the model. All the fields are
+// optional, requirements are checked by the same services as in REST API.
+func (r *typeRegistry) input(model interface{}) *graphql.InputObject {
+	return r.inputOf(reflect.TypeOf(model))
+}
+
+func (r *typeRegistry) inputOf(t reflect.Type) *graphql.InputObject {
+	if input, ok := r.inputs[t]; ok {
+		return input
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for i := 0; i < t.NumField(); i++ {
+		name, _ := jsonName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		fields[name] = &graphql.InputObjectFieldConfig{
+			Type: r.inputType(t.Field(i).Type),
+		}
+	}
+
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   t.Name() + "Input",
+		Fields: fields,
+	})
+	r.inputs[t] = input
+	return input
+}
+
+func (r *typeRegistry) inputType(t reflect.Type) graphql.Input {
+	switch t.Kind() {
+	case reflect.Bool:
+		return graphql.Boolean
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return graphql.Int
+	case reflect.Float32, reflect.Float64:
+		return graphql.Float
+	case reflect.Slice:
+		return graphql.NewList(graphql.NewNonNull(r.inputType(t.Elem())))
+	case reflect.Ptr:
+		return r.inputType(t.Elem())
+	case reflect.Struct:
+		if t == timeType {
+			return graphql.DateTime
+		}
+		return r.inputOf(t)
+	default:
+		return graphql.String
+	}
+}
+
+// decodeInput converts input object argument to the model
+func decodeInput(arg interface{}, model interface{}) error {
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, model)
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" || !field.IsExported() {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
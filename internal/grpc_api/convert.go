@@ -0,0 +1,250 @@
+package grpc_api
+
+import (
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	mdmpb "github.com/samsvi/mdm-webapi/pkg/mdmpb/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toPatientPb(patient *mdm.Patient) *mdmpb.Patient {
+	allergies := make([]*mdmpb.Allergy, 0, len(patient.Allergies))
+	for _, allergy := range patient.Allergies {
+		allergies = append(allergies, &mdmpb.Allergy{
+			Substance: allergy.Substance,
+			Reaction:  allergy.Reaction,
+			Severity:  allergy.Severity,
+			Verified:  allergy.Verified,
+		})
+	}
+	return &mdmpb.Patient{
+		Id:              patient.Id,
+		FirstName:       patient.FirstName,
+		LastName:        patient.LastName,
+		DateOfBirth:     patient.DateOfBirth,
+		Gender:          patient.Gender,
+		InsuranceNumber: patient.InsuranceNumber,
+		BloodType:       patient.BloodType,
+		Status:          patient.Status,
+		Allergies:       allergies,
+		MedicalNotes:    patient.MedicalNotes,
+		Address: &mdmpb.Address{
+			Street:     patient.Address.Street,
+			City:       patient.Address.City,
+			PostalCode: patient.Address.PostalCode,
+			Country:    patient.Address.Country,
+		},
+		EmergencyContact: &mdmpb.EmergencyContact{
+			Name:         patient.EmergencyContact.Name,
+			Relationship: patient.EmergencyContact.Relationship,
+			PhoneNumber:  patient.EmergencyContact.PhoneNumber,
+		},
+		CreatedAt: toTimestamp(patient.CreatedAt),
+		UpdatedAt: toTimestamp(patient.UpdatedAt),
+	}
+}
+
+func fromPatientPb(patient *mdmpb.Patient) mdm.Patient {
+	var allergies []mdm.Allergy
+	for _, allergy := range patient.GetAllergies() {
+		allergies = append(allergies, mdm.Allergy{
+			Substance: allergy.GetSubstance(),
+			Reaction:  allergy.GetReaction(),
+			Severity:  allergy.GetSeverity(),
+			Verified:  allergy.GetVerified(),
+		})
+	}
+	return mdm.Patient{
+		Id:              patient.GetId(),
+		FirstName:       patient.GetFirstName(),
+		LastName:        patient.GetLastName(),
+		DateOfBirth:     patient.GetDateOfBirth(),
+		Gender:          patient.GetGender(),
+		InsuranceNumber: patient.GetInsuranceNumber(),
+		BloodType:       patient.GetBloodType(),
+		Status:          patient.GetStatus(),
+		Allergies:       allergies,
+		MedicalNotes:    patient.GetMedicalNotes(),
+		Address: mdm.Address{
+			Street:     patient.GetAddress().GetStreet(),
+			City:       patient.GetAddress().GetCity(),
+			PostalCode: patient.GetAddress().GetPostalCode(),
+			Country:    patient.GetAddress().GetCountry(),
+		},
+		EmergencyContact: mdm.EmergencyContact{
+			Name:         patient.GetEmergencyContact().GetName(),
+			Relationship: patient.GetEmergencyContact().GetRelationship(),
+			PhoneNumber:  patient.GetEmergencyContact().GetPhoneNumber(),
+		},
+		CreatedAt: fromTimestamp(patient.GetCreatedAt()),
+		UpdatedAt: fromTimestamp(patient.GetUpdatedAt()),
+	}
+}
+
+func toMedicalRecordPb(record *mdm.MedicalRecord) *mdmpb.MedicalRecord {
+	medications := make([]*mdmpb.Medication, 0, len(record.Medications))
+	for i := range record.Medications {
+		medications = append(medications, toMedicationPb(&record.Medications[i]))
+	}
+	secondaryDiagnoses := make([]*mdmpb.CodedDiagnosis, 0, len(record.SecondaryDiagnoses))
+	for i := range record.SecondaryDiagnoses {
+		secondaryDiagnoses = append(secondaryDiagnoses, toCodedDiagnosisPb(&record.SecondaryDiagnoses[i]))
+	}
+	allergyWarnings := make([]*mdmpb.AllergyWarning, 0, len(record.AllergyWarnings))
+	for _, warning := range record.AllergyWarnings {
+		allergyWarnings = append(allergyWarnings, &mdmpb.AllergyWarning{
+			Medication: warning.Medication,
+			Substance:  warning.Substance,
+			Severity:   warning.Severity,
+			Verified:   warning.Verified,
+		})
+	}
+	interactions := make([]*mdmpb.DrugInteraction, 0, len(record.Interactions))
+	for _, interaction := range record.Interactions {
+		interactions = append(interactions, &mdmpb.DrugInteraction{
+			Medication:            interaction.Medication,
+			AtcCode:               interaction.AtcCode,
+			InteractsWith:         interaction.InteractsWith,
+			InteractsWithAtcCode:  interaction.InteractsWithAtcCode,
+			InteractsWithRecordId: interaction.InteractsWithRecordId,
+			Severity:              interaction.Severity,
+			Description:           interaction.Description,
+		})
+	}
+	reconciliations := make([]*mdmpb.MedicationReconciliation, 0, len(record.Reconciliations))
+	for _, reconciliation := range record.Reconciliations {
+		reconciliations = append(reconciliations, &mdmpb.MedicationReconciliation{
+			MedicationId: reconciliation.MedicationId,
+			RecordId:     reconciliation.RecordId,
+			Name:         reconciliation.Name,
+			Action:       reconciliation.Action,
+			Medication:   toMedicationPb(reconciliation.Medication),
+			Reason:       reconciliation.Reason,
+		})
+	}
+	return &mdmpb.MedicalRecord{
+		Id:           record.Id,
+		PatientId:    record.PatientId,
+		DateOfVisit:  toTimestamp(record.DateOfVisit),
+		Diagnosis:    record.Diagnosis,
+		Symptoms:     record.Symptoms,
+		Treatment:    record.Treatment,
+		Medications:  medications,
+		DoctorName:   record.DoctorName,
+		Notes:        record.Notes,
+		FollowUpDate: record.FollowUpDate,
+		CreatedAt:    toTimestamp(record.CreatedAt),
+		UpdatedAt:    toTimestamp(record.UpdatedAt),
+
+		PrimaryDiagnosis:   toCodedDiagnosisPb(record.PrimaryDiagnosis),
+		SecondaryDiagnoses: secondaryDiagnoses,
+		AllergyOverride:    record.AllergyOverride,
+		AllergyWarnings:    allergyWarnings,
+		Interactions:       interactions,
+		Reconciliations:    reconciliations,
+	}
+}
+
+func fromMedicalRecordPb(record *mdmpb.MedicalRecord) mdm.MedicalRecord {
+	var medications []mdm.Medication
+	for _, medication := range record.GetMedications() {
+		medications = append(medications, *fromMedicationPb(medication))
+	}
+	var secondaryDiagnoses []mdm.CodedDiagnosis
+	for _, diagnosis := range record.GetSecondaryDiagnoses() {
+		secondaryDiagnoses = append(secondaryDiagnoses, *fromCodedDiagnosisPb(diagnosis))
+	}
+	var reconciliations []mdm.MedicationReconciliation
+	for _, reconciliation := range record.GetReconciliations() {
+		reconciliations = append(reconciliations, mdm.MedicationReconciliation{
+			MedicationId: reconciliation.GetMedicationId(),
+			RecordId:     reconciliation.GetRecordId(),
+			Name:         reconciliation.GetName(),
+			Action:       reconciliation.GetAction(),
+			Medication:   fromMedicationPb(reconciliation.GetMedication()),
+			Reason:       reconciliation.GetReason(),
+		})
+	}
+	return mdm.MedicalRecord{
+		Id:           record.GetId(),
+		PatientId:    record.GetPatientId(),
+		DateOfVisit:  fromTimestamp(record.GetDateOfVisit()),
+		Diagnosis:    record.GetDiagnosis(),
+		Symptoms:     record.GetSymptoms(),
+		Treatment:    record.GetTreatment(),
+		Medications:  medications,
+		DoctorName:   record.GetDoctorName(),
+		Notes:        record.GetNotes(),
+		FollowUpDate: record.GetFollowUpDate(),
+		CreatedAt:    fromTimestamp(record.GetCreatedAt()),
+		UpdatedAt:    fromTimestamp(record.GetUpdatedAt()),
+
+		PrimaryDiagnosis:   fromCodedDiagnosisPb(record.GetPrimaryDiagnosis()),
+		SecondaryDiagnoses: secondaryDiagnoses,
+		AllergyOverride:    record.GetAllergyOverride(),
+		Reconciliations:    reconciliations,
+	}
+}
+
+func toMedicationPb(medication *mdm.Medication) *mdmpb.Medication {
+	if medication == nil {
+		return nil
+	}
+	return &mdmpb.Medication{
+		Id:        medication.Id,
+		Name:      medication.Name,
+		AtcCode:   medication.AtcCode,
+		Dosage:    medication.Dosage,
+		Frequency: medication.Frequency,
+		Duration:  medication.Duration,
+		StartDate: medication.StartDate,
+		EndDate:   medication.EndDate,
+		Status:    medication.Status,
+	}
+}
+
+func fromMedicationPb(medication *mdmpb.Medication) *mdm.Medication {
+	if medication == nil {
+		return nil
+	}
+	return &mdm.Medication{
+		Id:        medication.GetId(),
+		Name:      medication.GetName(),
+		AtcCode:   medication.GetAtcCode(),
+		Dosage:    medication.GetDosage(),
+		Frequency: medication.GetFrequency(),
+		Duration:  medication.GetDuration(),
+		StartDate: medication.GetStartDate(),
+		EndDate:   medication.GetEndDate(),
+		Status:    medication.GetStatus(),
+	}
+}
+
+func toCodedDiagnosisPb(diagnosis *mdm.CodedDiagnosis) *mdmpb.CodedDiagnosis {
+	if diagnosis == nil {
+		return nil
+	}
+	return &mdmpb.CodedDiagnosis{Code: diagnosis.Code, Display: diagnosis.Display}
+}
+
+func fromCodedDiagnosisPb(diagnosis *mdmpb.CodedDiagnosis) *mdm.CodedDiagnosis {
+	if diagnosis == nil {
+		return nil
+	}
+	return &mdm.CodedDiagnosis{Code: diagnosis.GetCode(), Display: diagnosis.GetDisplay()}
+}
@@ -0,0 +1,177 @@
+package grpc_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	mdmpb "github.com/samsvi/mdm-webapi/pkg/mdmpb/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer returns gRPC server exposing patients and medical records through
+// the same services as the REST API, together with server reflection and the
+// standard health service.
+func NewServer(
+	patientsDb db_service.DbService[mdm.Patient],
+	medicalRecordsDb db_service.DbService[mdm.MedicalRecord],
+) *grpc.Server {
+	server := grpc.NewServer()
+
+	mdmpb.RegisterPatientsServiceServer(server, &patientsServer{
+		service: mdm.NewPatientsService(patientsDb),
+	})
+	mdmpb.RegisterMedicalRecordsServiceServer(server, &medicalRecordsServer{
+		service: mdm.NewMedicalRecordsService(medicalRecordsDb, patientsDb),
+	})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return server
+}
+
+// translateError maps errors of the services to the same outcomes as in REST API
+func translateError(err error) error {
+	var validationErr *mdm.ValidationError
+	var allergyErr *mdm.AllergyConflictError
+	switch {
+	case errors.As(err, &validationErr):
+		return status.Error(codes.InvalidArgument, validationErr.Message)
+	case errors.As(err, &allergyErr):
+		return status.Error(codes.FailedPrecondition, allergyErr.Error())
+	case err == mdm.ErrIdMismatch:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case err == db_service.ErrNotFound:
+		return status.Error(codes.NotFound, "Not found")
+	case errors.Is(err, db_service.ErrConflict):
+		return status.Error(codes.AlreadyExists, mdm.ConflictMessage(err, "Already exists"))
+	case errors.Is(err, db_service.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+type patientsServer struct {
+	mdmpb.UnimplementedPatientsServiceServer
+	service *mdm.PatientsService
+}
+
+func (s *patientsServer) ListPatients(ctx context.Context, req *mdmpb.ListPatientsRequest) (*mdmpb.ListPatientsResponse, error) {
+	patients, err := s.service.GetAllPatients(ctx)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	resp := &mdmpb.ListPatientsResponse{}
+	for i := range patients {
+		resp.Patients = append(resp.Patients, toPatientPb(&patients[i]))
+	}
+	return resp, nil
+}
+
+func (s *patientsServer) GetPatient(ctx context.Context, req *mdmpb.GetPatientRequest) (*mdmpb.GetPatientResponse, error) {
+	if req.GetPatientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID is required")
+	}
+	patient, err := s.service.GetPatient(ctx, req.GetPatientId())
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.GetPatientResponse{Patient: toPatientPb(patient)}, nil
+}
+
+func (s *patientsServer) CreatePatient(ctx context.Context, req *mdmpb.CreatePatientRequest) (*mdmpb.CreatePatientResponse, error) {
+	if req.GetPatient() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Patient is required")
+	}
+	patient := fromPatientPb(req.GetPatient())
+	if err := s.service.CreatePatient(ctx, &patient); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.CreatePatientResponse{Patient: toPatientPb(&patient)}, nil
+}
+
+func (s *patientsServer) UpdatePatient(ctx context.Context, req *mdmpb.UpdatePatientRequest) (*mdmpb.UpdatePatientResponse, error) {
+	if req.GetPatientId() == "" || req.GetPatient() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID and patient are required")
+	}
+	patient := fromPatientPb(req.GetPatient())
+	if err := s.service.UpdatePatient(ctx, req.GetPatientId(), &patient); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.UpdatePatientResponse{Patient: toPatientPb(&patient)}, nil
+}
+
+func (s *patientsServer) DeletePatient(ctx context.Context, req *mdmpb.DeletePatientRequest) (*mdmpb.DeletePatientResponse, error) {
+	if req.GetPatientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID is required")
+	}
+	if err := s.service.DeletePatient(ctx, req.GetPatientId()); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.DeletePatientResponse{}, nil
+}
+
+type medicalRecordsServer struct {
+	mdmpb.UnimplementedMedicalRecordsServiceServer
+	service *mdm.MedicalRecordsService
+}
+
+func (s *medicalRecordsServer) ListMedicalRecords(ctx context.Context, req *mdmpb.ListMedicalRecordsRequest) (*mdmpb.ListMedicalRecordsResponse, error) {
+	if req.GetPatientId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID is required")
+	}
+	records, err := s.service.GetPatientMedicalRecords(ctx, req.GetPatientId())
+	if err != nil {
+		return nil, translateError(err)
+	}
+	resp := &mdmpb.ListMedicalRecordsResponse{}
+	for i := range records {
+		resp.MedicalRecords = append(resp.MedicalRecords, toMedicalRecordPb(&records[i]))
+	}
+	return resp, nil
+}
+
+func (s *medicalRecordsServer) CreateMedicalRecord(ctx context.Context, req *mdmpb.CreateMedicalRecordRequest) (*mdmpb.CreateMedicalRecordResponse, error) {
+	if req.GetPatientId() == "" || req.GetMedicalRecord() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID and medical record are required")
+	}
+	record := fromMedicalRecordPb(req.GetMedicalRecord())
+	if err := s.service.CreateMedicalRecord(ctx, req.GetPatientId(), &record); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.CreateMedicalRecordResponse{MedicalRecord: toMedicalRecordPb(&record)}, nil
+}
+
+func (s *medicalRecordsServer) UpdateMedicalRecord(ctx context.Context, req *mdmpb.UpdateMedicalRecordRequest) (*mdmpb.UpdateMedicalRecordResponse, error) {
+	if req.GetPatientId() == "" || req.GetRecordId() == "" || req.GetMedicalRecord() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID, Record ID and medical record are required")
+	}
+	record := fromMedicalRecordPb(req.GetMedicalRecord())
+	if err := s.service.UpdateMedicalRecord(ctx, req.GetPatientId(), req.GetRecordId(), &record); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.UpdateMedicalRecordResponse{MedicalRecord: toMedicalRecordPb(&record)}, nil
+}
+
+func (s *medicalRecordsServer) DeleteMedicalRecord(ctx context.Context, req *mdmpb.DeleteMedicalRecordRequest) (*mdmpb.DeleteMedicalRecordResponse, error) {
+	if req.GetPatientId() == "" || req.GetRecordId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Patient ID and Record ID are required")
+	}
+	if err := s.service.DeleteMedicalRecord(ctx, req.GetPatientId(), req.GetRecordId()); err != nil {
+		return nil, translateError(err)
+	}
+	return &mdmpb.DeleteMedicalRecordResponse{}, nil
+}
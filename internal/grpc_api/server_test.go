@@ -0,0 +1,71 @@
+package grpc_api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	mdmpb "github.com/samsvi/mdm-webapi/pkg/mdmpb/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// patients returns the patient of the id or fails with the error
+type patients struct {
+	db_service.DbService[mdm.Patient]
+	patient *mdm.Patient
+	err     error
+}
+
+func (p patients) FindDocument(ctx context.Context, id string) (*mdm.Patient, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.patient, nil
+}
+
+func TestGetPatient(t *testing.T) {
+	cases := []struct {
+		name      string
+		patientId string
+		db        patients
+		code      codes.Code
+	}{
+		{"found", "pat1", patients{patient: &mdm.Patient{Id: "pat1", FirstName: "Ján"}}, codes.OK},
+		{"not found", "pat2", patients{err: db_service.ErrNotFound}, codes.NotFound},
+		{"database unavailable", "pat1", patients{err: &db_service.UnavailableError{RetryAfter: time.Second}}, codes.Unavailable},
+		{"missing id", "", patients{}, codes.InvalidArgument},
+	}
+	for _, c := range cases {
+		server := &patientsServer{service: mdm.NewPatientsService(c.db)}
+		response, err := server.GetPatient(context.Background(), &mdmpb.GetPatientRequest{PatientId: c.patientId})
+		if code := status.Code(err); code != c.code {
+			t.Errorf("%s: GetPatient returned %v, want %v", c.name, err, c.code)
+		}
+		if c.code == codes.OK && response.GetPatient().GetId() != c.patientId {
+			t.Errorf("%s: GetPatient returned %v", c.name, response)
+		}
+	}
+}
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{db_service.ErrNotFound, codes.NotFound},
+		{&mdm.ValidationError{Message: "Unknown ICD-10 code J45.99"}, codes.InvalidArgument},
+		{mdm.ErrIdMismatch, codes.PermissionDenied},
+		{&db_service.ConflictError{}, codes.AlreadyExists},
+		{&db_service.UnavailableError{}, codes.Unavailable},
+		{errors.New("connection reset"), codes.Unavailable},
+	}
+	for _, c := range cases {
+		if code := status.Code(translateError(c.err)); code != c.code {
+			t.Errorf("translateError(%v) = %v, want %v", c.err, code, c.code)
+		}
+	}
+}
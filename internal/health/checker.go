@@ -0,0 +1,165 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Statuses reported for the service and for its dependencies
+const (
+	StatusUp           = "UP"
+	StatusDown         = "DOWN"
+	StatusShuttingDown = "SHUTTING_DOWN"
+)
+
+// Check verifies one dependency of the service is available
+type Check func(ctx context.Context) error
+
+// Pinger is implemented by dependencies able to verify their connection
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Config struct {
+	// how long results of the checks are reused by subsequent probes
+	CacheTTL time.Duration
+	// deadline of a single check
+	Timeout time.Duration
+}
+
+// DependencyStatus is the result of the check of one dependency
+type DependencyStatus struct {
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	CheckedAt  time.Time `json:"checkedAt"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Report is the body of the readiness response
+type Report struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// Checker runs checks of the dependencies for the readiness probe. Results
+// are cached, so frequent probes of many replicas do not load the database,
+// and concurrent probes wait for a single run of the checks.
+type Checker struct {
+	Config
+	checks       map[string]Check
+	lock         sync.Mutex
+	checkedAt    time.Time
+	last         map[string]DependencyStatus
+	shuttingDown atomic.Bool
+}
+
+func NewChecker(config Config) *Checker {
+	if config.CacheTTL == 0 {
+		config.CacheTTL = 5 * time.Second
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 2 * time.Second
+	}
+	return &Checker{
+		Config: config,
+		checks: map[string]Check{},
+	}
+}
+
+// Add registers check of the named dependency, all checks have to be added
+// before the checker is used
+func (c *Checker) Add(name string, check Check) {
+	c.checks[name] = check
+}
+
+// Shutdown makes the service report not ready, so it stops receiving new
+// traffic while the requests in progress are drained
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready returns report of all the dependencies, running the checks only if
+// the cached results are older than CacheTTL
+func (c *Checker) Ready(ctx context.Context) Report {
+	if c.shuttingDown.Load() {
+		return Report{Status: StatusShuttingDown}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.last == nil || time.Since(c.checkedAt) > c.CacheTTL {
+		// probes sharing the results must not fail when the first one gives up
+		c.last = c.runChecks(context.WithoutCancel(ctx))
+		c.checkedAt = time.Now()
+	}
+
+	report := Report{Status: StatusUp, Dependencies: c.last}
+	for _, dependency := range c.last {
+		if dependency.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (c *Checker) runChecks(ctx context.Context) map[string]DependencyStatus {
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]DependencyStatus, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.Timeout)
+			defer cancel()
+
+			started := time.Now()
+			err := c.checks[name](checkCtx)
+			results[i] = DependencyStatus{
+				Status:     StatusUp,
+				CheckedAt:  started,
+				DurationMs: time.Since(started).Milliseconds(),
+			}
+			if err != nil {
+				results[i].Status = StatusDown
+				results[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	statuses := make(map[string]DependencyStatus, len(names))
+	for i, name := range names {
+		statuses[name] = results[i]
+	}
+	return statuses
+}
+
+// HandleLive responds to the liveness probe. The process is alive as long as
+// it serves requests, dependencies are not checked so that their outage does
+// not make the orchestrator restart all the replicas.
+func (c *Checker) HandleLive(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, Report{Status: StatusUp})
+}
+
+// HandleReady responds to the readiness probe with the status of dependencies,
+// 503 Service Unavailable when any of them is down or the service shuts down
+func (c *Checker) HandleReady(ctx *gin.Context) {
+	report := c.Ready(ctx.Request.Context())
+	if report.Status != StatusUp {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHandleReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	cases := []struct {
+		name         string
+		checks       map[string]Check
+		shuttingDown bool
+		status       int
+		report       string
+	}{
+		{"all up", map[string]Check{"mongodb": up, "nats": up}, false, http.StatusOK, StatusUp},
+		{"one down", map[string]Check{"mongodb": down, "nats": up}, false, http.StatusServiceUnavailable, StatusDown},
+		{"shutting down", map[string]Check{"mongodb": up}, true, http.StatusServiceUnavailable, StatusShuttingDown},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			checker := NewChecker(Config{})
+			for name, check := range c.checks {
+				checker.Add(name, check)
+			}
+			if c.shuttingDown {
+				checker.Shutdown()
+			}
+			router := gin.New()
+			router.GET("/health/ready", checker.HandleReady)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			if recorder.Code != c.status {
+				t.Fatalf("status %d, want %d", recorder.Code, c.status)
+			}
+			var report Report
+			if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+				t.Fatal(err)
+			}
+			if report.Status != c.report {
+				t.Fatalf("report %+v, want status %s", report, c.report)
+			}
+			if c.report == StatusDown && (report.Dependencies["mongodb"].Error != "connection refused" || report.Dependencies["nats"].Status != StatusUp) {
+				t.Fatalf("dependencies %+v, want the failed check reported", report.Dependencies)
+			}
+		})
+	}
+}
+
+func TestReadyCachesResults(t *testing.T) {
+	runs := 0
+	checker := NewChecker(Config{})
+	checker.Add("mongodb", func(ctx context.Context) error {
+		runs++
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		checker.Ready(context.Background())
+	}
+	if runs != 1 {
+		t.Fatalf("check ran %d times within the cache TTL, want once", runs)
+	}
+}
@@ -0,0 +1,75 @@
+// Package interactions checks medications for drug-drug interactions. The
+// interactions are defined between groups of the ATC classification, so that
+// a rule covers all substances of the group, e.g. all NSAIDs.
+package interactions
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+)
+
+// Severities of the interactions from the least serious
+const (
+	Minor           = "Minor"
+	Moderate        = "Moderate"
+	Major           = "Major"
+	Contraindicated = "Contraindicated"
+)
+
+var severityRank = map[string]int{Minor: 1, Moderate: 2, Major: 3, Contraindicated: 4}
+
+// Rule is an interaction of substances whose ATC codes start with the groups
+type Rule struct {
+	GroupA, GroupB string
+	Severity       string
+	Description    string
+}
+
+//go:embed rules.tsv
+var rulesData string
+
+var rules = mustParseRules(rulesData)
+
+// Interaction returns the most severe interaction of the substances given by
+// their ATC codes, the order of the substances does not matter
+func Interaction(atcA, atcB string) (Rule, bool) {
+	found := Rule{}
+	for _, rule := range rules {
+		matches := (strings.HasPrefix(atcA, rule.GroupA) && strings.HasPrefix(atcB, rule.GroupB)) ||
+			(strings.HasPrefix(atcA, rule.GroupB) && strings.HasPrefix(atcB, rule.GroupA))
+		if matches && Compare(rule.Severity, found.Severity) > 0 {
+			found = rule
+		}
+	}
+	return found, found.Severity != ""
+}
+
+// Compare returns positive number when the severity a is more serious than b,
+// negative when less serious and 0 when they are the same
+func Compare(a, b string) int {
+	return severityRank[a] - severityRank[b]
+}
+
+// IsSeverity reports whether the value is one of the severities
+func IsSeverity(value string) bool {
+	return severityRank[value] > 0
+}
+
+func mustParseRules(data string) []Rule {
+	var parsed []Rule
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) != 4 || !IsSeverity(fields[2]) {
+			panic(fmt.Sprintf("invalid interaction rule on line %d", line))
+		}
+		parsed = append(parsed, Rule{GroupA: fields[0], GroupB: fields[1], Severity: fields[2], Description: fields[3]})
+	}
+	return parsed
+}
@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"log/slog"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const HeaderRequestId = "X-Request-ID"
+
+// requestIdPattern limits request ids accepted from clients, so they cannot
+// inject arbitrary content into logs and responses
+var requestIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIdMiddleware reuses the X-Request-ID header of the request or
+// generates a new id, returns it in the response header and attaches it to
+// the request context for logging
+func RequestIdMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestId := ctx.GetHeader(HeaderRequestId)
+		if !requestIdPattern.MatchString(requestId) {
+			requestId = uuid.NewString()
+		}
+		ctx.Header(HeaderRequestId, requestId)
+		ctx.Request = ctx.Request.WithContext(WithRequestId(ctx.Request.Context(), requestId))
+		ctx.Next()
+	}
+}
+
+// AccessLogMiddleware logs every request once it is handled. Query strings
+// and bodies are never logged, only the path with resource identifiers.
+func AccessLogMiddleware(skipPaths ...string) gin.HandlerFunc {
+	skip := map[string]struct{}{}
+	for _, path := range skipPaths {
+		skip[path] = struct{}{}
+	}
+
+	return func(ctx *gin.Context) {
+		started := time.Now()
+		ctx.Next()
+
+		if _, ok := skip[ctx.Request.URL.Path]; ok {
+			return
+		}
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= 500:
+			level = slog.LevelError
+		case status >= 400:
+			level = slog.LevelWarn
+		}
+		slog.Default().LogAttrs(ctx.Request.Context(), level, "Request handled",
+			slog.String("method", ctx.Request.Method),
+			slog.String("route", ctx.FullPath()),
+			slog.String("path", ctx.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(started)),
+			slog.Int("size", ctx.Writer.Size()),
+			slog.String("client_ip", ctx.ClientIP()),
+		)
+	}
+}
+
+// Recovery logs panics of the handlers and responds with Internal Server Error
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(ctx *gin.Context, recovered any) {
+		slog.ErrorContext(ctx.Request.Context(), "Handler panicked",
+			slog.String("route", ctx.FullPath()),
+			slog.Any("panic", recovered),
+		)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces values which must not reach the logs
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are attribute keys, normalized by normalizeKey, whose values
+// are always redacted. They cover personal and medical data of the patients
+// as named in the API models and credentials.
+var sensitiveKeys = map[string]struct{}{
+	"firstname":          {},
+	"lastname":           {},
+	"name":               {},
+	"fullname":           {},
+	"dateofbirth":        {},
+	"insurancenumber":    {},
+	"allergies":          {},
+	"allergywarnings":    {},
+	"medicalnotes":       {},
+	"notes":              {},
+	"diagnosis":          {},
+	"primarydiagnosis":   {},
+	"secondarydiagnoses": {},
+	"symptoms":           {},
+	"treatment":          {},
+	"medications":        {},
+	"interactions":       {},
+	"reconciliations":    {},
+	"vitals":             {},
+	"doctorname":         {},
+	"address":            {},
+	"street":             {},
+	"phonenumber":        {},
+	"emergencycontact":   {},
+	"username":           {},
+	"password":           {},
+	"secret":             {},
+	"authorization":      {},
+}
+
+// insuranceNumberPattern matches Slovak birth numbers used as insurance numbers,
+// which may be quoted by errors, e.g. of a violated unique index
+var insuranceNumberPattern = regexp.MustCompile(`\b\d{6}/?\d{3,4}\b`)
+
+// RedactingHandler removes personal data from the records before passing them
+// to the wrapped handler:
+//
+//   - values of attributes with sensitive keys are replaced
+//   - structs, maps and pointers are replaced unless they implement
+//     slog.LogValuer selecting the attributes safe to log
+//   - insurance numbers are masked in the message and in string values
+type RedactingHandler struct {
+	handler slog.Handler
+}
+
+func NewRedactingHandler(handler slog.Handler) *RedactingHandler {
+	return &RedactingHandler{handler: handler}
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, RedactString(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(attr)
+	}
+	return &RedactingHandler{handler: h.handler.WithAttrs(redacted)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{handler: h.handler.WithGroup(name)}
+}
+
+// RedactString masks insurance numbers in the text
+func RedactString(text string) string {
+	return insuranceNumberPattern.ReplaceAllString(text, Redacted)
+}
+
+func redactAttr(attr slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[normalizeKey(attr.Key)]; ok {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, RedactString(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, member := range group {
+			redacted[i] = redactAttr(member)
+		}
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindAny:
+		return slog.Attr{Key: attr.Key, Value: redactAny(value.Any())}
+	default:
+		return slog.Attr{Key: attr.Key, Value: value}
+	}
+}
+
+func redactAny(value any) slog.Value {
+	switch typed := value.(type) {
+	case nil:
+		return slog.AnyValue(nil)
+	case error:
+		return slog.StringValue(RedactString(typed.Error()))
+	case fmt.Stringer:
+		return slog.StringValue(RedactString(typed.String()))
+	}
+
+	reflected := reflect.Indirect(reflect.ValueOf(value))
+	switch reflected.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return slog.StringValue(Redacted)
+	case reflect.Slice, reflect.Array:
+		switch reflected.Type().Elem().Kind() {
+		case reflect.Struct, reflect.Map, reflect.Interface, reflect.Pointer, reflect.Slice:
+			return slog.StringValue(Redacted)
+		}
+		return slog.StringValue(RedactString(fmt.Sprint(value)))
+	default:
+		return slog.AnyValue(value)
+	}
+}
+
+func normalizeKey(key string) string {
+	key = strings.ToLower(key)
+	return strings.NewReplacer("_", "", "-", "", ".", "").Replace(key)
+}
@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// personalData are values of the patient which must never reach the logs
+var personalData = []string{"Ján", "Novák", "1985-03-12", "850312/1234", "8503121234", "Penicilín", "Astma", "Ibuprofén", "MUDr. Kováč"}
+
+func newRedactingLogger() (*slog.Logger, *bytes.Buffer) {
+	var output bytes.Buffer
+	handler := slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(NewRedactingHandler(handler)), &output
+}
+
+func TestRedactingHandler(t *testing.T) {
+	patient := mdm.Patient{
+		Id: "pat1", FirstName: "Ján", LastName: "Novák", DateOfBirth: "1985-03-12",
+		InsuranceNumber: "850312/1234", Status: "Stable",
+		Allergies:    []mdm.Allergy{{Substance: "Penicilín"}},
+		MedicalNotes: "Astma",
+	}
+	record := mdm.MedicalRecord{
+		Id: "rec1", PatientId: "pat1", Diagnosis: "Astma", DoctorName: "MUDr. Kováč",
+		Medications: []mdm.Medication{{Name: "Ibuprofén"}},
+	}
+
+	cases := []struct {
+		name string
+		log  func(logger *slog.Logger)
+		safe []string
+	}{
+		{"patient", func(logger *slog.Logger) {
+			logger.Info("Patient created", slog.Any("patient", patient))
+		}, []string{"pat1", "Stable"}},
+		{"patient pointer", func(logger *slog.Logger) {
+			logger.Info("Patient updated", slog.Any("patient", &patient))
+		}, []string{"pat1"}},
+		{"medical record", func(logger *slog.Logger) {
+			logger.Info("Medical record created", slog.Any("record", record))
+		}, []string{"rec1", "pat1"}},
+		{"model without log value", func(logger *slog.Logger) {
+			logger.Info("Allergies changed", slog.Any("changed", patient.Allergies), slog.Any("medication", record.Medications[0]))
+		}, []string{Redacted}},
+		{"nested groups", func(logger *slog.Logger) {
+			logger.Info("Request rejected", slog.Group("request",
+				slog.String("route", "/api/patients"),
+				slog.Group("body",
+					slog.String("firstName", "Ján"),
+					slog.String("last_name", "Novák"),
+					slog.Group("address", slog.String("city", "Bratislava")),
+					slog.String("comment", "rodné číslo 8503121234"),
+				),
+			))
+		}, []string{"/api/patients"}},
+		{"handler groups", func(logger *slog.Logger) {
+			logger.WithGroup("patient").With(slog.String("dateOfBirth", "1985-03-12")).Info("Patient", slog.String("lastName", "Novák"))
+		}, []string{"patient"}},
+		{"raw insurance number", func(logger *slog.Logger) {
+			logger.Info("Duplicate patient", slog.String("insuranceNumber", "850312/1234"), slog.String("id", "pat1"))
+		}, []string{"pat1"}},
+		{"insurance number in message and error", func(logger *slog.Logger) {
+			logger.Error("Insert of 8503121234 failed", slog.Any("error", errors.New(`E11000 duplicate key { insurancenumber: "850312/1234" }`)))
+		}, []string{"E11000 duplicate key"}},
+	}
+	for _, c := range cases {
+		logger, output := newRedactingLogger()
+		c.log(logger)
+		logged := output.String()
+		for _, value := range personalData {
+			if strings.Contains(logged, value) {
+				t.Errorf("%s: logged %q reveals %q", c.name, logged, value)
+			}
+		}
+		for _, value := range c.safe {
+			if !strings.Contains(logged, value) {
+				t.Errorf("%s: logged %q is missing %q", c.name, logged, value)
+			}
+		}
+	}
+}
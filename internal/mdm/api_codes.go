@@ -0,0 +1,28 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type CodesAPI interface {
+
+
+    // SearchAtcCodes Get /api/codes/atc
+    // Searches ATC codes of medicinal substances 
+     SearchAtcCodes(c *gin.Context)
+
+    // SearchIcd10Codes Get /api/codes/icd10
+    // Searches ICD-10 diagnosis codes 
+     SearchIcd10Codes(c *gin.Context)
+
+}
@@ -0,0 +1,28 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type EarlyWarningAPI interface {
+
+
+    // GetEarlyWarningScores Get /api/early-warning
+    // Provides early warning scores of all patients of the ward 
+     GetEarlyWarningScores(c *gin.Context)
+
+    // GetPatientEarlyWarningScore Get /api/patients/:patientId/early-warning
+    // Provides early warning score of the patient 
+     GetPatientEarlyWarningScore(c *gin.Context)
+
+}
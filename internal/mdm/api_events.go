@@ -0,0 +1,28 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type EventsAPI interface {
+
+
+    // StreamEvents Get /api/events
+    // Streams patient and medical record events 
+     StreamEvents(c *gin.Context)
+
+    // StreamEventsWebSocket Get /api/events/ws
+    // Streams patient and medical record events over WebSocket 
+     StreamEventsWebSocket(c *gin.Context)
+
+}
\ No newline at end of file
@@ -0,0 +1,24 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type InteractionsAPI interface {
+
+
+    // CheckInteractions Post /api/interactions:check
+    // Checks medications for drug-drug interactions 
+     CheckInteractions(c *gin.Context)
+
+}
@@ -0,0 +1,28 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type MedicationsAPI interface {
+
+
+    // GetPatientMedications Get /api/patients/:patientId/medications
+    // Provides medications of the patient across all medical records 
+     GetPatientMedications(c *gin.Context)
+
+    // ReconcileMedications Post /api/patients/:patientId/medical-records/:recordId/reconciliation
+    // Reconciles medications of former visits at the visit 
+     ReconcileMedications(c *gin.Context)
+
+}
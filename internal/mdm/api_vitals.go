@@ -0,0 +1,32 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type VitalsAPI interface {
+
+
+    // GetPatientVitals Get /api/patients/:patientId/vitals
+    // Provides vital signs of the patient measured within the range of time 
+     GetPatientVitals(c *gin.Context)
+
+    // GetPatientVitalsSummary Get /api/patients/:patientId/vitals/summary
+    // Provides vital signs of the patient downsampled to intervals 
+     GetPatientVitalsSummary(c *gin.Context)
+
+    // RecordPatientVitals Post /api/patients/:patientId/vitals
+    // Records batch of vital signs of the patient 
+     RecordPatientVitals(c *gin.Context)
+
+}
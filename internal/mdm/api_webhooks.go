@@ -0,0 +1,44 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type WebhooksAPI interface {
+
+
+    // CreateWebhookSubscription Post /api/webhooks
+    // Creates a new webhook subscription 
+     CreateWebhookSubscription(c *gin.Context)
+
+    // DeleteWebhookSubscription Delete /api/webhooks/:subscriptionId
+    // Deletes specific webhook subscription 
+     DeleteWebhookSubscription(c *gin.Context)
+
+    // GetWebhookDeliveries Get /api/webhooks/:subscriptionId/deliveries
+    // Provides delivery log of specific webhook subscription 
+     GetWebhookDeliveries(c *gin.Context)
+
+    // GetWebhookSubscription Get /api/webhooks/:subscriptionId
+    // Provides details about specific webhook subscription 
+     GetWebhookSubscription(c *gin.Context)
+
+    // GetWebhookSubscriptions Get /api/webhooks
+    // Provides list of webhook subscriptions 
+     GetWebhookSubscriptions(c *gin.Context)
+
+    // UpdateWebhookSubscription Put /api/webhooks/:subscriptionId
+    // Updates specific webhook subscription 
+     UpdateWebhookSubscription(c *gin.Context)
+
+}
\ No newline at end of file
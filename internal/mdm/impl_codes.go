@@ -0,0 +1,72 @@
+package mdm
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/codes"
+)
+
+type implCodesAPI struct {
+}
+
+func NewCodesAPI() CodesAPI {
+	return &implCodesAPI{}
+}
+
+// SearchAtcCodes autocompletes medicinal substances by the beginning of the
+// code or by words of the name
+func (o implCodesAPI) SearchAtcCodes(c *gin.Context) {
+	matches, ok := searchCodes(c, codes.Atc)
+	if !ok {
+		return
+	}
+	substances := make([]AtcCode, 0, len(matches))
+	for _, match := range matches {
+		substances = append(substances, AtcCode{Code: match.Code, Display: match.Display})
+	}
+	c.JSON(http.StatusOK, substances)
+}
+
+// SearchIcd10Codes autocompletes diagnoses by the beginning of the code or by
+// words of the name
+func (o implCodesAPI) SearchIcd10Codes(c *gin.Context) {
+	matches, ok := searchCodes(c, codes.Icd10)
+	if !ok {
+		return
+	}
+	diagnoses := make([]CodedDiagnosis, 0, len(matches))
+	for _, match := range matches {
+		diagnoses = append(diagnoses, CodedDiagnosis{Code: match.Code, Display: match.Display})
+	}
+	c.JSON(http.StatusOK, diagnoses)
+}
+
+// searchCodes searches the code list by q and limit query parameters. On
+// invalid parameters it responds with Bad Request and returns false.
+func searchCodes(c *gin.Context, catalogue *codes.Catalogue) ([]codes.Code, bool) {
+	query := c.Query("q")
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Query parameter q is required",
+		})
+		return nil, false
+	}
+
+	limit := codes.DefaultSearchLimit
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > codes.MaxSearchLimit {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Limit must be an integer between 1 and " + strconv.Itoa(codes.MaxSearchLimit),
+			})
+			return nil, false
+		}
+		limit = parsed
+	}
+
+	return catalogue.Search(query, limit), true
+}
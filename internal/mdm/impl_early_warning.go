@@ -0,0 +1,90 @@
+package mdm
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+type implEarlyWarningAPI struct {
+}
+
+func NewEarlyWarningAPI() EarlyWarningAPI {
+	return &implEarlyWarningAPI{}
+}
+
+func (o implEarlyWarningAPI) GetEarlyWarningScores(c *gin.Context) {
+	minScore := 0
+	if value := c.Query("minScore"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Query parameter minScore must be a non-negative integer",
+			})
+			return
+		}
+		minScore = parsed
+	}
+
+	service, ok := vitalsService(c)
+	if !ok {
+		return
+	}
+
+	scores, err := service.GetEarlyWarningScores(c)
+	if err != nil {
+		respondDbError(c, "Failed to calculate early warning scores", err)
+		return
+	}
+
+	if minScore > 0 {
+		filtered := []EarlyWarningScore{}
+		for _, score := range scores {
+			if score.Score >= minScore {
+				filtered = append(filtered, score)
+			}
+		}
+		scores = filtered
+	}
+
+	scores, ok = paginate(c, scores)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, scores)
+}
+
+func (o implEarlyWarningAPI) GetPatientEarlyWarningScore(c *gin.Context) {
+	patientId := c.Param("patientId")
+	if patientId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Patient ID is required",
+		})
+		return
+	}
+
+	service, ok := vitalsService(c)
+	if !ok {
+		return
+	}
+
+	score, err := service.GetEarlyWarningScore(c, patientId)
+	if err != nil {
+		if err == db_service.ErrNotFound {
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Patient not found",
+			})
+			return
+		}
+		respondDbError(c, "Failed to calculate early warning score", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, score)
+}
@@ -0,0 +1,33 @@
+package mdm
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// respondDbError responds to failure of the database. While the database is
+// unavailable, e.g. during failover, the response is Service Unavailable with
+// Retry-After header, otherwise Bad Gateway.
+func respondDbError(c *gin.Context, message string, err error) {
+	var unavailable *db_service.UnavailableError
+	if errors.As(err, &unavailable) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(unavailable.RetryAfter.Seconds()))))
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  "Service Unavailable",
+			"message": message,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadGateway, gin.H{
+		"status":  "Bad Gateway",
+		"message": message,
+		"error":   err.Error(),
+	})
+}
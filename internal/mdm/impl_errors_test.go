@@ -0,0 +1,47 @@
+package mdm
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRespondDbError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	breaker := db_service.NewCircuitBreaker(db_service.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 30 * time.Second})
+	breaker.Record(mongo.CommandError{Code: 189, Name: "PrimarySteppedDown"})
+	openCircuit := breaker.Allow()
+
+	cases := []struct {
+		name       string
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"open circuit", openCircuit, http.StatusServiceUnavailable, "30"},
+		{"retries exhausted", &db_service.UnavailableError{RetryAfter: 1500 * time.Millisecond}, http.StatusServiceUnavailable, "2"},
+		{"wrapped unavailable", errors.Join(errors.New("find failed"), &db_service.UnavailableError{RetryAfter: time.Second}), http.StatusServiceUnavailable, "1"},
+		{"other failure", errors.New("unauthorized"), http.StatusBadGateway, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+
+			respondDbError(ctx, "Failed to retrieve patients", c.err)
+
+			if recorder.Code != c.status {
+				t.Fatalf("status %d, want %d", recorder.Code, c.status)
+			}
+			if retryAfter := recorder.Header().Get("Retry-After"); retryAfter != c.retryAfter {
+				t.Fatalf("Retry-After %q, want %q", retryAfter, c.retryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package mdm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/samsvi/mdm-webapi/internal/events"
+)
+
+const (
+	eventStreamKeepAlive = 15 * time.Second
+	eventStreamWriteWait = 10 * time.Second
+)
+
+var eventStreamUpgrader = websocket.Upgrader{
+	// the API is open to any origin, see CORS configuration
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type implEventsAPI struct {
+}
+
+func NewEventsAPI() EventsAPI {
+	return &implEventsAPI{}
+}
+
+func (o implEventsAPI) StreamEvents(c *gin.Context) {
+	filter, err := eventFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid event filter",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	value, exists := c.Get("event_broadcaster")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "event_broadcaster not found",
+		})
+		return
+	}
+
+	broadcaster, ok := value.(*events.Broadcaster)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "event_broadcaster context is not of correct type",
+		})
+		return
+	}
+
+	stream, unsubscribe := broadcaster.Subscribe(filter)
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// disable response buffering in nginx ingress
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func (o implEventsAPI) StreamEventsWebSocket(c *gin.Context) {
+	filter, err := eventFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid event filter",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	value, exists := c.Get("event_broadcaster")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "event_broadcaster not found",
+		})
+		return
+	}
+
+	broadcaster, ok := value.(*events.Broadcaster)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "event_broadcaster context is not of correct type",
+		})
+		return
+	}
+
+	// Upgrade responds with 400 on its own if the request is not an upgrade request
+	conn, err := eventStreamUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	stream, unsubscribe := broadcaster.Subscribe(filter)
+	defer unsubscribe()
+
+	// read until the client closes the connection, messages are ignored
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-stream:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down"),
+					time.Now().Add(eventStreamWriteWait))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(eventStreamWriteWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventStreamWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// eventFilter reads patientId and status query parameters, both may be
+// repeated or contain comma separated values
+func eventFilter(c *gin.Context) (events.Filter, error) {
+	filter := events.Filter{
+		PatientIds: splitQueryValues(c.QueryArray("patientId")),
+		Statuses:   splitQueryValues(c.QueryArray("status")),
+	}
+
+	for _, status := range filter.Statuses {
+		if !slices.Contains(PatientStatuses, status) {
+			return filter, fmt.Errorf("unknown patient status: %v", status)
+		}
+	}
+	return filter, nil
+}
+
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package mdm
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+type implInteractionsAPI struct {
+}
+
+func NewInteractionsAPI() InteractionsAPI {
+	return &implInteractionsAPI{}
+}
+
+func (o implInteractionsAPI) CheckInteractions(c *gin.Context) {
+	var request InteractionCheckRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+		return
+	}
+	if len(request.Medications) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "At least one medication is required",
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[MedicalRecord])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	if request.PatientId != "" {
+		if _, err := patientsDb.FindDocument(c, request.PatientId); err != nil {
+			if errors.Is(err, db_service.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{
+					"status":  "Not Found",
+					"message": "Patient not found",
+				})
+				return
+			}
+			respondDbError(c, "Failed to load patient", err)
+			return
+		}
+	}
+
+	found, err := NewMedicalRecordsService(db, patientsDb).CheckInteractions(c, request.PatientId, "", request.Medications)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+			return
+		}
+		respondDbError(c, "Failed to load active medications", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, InteractionCheckResult{Medications: request.Medications, Interactions: found})
+}
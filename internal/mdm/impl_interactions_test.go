@@ -0,0 +1,39 @@
+package mdm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCheckInteractionsRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(ctx *gin.Context) {
+		ctx.Set("db_service", newTransactionalRecords(t))
+		ctx.Set("patients_db_service", staticPatients{})
+		ctx.Next()
+	})
+	router.POST("/api/interactions\\:check", NewInteractionsAPI().CheckInteractions)
+
+	body := `{"medications": [{"name": "Ibuprofén"}, {"name": "warfarín"}]}`
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{"/api/interactions:check", http.StatusOK},
+		{"/api/interactionsX", http.StatusNotFound},
+		{"/api/interactions:other", http.StatusNotFound},
+		{"/api/interactions", http.StatusNotFound},
+	}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, c.path, strings.NewReader(body)))
+		if recorder.Code != c.status {
+			t.Errorf("POST %s returned %d, want %d", c.path, recorder.Code, c.status)
+		}
+	}
+}
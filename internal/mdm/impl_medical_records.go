@@ -1,13 +1,11 @@
 package mdm
 
 import (
+	"errors"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/samsvi/mdm-webapi/internal/db_service"
-	"go.mongodb.org/mongo-driver/bson"
 )
 
 type implMedicalRecordsAPI struct {
@@ -18,7 +16,7 @@ func NewMedicalRecordsAPI() MedicalRecordsAPI {
 }
 
 func (o implMedicalRecordsAPI) CreateMedicalRecord(c *gin.Context) {
-	
+
 	patientId := c.Param("patientId")
 	if patientId == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -38,24 +36,6 @@ func (o implMedicalRecordsAPI) CreateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	if record.Diagnosis == "" || record.DateOfVisit.IsZero() {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "Bad Request",
-			"message": "Missing required fields (diagnosis, dateOfVisit)",
-		})
-		return
-	}
-
-	if record.Id == "" || record.Id == "@new" {
-		record.Id = uuid.NewString()
-	}
-
-	record.PatientId = patientId
-
-	now := time.Now()
-	record.CreatedAt = now
-	record.UpdatedAt = now
-
 	value, exists := c.Get("db_service")
 	if !exists {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -74,19 +54,34 @@ func (o implMedicalRecordsAPI) CreateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	if err := db.CreateDocument(c, record.Id, &record); err != nil {
-		switch err {
-		case db_service.ErrConflict:
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	if err := NewMedicalRecordsService(db, patientsDb).CreateMedicalRecord(c, patientId, &record); err != nil {
+		var validationErr *ValidationError
+		var allergyErr *AllergyConflictError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+		case errors.As(err, &allergyErr):
+			respondAllergyConflict(c, allergyErr)
+		case err == db_service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Patient not found",
+			})
+		case errors.Is(err, db_service.ErrConflict):
 			c.JSON(http.StatusConflict, gin.H{
 				"status":  "Conflict",
 				"message": "Medical record already exists",
 			})
 		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to create medical record",
-				"error":   err.Error(),
-			})
+			respondDbError(c, "Failed to create medical record", err)
 		}
 		return
 	}
@@ -123,16 +118,19 @@ func (o implMedicalRecordsAPI) GetPatientMedicalRecords(c *gin.Context) {
 		return
 	}
 
-	// Use bson.M filter instead of function
-	filter := bson.M{"patientid": patientId}
-	records, err := db.FindDocumentsByCondition(c, filter)
-	
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	records, err := NewMedicalRecordsService(db, patientsDb).GetPatientMedicalRecords(c, patientId)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"status":  "Bad Gateway",
-			"message": "Failed to retrieve medical records",
-			"error":   err.Error(),
-		})
+		respondDbError(c, "Failed to retrieve medical records", err)
+		return
+	}
+
+	records, ok = paginate(c, records)
+	if !ok {
 		return
 	}
 
@@ -161,18 +159,6 @@ func (o implMedicalRecordsAPI) UpdateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	if updatedRecord.Id != "" && updatedRecord.Id != recordId {
-		c.JSON(http.StatusForbidden, gin.H{
-			"status":  "Forbidden",
-			"message": "Record ID in path and request body do not match",
-		})
-		return
-	}
-
-	updatedRecord.Id = recordId
-	updatedRecord.PatientId = patientId
-	updatedRecord.UpdatedAt = time.Now()
-
 	value, exists := c.Get("db_service")
 	if !exists {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -191,19 +177,38 @@ func (o implMedicalRecordsAPI) UpdateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	if err := db.UpdateDocument(c, recordId, &updatedRecord); err != nil {
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	if err := NewMedicalRecordsService(db, patientsDb).UpdateMedicalRecord(c, patientId, recordId, &updatedRecord); err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+			return
+		}
+		var allergyErr *AllergyConflictError
+		if errors.As(err, &allergyErr) {
+			respondAllergyConflict(c, allergyErr)
+			return
+		}
 		switch err {
+		case ErrIdMismatch:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status":  "Forbidden",
+				"message": "Record ID in path and request body do not match",
+			})
 		case db_service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
 				"status":  "Not Found",
 				"message": "Patient or Medical record not found",
 			})
 		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to update medical record",
-				"error":   err.Error(),
-			})
+			respondDbError(c, "Failed to update medical record", err)
 		}
 		return
 	}
@@ -241,7 +246,12 @@ func (o implMedicalRecordsAPI) DeleteMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	if err := db.DeleteDocument(c, recordId); err != nil {
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	if err := NewMedicalRecordsService(db, patientsDb).DeleteMedicalRecord(c, patientId, recordId); err != nil {
 		switch err {
 		case db_service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
@@ -249,14 +259,43 @@ func (o implMedicalRecordsAPI) DeleteMedicalRecord(c *gin.Context) {
 				"message": "Patient or Medical record not found",
 			})
 		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to delete medical record",
-				"error":   err.Error(),
-			})
+			respondDbError(c, "Failed to delete medical record", err)
 		}
 		return
 	}
 
 	c.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// patientsDbService returns database of the patients the medical records are
+// checked against, on failure it responds with Internal Server Error
+func patientsDbService(c *gin.Context) (db_service.DbService[Patient], bool) {
+	value, exists := c.Get("patients_db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "patients_db_service not found",
+		})
+		return nil, false
+	}
+
+	db, ok := value.(db_service.DbService[Patient])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "patients_db_service context is not of correct type",
+		})
+		return nil, false
+	}
+	return db, true
+}
+
+// respondAllergyConflict responds to medications conflicting with allergies
+// of the patient, the request may be repeated with allergyOverride set
+func respondAllergyConflict(c *gin.Context, err *AllergyConflictError) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"status":    "Unprocessable Entity",
+		"message":   "Medications conflict with allergies of the patient, set allergyOverride to prescribe them anyway",
+		"conflicts": err.Warnings,
+	})
+}
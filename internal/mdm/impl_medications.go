@@ -0,0 +1,176 @@
+package mdm
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+type implMedicationsAPI struct {
+}
+
+func NewMedicationsAPI() MedicationsAPI {
+	return &implMedicationsAPI{}
+}
+
+func (o implMedicationsAPI) GetPatientMedications(c *gin.Context) {
+	patientId := c.Param("patientId")
+	if patientId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Patient ID is required",
+		})
+		return
+	}
+
+	var active *bool
+	if value := c.Query("active"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Query parameter active must be true or false",
+			})
+			return
+		}
+		active = &parsed
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[MedicalRecord])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	medications, err := NewMedicalRecordsService(db, patientsDb).GetPatientMedications(c, patientId)
+	if err != nil {
+		respondDbError(c, "Failed to retrieve medications", err)
+		return
+	}
+
+	if active != nil {
+		filtered := []PatientMedication{}
+		for _, medication := range medications {
+			if medication.Active == *active {
+				filtered = append(filtered, medication)
+			}
+		}
+		medications = filtered
+	}
+
+	medications, ok = paginate(c, medications)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, medications)
+}
+
+func (o implMedicationsAPI) ReconcileMedications(c *gin.Context) {
+	patientId := c.Param("patientId")
+	recordId := c.Param("recordId")
+
+	if patientId == "" || recordId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Patient ID and Record ID are required",
+		})
+		return
+	}
+
+	var reconciliations []MedicationReconciliation
+	if err := c.ShouldBindJSON(&reconciliations); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[MedicalRecord])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return
+	}
+
+	value, exists = c.Get("transaction_runner")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "transaction_runner not found",
+		})
+		return
+	}
+
+	transactions, ok := value.(db_service.TransactionRunner)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "transaction_runner context is not of correct type",
+		})
+		return
+	}
+
+	record, err := NewMedicalRecordsService(db, patientsDb).ReconcileMedications(c, transactions, patientId, recordId, reconciliations)
+	if err != nil {
+		var validationErr *ValidationError
+		var allergyErr *AllergyConflictError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+		case errors.As(err, &allergyErr):
+			respondAllergyConflict(c, allergyErr)
+		case err == db_service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Patient or Medical record not found",
+			})
+		default:
+			respondDbError(c, "Failed to reconcile medications", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
@@ -0,0 +1,34 @@
+package mdm
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+)
+
+// Status values for which a dedicated status change event is emitted
+var notifiedStatuses = map[string]bool{
+	"Critical":   true,
+	"Discharged": true,
+}
+
+// withEvent attaches the event to the context as an outbox message. The
+// db_service stores it in the same transaction as the document change and
+// the relay publishes it afterwards.
+func withEvent(ctx context.Context, eventType string, patientId string, recordId string, data interface{}) context.Context {
+	event, err := events.NewEvent(eventType, patientId, recordId, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create event", "event_type", eventType, "error", err)
+		return ctx
+	}
+
+	message, err := event.OutboxMessage()
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to create outbox message", "event_type", eventType, "error", err)
+		return ctx
+	}
+
+	return db_service.WithOutboxMessages(ctx, message)
+}
@@ -0,0 +1,49 @@
+package mdm
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paginate returns the page of items selected by limit and offset query
+// parameters and sets X-Total-Count header. On invalid parameters it responds
+// with Bad Request and returns false.
+func paginate[T any](c *gin.Context, items []T) ([]T, bool) {
+	offset := 0
+	if value := c.Query("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Offset must be a non-negative integer",
+			})
+			return nil, false
+		}
+		offset = parsed
+	}
+
+	limit := len(items)
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Limit must be a positive integer",
+			})
+			return nil, false
+		}
+		limit = parsed
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(len(items)))
+	if offset >= len(items) {
+		return []T{}, true
+	}
+	end := len(items)
+	if limit < end-offset {
+		end = offset + limit
+	}
+	return items[offset:end], true
+}
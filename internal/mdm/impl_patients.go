@@ -1,17 +1,24 @@
 package mdm
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/samsvi/mdm-webapi/internal/db_service"
 )
 
 type implPatientsAPI struct {
 }
 
+// patientResponse is the patient with the early warning score of its latest
+// observations, the score is not stored with the patient
+type patientResponse struct {
+	Patient
+	EarlyWarning *PatientEarlyWarning `json:"earlyWarning,omitempty"`
+}
+
 func NewPatientsAPI() PatientsAPI {
 	return &implPatientsAPI{}
 }
@@ -21,31 +28,13 @@ func (o implPatientsAPI) CreatePatient(c *gin.Context) {
 
 	if err := c.ShouldBindJSON(&patient); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "Bad Request",  
+			"status":  "Bad Request",
 			"message": "Invalid request body",
 			"error":   err.Error(),
 		})
 		return
 	}
 
-	if patient.FirstName == "" || patient.LastName == "" || 
-	   patient.DateOfBirth == "" || patient.Gender == "" || 
-	   patient.InsuranceNumber == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status":  "Bad Request",
-			"message": "Missing required fields",
-		})
-		return
-	}
-
-	if patient.Id == "" || patient.Id == "@new" {
-		patient.Id = uuid.NewString()
-	}
-
-	now := time.Now()
-	patient.CreatedAt = now
-	patient.UpdatedAt = now
-
 	value, exists := c.Get("db_service")
 	if !exists {
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -58,25 +47,27 @@ func (o implPatientsAPI) CreatePatient(c *gin.Context) {
 	db, ok := value.(db_service.DbService[Patient])
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "Internal Server Error", 
+			"status":  "Internal Server Error",
 			"message": "db_service context is not of correct type",
 		})
 		return
 	}
 
-	if err := db.CreateDocument(c, patient.Id, &patient); err != nil {
-		switch err {
-		case db_service.ErrConflict:
+	if err := NewPatientsService(db).CreatePatient(c, &patient); err != nil {
+		var validationErr *ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+		case errors.Is(err, db_service.ErrConflict):
 			c.JSON(http.StatusConflict, gin.H{
 				"status":  "Conflict",
-				"message": "Patient already exists",
+				"message": ConflictMessage(err, "Patient already exists"),
 			})
 		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to create patient",
-				"error":   err.Error(),
-			})
+			respondDbError(c, "Failed to create patient", err)
 		}
 		return
 	}
@@ -103,13 +94,14 @@ func (o implPatientsAPI) GetAllPatients(c *gin.Context) {
 		return
 	}
 
-	patients, err := db.FindAllDocuments(c)
+	patients, err := NewPatientsService(db).GetAllPatients(c)
 	if err != nil {
-		c.JSON(http.StatusBadGateway, gin.H{
-			"status":  "Bad Gateway",
-			"message": "Failed to retrieve patients",
-			"error":   err.Error(),
-		})
+		respondDbError(c, "Failed to retrieve patients", err)
+		return
+	}
+
+	patients, ok = paginate(c, patients)
+	if !ok {
 		return
 	}
 
@@ -118,7 +110,7 @@ func (o implPatientsAPI) GetAllPatients(c *gin.Context) {
 
 func (o implPatientsAPI) GetPatient(c *gin.Context) {
 	patientId := c.Param("patientId")
-	
+
 	if patientId == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "Bad Request",
@@ -145,27 +137,23 @@ func (o implPatientsAPI) GetPatient(c *gin.Context) {
 		return
 	}
 
-	patient, err := db.FindDocument(c, patientId)
+	patient, err := NewPatientsService(db).GetPatient(c, patientId)
 	switch err {
 	case nil:
-		c.JSON(http.StatusOK, *patient)
+		c.JSON(http.StatusOK, patientResponse{Patient: *patient, EarlyWarning: patientEarlyWarning(c, db, *patient)})
 	case db_service.ErrNotFound:
 		c.JSON(http.StatusNotFound, gin.H{
-			"status":  "Not Found", 
+			"status":  "Not Found",
 			"message": "Patient not found",
 		})
 	default:
-		c.JSON(http.StatusBadGateway, gin.H{
-			"status":  "Bad Gateway",
-			"message": "Failed to find patient",
-			"error":   err.Error(),
-		})
+		respondDbError(c, "Failed to find patient", err)
 	}
 }
 
 func (o implPatientsAPI) UpdatePatient(c *gin.Context) {
 	patientId := c.Param("patientId")
-	
+
 	if patientId == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "Bad Request",
@@ -184,22 +172,11 @@ func (o implPatientsAPI) UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	if updatedPatient.Id != "" && updatedPatient.Id != patientId {
-		c.JSON(http.StatusForbidden, gin.H{
-			"status":  "Forbidden",
-			"message": "Patient ID in path and request body do not match",
-		})
-		return
-	}
-
-	updatedPatient.Id = patientId
-	updatedPatient.UpdatedAt = time.Now()
-
 	value, exists := c.Get("db_service")
 	if !exists {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "Internal Server Error",
-			"message": "db_service not found", 
+			"message": "db_service not found",
 		})
 		return
 	}
@@ -213,19 +190,31 @@ func (o implPatientsAPI) UpdatePatient(c *gin.Context) {
 		return
 	}
 
-	if err := db.UpdateDocument(c, patientId, &updatedPatient); err != nil {
-		switch err {
-		case db_service.ErrNotFound:
+	if err := NewPatientsService(db).UpdatePatient(c, patientId, &updatedPatient); err != nil {
+		var validationErr *ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+		case err == ErrIdMismatch:
+			c.JSON(http.StatusForbidden, gin.H{
+				"status":  "Forbidden",
+				"message": "Patient ID in path and request body do not match",
+			})
+		case err == db_service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
 				"status":  "Not Found",
 				"message": "Patient not found",
 			})
-		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway", 
-				"message": "Failed to update patient",
-				"error":   err.Error(),
+		case errors.Is(err, db_service.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  "Conflict",
+				"message": ConflictMessage(err, "Patient already exists"),
 			})
+		default:
+			respondDbError(c, "Failed to update patient", err)
 		}
 		return
 	}
@@ -235,11 +224,11 @@ func (o implPatientsAPI) UpdatePatient(c *gin.Context) {
 
 func (o implPatientsAPI) DeletePatient(c *gin.Context) {
 	patientId := c.Param("patientId")
-	
+
 	if patientId == "" {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "Bad Request",
-			"message": "Patient ID is required",  
+			"message": "Patient ID is required",
 		})
 		return
 	}
@@ -262,7 +251,7 @@ func (o implPatientsAPI) DeletePatient(c *gin.Context) {
 		return
 	}
 
-	if err := db.DeleteDocument(c, patientId); err != nil {
+	if err := NewPatientsService(db).DeletePatient(c, patientId); err != nil {
 		switch err {
 		case db_service.ErrNotFound:
 			c.JSON(http.StatusNotFound, gin.H{
@@ -270,14 +259,30 @@ func (o implPatientsAPI) DeletePatient(c *gin.Context) {
 				"message": "Patient not found",
 			})
 		default:
-			c.JSON(http.StatusBadGateway, gin.H{
-				"status":  "Bad Gateway",
-				"message": "Failed to delete patient", 
-				"error":   err.Error(),
-			})
+			respondDbError(c, "Failed to delete patient", err)
 		}
 		return
 	}
 
 	c.Status(http.StatusNoContent)
-}
\ No newline at end of file
+}
+
+// patientEarlyWarning scores the latest observations of the patient. The
+// patient is returned without the score when the vital signs are not
+// available or fail to load.
+func patientEarlyWarning(c *gin.Context, patients db_service.DbService[Patient], patient Patient) *PatientEarlyWarning {
+	value, exists := c.Get("vitals_db_service")
+	if !exists {
+		return nil
+	}
+	vitalsDb, ok := value.(db_service.TimeSeriesService[VitalSign])
+	if !ok {
+		return nil
+	}
+
+	earlyWarning, err := NewVitalsService(vitalsDb, patients, earlyWarningConfig(c)).GetPatientEarlyWarning(c, patient)
+	if err != nil {
+		slog.WarnContext(c, "Failed to score the latest observations of the patient", "patient_id", patient.Id, "error", err)
+	}
+	return earlyWarning
+}
@@ -0,0 +1,60 @@
+package mdm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGetPatientWithEarlyWarning(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	vitals := news2Vitals(map[string]float64{VitalConsciousness: ConsciousnessPain}, time.Now())
+
+	cases := []struct {
+		name         string
+		vitals       interface{}
+		earlyWarning *PatientEarlyWarning
+	}{
+		{"with vital signs", latestVitals{vitals: vitals}, &PatientEarlyWarning{Score: 3, Risk: RiskLowMedium, Complete: true}},
+		{"without vital signs", latestVitals{}, nil},
+		{"without vitals service", nil, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set("db_service", staticPatients{})
+				if c.vitals != nil {
+					ctx.Set("vitals_db_service", c.vitals)
+				}
+				ctx.Next()
+			})
+			router.GET("/api/patients/:patientId", NewPatientsAPI().GetPatient)
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/patients/pat1", nil))
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status %d, want %d", recorder.Code, http.StatusOK)
+			}
+
+			var patient patientResponse
+			if err := json.Unmarshal(recorder.Body.Bytes(), &patient); err != nil {
+				t.Fatal(err)
+			}
+			if patient.Id != "pat1" {
+				t.Fatalf("patient %+v", patient.Patient)
+			}
+			got := patient.EarlyWarning
+			if (got == nil) != (c.earlyWarning == nil) {
+				t.Fatalf("early warning %+v, want %+v", got, c.earlyWarning)
+			}
+			if got != nil && (got.Score != c.earlyWarning.Score || got.Risk != c.earlyWarning.Risk || got.Complete != c.earlyWarning.Complete || got.CalculatedAt.IsZero()) {
+				t.Fatalf("early warning %+v, want %+v", got, c.earlyWarning)
+			}
+		})
+	}
+}
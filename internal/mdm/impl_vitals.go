@@ -0,0 +1,219 @@
+package mdm
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// vital signs of the last week are listed unless the range is given
+const defaultVitalsRange = 7 * 24 * time.Hour
+
+// charts are downsampled to this number of intervals unless given
+const defaultVitalsPoints = 200
+
+type implVitalsAPI struct {
+}
+
+func NewVitalsAPI() VitalsAPI {
+	return &implVitalsAPI{}
+}
+
+func (o implVitalsAPI) RecordPatientVitals(c *gin.Context) {
+	patientId := c.Param("patientId")
+	if patientId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Patient ID is required",
+		})
+		return
+	}
+
+	var vitals []VitalSign
+	if err := c.ShouldBindJSON(&vitals); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	service, ok := vitalsService(c)
+	if !ok {
+		return
+	}
+
+	if err := service.RecordVitals(c, patientId, vitals); err != nil {
+		var validationErr *ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": validationErr.Message,
+			})
+		case err == db_service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Patient not found",
+			})
+		default:
+			respondDbError(c, "Failed to record vital signs", err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, vitals)
+}
+
+func (o implVitalsAPI) GetPatientVitals(c *gin.Context) {
+	patientId := c.Param("patientId")
+	from, to, ok := vitalsRange(c)
+	if !ok {
+		return
+	}
+
+	service, ok := vitalsService(c)
+	if !ok {
+		return
+	}
+
+	vitals, err := service.GetVitals(c, patientId, c.QueryArray("type"), from, to)
+	if err != nil {
+		respondVitalsError(c, err)
+		return
+	}
+
+	vitals, ok = paginate(c, vitals)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, vitals)
+}
+
+func (o implVitalsAPI) GetPatientVitalsSummary(c *gin.Context) {
+	patientId := c.Param("patientId")
+	from, to, ok := vitalsRange(c)
+	if !ok {
+		return
+	}
+
+	interval := VitalsInterval(from, to, defaultVitalsPoints)
+	if value := c.Query("interval"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Interval must be a duration, e.g. 15m or 1h",
+			})
+			return
+		}
+		interval = parsed
+	} else if value := c.Query("points"); value != "" {
+		points, err := strconv.Atoi(value)
+		if err != nil || points <= 0 || points > MaxVitalsPoints {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Points must be an integer between 1 and " + strconv.Itoa(MaxVitalsPoints),
+			})
+			return
+		}
+		interval = VitalsInterval(from, to, points)
+	}
+
+	service, ok := vitalsService(c)
+	if !ok {
+		return
+	}
+
+	summaries, err := service.SummarizeVitals(c, patientId, c.QueryArray("type"), from, to, interval)
+	if err != nil {
+		respondVitalsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summaries)
+}
+
+// vitalsRange parses the from and to query parameters, the range ends now and
+// spans the last week unless given
+func vitalsRange(c *gin.Context) (time.Time, time.Time, bool) {
+	to := time.Now()
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Query parameter to must be RFC 3339 date-time",
+			})
+			return time.Time{}, time.Time{}, false
+		}
+		to = parsed
+	}
+	from := to.Add(-defaultVitalsRange)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status":  "Bad Request",
+				"message": "Query parameter from must be RFC 3339 date-time",
+			})
+			return time.Time{}, time.Time{}, false
+		}
+		from = parsed
+	}
+	return from, to, true
+}
+
+func respondVitalsError(c *gin.Context, err error) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": validationErr.Message,
+		})
+		return
+	}
+	respondDbError(c, "Failed to retrieve vital signs", err)
+}
+
+func vitalsService(c *gin.Context) (*VitalsService, bool) {
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return nil, false
+	}
+
+	db, ok := value.(db_service.TimeSeriesService[VitalSign])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return nil, false
+	}
+
+	patientsDb, ok := patientsDbService(c)
+	if !ok {
+		return nil, false
+	}
+
+	return NewVitalsService(db, patientsDb, earlyWarningConfig(c)), true
+}
+
+func earlyWarningConfig(c *gin.Context) EarlyWarningConfig {
+	if value, exists := c.Get("early_warning_config"); exists {
+		if config, ok := value.(EarlyWarningConfig); ok {
+			return config
+		}
+	}
+	return DefaultEarlyWarningConfig
+}
@@ -0,0 +1,415 @@
+package mdm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"github.com/samsvi/mdm-webapi/internal/netguard"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// WebhookSubscriptionIndexes are indexes of the webhook subscriptions
+// collection, the dispatcher looks up active subscriptions of event type
+var WebhookSubscriptionIndexes = []db_service.Index{
+	{Name: "id", Keys: []string{"id"}, Unique: true},
+	{Name: "active_eventtypes", Keys: []string{"active", "eventtypes"}},
+}
+
+// WebhookDeliveryIndexes are indexes of the webhook deliveries collection,
+// the dispatcher polls due pending deliveries
+var WebhookDeliveryIndexes = []db_service.Index{
+	{Name: "id", Keys: []string{"id"}, Unique: true},
+	{Name: "status_nextattemptat", Keys: []string{"status", "nextattemptat"}},
+	{Name: "subscriptionid", Keys: []string{"subscriptionid"}},
+}
+
+type implWebhooksAPI struct {
+}
+
+func NewWebhooksAPI() WebhooksAPI {
+	return &implWebhooksAPI{}
+}
+
+func (o implWebhooksAPI) CreateWebhookSubscription(c *gin.Context) {
+	var subscription WebhookSubscription
+
+	if err := c.ShouldBindJSON(&subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if err := validateWebhookSubscription(c, &subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid webhook subscription",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if subscription.Id == "" || subscription.Id == "@new" {
+		subscription.Id = uuid.NewString()
+	}
+
+	if subscription.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "Internal Server Error",
+				"message": "Failed to generate webhook secret",
+				"error":   err.Error(),
+			})
+			return
+		}
+		subscription.Secret = secret
+	}
+
+	subscription.Active = true
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookSubscription])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	if err := db.CreateDocument(c, subscription.Id, &subscription); err != nil {
+		if errors.Is(err, db_service.ErrConflict) {
+			c.JSON(http.StatusConflict, gin.H{
+				"status":  "Conflict",
+				"message": "Webhook subscription already exists",
+			})
+		} else {
+			respondDbError(c, "Failed to create webhook subscription", err)
+		}
+		return
+	}
+
+	// the secret is returned only once, on creation
+	c.JSON(http.StatusCreated, subscription)
+}
+
+func (o implWebhooksAPI) GetWebhookSubscriptions(c *gin.Context) {
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookSubscription])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	subscriptions, err := db.FindAllDocuments(c)
+	if err != nil {
+		respondDbError(c, "Failed to retrieve webhook subscriptions", err)
+		return
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+func (o implWebhooksAPI) GetWebhookSubscription(c *gin.Context) {
+	subscriptionId := c.Param("subscriptionId")
+
+	if subscriptionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Subscription ID is required",
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookSubscription])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	subscription, err := db.FindDocument(c, subscriptionId)
+	switch err {
+	case nil:
+		subscription.Secret = ""
+		c.JSON(http.StatusOK, *subscription)
+	case db_service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "Not Found",
+			"message": "Webhook subscription not found",
+		})
+	default:
+		respondDbError(c, "Failed to find webhook subscription", err)
+	}
+}
+
+func (o implWebhooksAPI) UpdateWebhookSubscription(c *gin.Context) {
+	subscriptionId := c.Param("subscriptionId")
+
+	if subscriptionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Subscription ID is required",
+		})
+		return
+	}
+
+	var updatedSubscription WebhookSubscription
+	// the state of the subscription is kept when the update omits it
+	var state struct {
+		Active *bool `json:"active"`
+	}
+	err := c.ShouldBindBodyWithJSON(&updatedSubscription)
+	if err == nil {
+		err = c.ShouldBindBodyWithJSON(&state)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid request body",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if updatedSubscription.Id != "" && updatedSubscription.Id != subscriptionId {
+		c.JSON(http.StatusForbidden, gin.H{
+			"status":  "Forbidden",
+			"message": "Subscription ID in path and request body do not match",
+		})
+		return
+	}
+
+	if err := validateWebhookSubscription(c, &updatedSubscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Invalid webhook subscription",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookSubscription])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	subscription, err := db.FindDocument(c, subscriptionId)
+	switch err {
+	case nil:
+	case db_service.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "Not Found",
+			"message": "Webhook subscription not found",
+		})
+		return
+	default:
+		respondDbError(c, "Failed to find webhook subscription", err)
+		return
+	}
+
+	updatedSubscription.Id = subscriptionId
+	if updatedSubscription.Secret == "" {
+		updatedSubscription.Secret = subscription.Secret
+	}
+	if state.Active == nil {
+		updatedSubscription.Active = subscription.Active
+	}
+	updatedSubscription.CreatedAt = subscription.CreatedAt
+	updatedSubscription.UpdatedAt = time.Now()
+
+	if err := db.UpdateDocument(c, subscriptionId, &updatedSubscription); err != nil {
+		switch err {
+		case db_service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Webhook subscription not found",
+			})
+		default:
+			respondDbError(c, "Failed to update webhook subscription", err)
+		}
+		return
+	}
+
+	updatedSubscription.Secret = ""
+	c.JSON(http.StatusOK, updatedSubscription)
+}
+
+func (o implWebhooksAPI) DeleteWebhookSubscription(c *gin.Context) {
+	subscriptionId := c.Param("subscriptionId")
+
+	if subscriptionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Subscription ID is required",
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookSubscription])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	if err := db.DeleteDocument(c, subscriptionId); err != nil {
+		switch err {
+		case db_service.ErrNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"status":  "Not Found",
+				"message": "Webhook subscription not found",
+			})
+		default:
+			respondDbError(c, "Failed to delete webhook subscription", err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (o implWebhooksAPI) GetWebhookDeliveries(c *gin.Context) {
+	subscriptionId := c.Param("subscriptionId")
+
+	if subscriptionId == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "Bad Request",
+			"message": "Subscription ID is required",
+		})
+		return
+	}
+
+	value, exists := c.Get("db_service")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service not found",
+		})
+		return
+	}
+
+	db, ok := value.(db_service.DbService[WebhookDelivery])
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "Internal Server Error",
+			"message": "db_service context is not of correct type",
+		})
+		return
+	}
+
+	filter := bson.M{"subscriptionid": subscriptionId}
+	deliveries, err := db.FindDocumentsByCondition(c, filter)
+	if err != nil {
+		respondDbError(c, "Failed to retrieve webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// validateWebhookSubscription checks the subscription before it is stored.
+// The URL must resolve to public addresses only, so the API cannot be used to
+// reach the internal network of the service.
+func validateWebhookSubscription(ctx context.Context, subscription *WebhookSubscription) error {
+	target, err := url.Parse(subscription.Url)
+	if err != nil || !target.IsAbs() || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	if err := netguard.CheckHost(ctx, target.Hostname()); err != nil {
+		return fmt.Errorf("url must target a public address: %w", err)
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return fmt.Errorf("at least one event type is required")
+	}
+
+	for _, eventType := range subscription.EventTypes {
+		if !events.IsKnownType(eventType) {
+			return fmt.Errorf("unknown event type: %v", eventType)
+		}
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
@@ -0,0 +1,75 @@
+package mdm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// storedSubscription keeps one webhook subscription in memory
+type storedSubscription struct {
+	db_service.DbService[WebhookSubscription]
+	subscription WebhookSubscription
+}
+
+func (s *storedSubscription) FindDocument(ctx context.Context, id string) (*WebhookSubscription, error) {
+	if id != s.subscription.Id {
+		return nil, db_service.ErrNotFound
+	}
+	subscription := s.subscription
+	return &subscription, nil
+}
+
+func (s *storedSubscription) UpdateDocument(ctx context.Context, id string, document *WebhookSubscription) error {
+	s.subscription = *document
+	return nil
+}
+
+func TestUpdateWebhookSubscriptionKeepsState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	createdAt := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		stored bool
+		active string
+		want   bool
+	}{
+		{"omitted keeps active", true, "", true},
+		{"omitted keeps inactive", false, "", false},
+		{"deactivates", true, `"active": false,`, false},
+		{"activates", false, `"active": true,`, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db := &storedSubscription{subscription: WebhookSubscription{
+				Id: "sub1", Url: "https://93.184.216.34/hooks", Secret: "secret",
+				EventTypes: []string{"patient.created"}, Active: c.stored, CreatedAt: createdAt,
+			}}
+			router := gin.New()
+			router.Use(func(ctx *gin.Context) {
+				ctx.Set("db_service", db)
+				ctx.Next()
+			})
+			router.PUT("/api/webhooks/:subscriptionId", NewWebhooksAPI().UpdateWebhookSubscription)
+
+			body := `{` + c.active + `"url": "https://93.184.216.34/mdm", "eventTypes": ["patient.updated"]}`
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/api/webhooks/sub1", strings.NewReader(body)))
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status %d, want %d: %s", recorder.Code, http.StatusOK, recorder.Body.String())
+			}
+
+			updated := db.subscription
+			if updated.Active != c.want || updated.Url != "https://93.184.216.34/mdm" || updated.Secret != "secret" || !updated.CreatedAt.Equal(createdAt) {
+				t.Fatalf("updated subscription %+v, want active %v", updated, c.want)
+			}
+		})
+	}
+}
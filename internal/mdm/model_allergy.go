@@ -0,0 +1,26 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type Allergy struct {
+
+	// Substance or drug class the patient is allergic to
+	Substance string `json:"substance"`
+
+	// Observed reaction
+	Reaction string `json:"reaction,omitempty"`
+
+	// Severity of the reaction, unknown when omitted
+	Severity string `json:"severity,omitempty"`
+
+	// Whether the allergy was confirmed by a physician or a test
+	Verified bool `json:"verified,omitempty"`
+}
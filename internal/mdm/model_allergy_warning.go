@@ -0,0 +1,26 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type AllergyWarning struct {
+
+	// Name of the prescribed medication
+	Medication string `json:"medication"`
+
+	// Substance of the patient allergy the medication conflicts with
+	Substance string `json:"substance"`
+
+	// Severity of the allergy
+	Severity string `json:"severity,omitempty"`
+
+	// Whether the allergy was confirmed
+	Verified bool `json:"verified,omitempty"`
+}
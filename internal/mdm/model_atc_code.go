@@ -0,0 +1,20 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type AtcCode struct {
+
+	// ATC code of the medicinal substance
+	Code string `json:"code"`
+
+	// Name of the medicinal substance
+	Display string `json:"display"`
+}
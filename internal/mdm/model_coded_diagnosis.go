@@ -0,0 +1,20 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type CodedDiagnosis struct {
+
+	// ICD-10 (MKCH-10) code of the diagnosis
+	Code string `json:"code"`
+
+	// Name of the diagnosis in the code list
+	Display string `json:"display,omitempty"`
+}
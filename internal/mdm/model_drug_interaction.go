@@ -0,0 +1,35 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type DrugInteraction struct {
+
+	// Name of the checked medication
+	Medication string `json:"medication"`
+
+	// ATC code of the checked medication
+	AtcCode string `json:"atcCode,omitempty"`
+
+	// Name of the interacting medication
+	InteractsWith string `json:"interactsWith"`
+
+	// ATC code of the interacting medication
+	InteractsWithAtcCode string `json:"interactsWithAtcCode,omitempty"`
+
+	// Medical record the interacting medication is actively taken from, empty when it is checked together with the medication
+	InteractsWithRecordId string `json:"interactsWithRecordId,omitempty"`
+
+	// Severity of the interaction
+	Severity string `json:"severity"`
+
+	// Description of the interaction
+	Description string `json:"description,omitempty"`
+}
@@ -0,0 +1,33 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type EarlyWarningParameter struct {
+
+	// Kind of the vital sign
+	Type string `json:"type"`
+
+	// Value of the latest observation in the standard unit
+	Value float64 `json:"value"`
+
+	// Standard unit of the type
+	Unit string `json:"unit"`
+
+	// When the latest observation was measured
+	MeasuredAt time.Time `json:"measuredAt"`
+
+	// NEWS2 score of the value
+	Score int `json:"score"`
+}
@@ -0,0 +1,45 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type EarlyWarningScore struct {
+
+	// Unique identifier of the patient
+	PatientId string `json:"patientId"`
+
+	// Current patient status
+	Status string `json:"status,omitempty"`
+
+	// NEWS2 score, sum of the scores of the parameters
+	Score int `json:"score"`
+
+	// Clinical risk of the score (Low, Low-medium, Medium, High), none when no parameter is scored
+	Risk string `json:"risk,omitempty"`
+
+	// Whether all the parameters required by NEWS2 were observed recently
+	Complete bool `json:"complete"`
+
+	// Required parameters without recent observation, not included in the score
+	Missing []string `json:"missing,omitempty"`
+
+	// Latest observations of the parameters with their scores
+	Parameters []EarlyWarningParameter `json:"parameters"`
+
+	// Status proposed for the patient as the score reached the configured threshold
+	ProposedStatus string `json:"proposedStatus,omitempty"`
+
+	// When the score was calculated
+	CalculatedAt time.Time `json:"calculatedAt"`
+}
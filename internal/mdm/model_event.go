@@ -0,0 +1,36 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type Event struct {
+
+	// Unique identifier of the event
+	Id string `json:"id"`
+
+	// Type of the event
+	Type string `json:"type"`
+
+	// Unique identifier of the patient
+	PatientId string `json:"patientId"`
+
+	// Unique identifier of the medical record, only for medical record events
+	RecordId string `json:"recordId,omitempty"`
+
+	// When the change was made
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// Patient or medical record after the change, missing for delete events
+	Data map[string]interface{} `json:"data,omitempty"`
+}
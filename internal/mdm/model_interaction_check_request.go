@@ -0,0 +1,20 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type InteractionCheckRequest struct {
+
+	// Patient whose active medications are checked together with the medications
+	PatientId string `json:"patientId,omitempty"`
+
+	// Medications to check
+	Medications []Medication `json:"medications"`
+}
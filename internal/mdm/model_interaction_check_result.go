@@ -0,0 +1,20 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type InteractionCheckResult struct {
+
+	// Checked medications with their ATC codes
+	Medications []Medication `json:"medications"`
+
+	// Interactions found, from the most severe
+	Interactions []DrugInteraction `json:"interactions"`
+}
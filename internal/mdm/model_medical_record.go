@@ -28,6 +28,11 @@ type MedicalRecord struct {
 	// Primary diagnosis
 	Diagnosis string `json:"diagnosis"`
 
+	PrimaryDiagnosis *CodedDiagnosis `json:"primaryDiagnosis,omitempty"`
+
+	// Secondary diagnoses coded by ICD-10
+	SecondaryDiagnoses []CodedDiagnosis `json:"secondaryDiagnoses,omitempty"`
+
 	// List of reported symptoms
 	Symptoms []string `json:"symptoms,omitempty"`
 
@@ -37,6 +42,18 @@ type MedicalRecord struct {
 	// List of prescribed medications
 	Medications []Medication `json:"medications,omitempty"`
 
+	// Medications are prescribed despite conflicting with allergies of the patient
+	AllergyOverride bool `json:"allergyOverride,omitempty"`
+
+	// Conflicts of the medications with allergies of the patient, set by the service
+	AllergyWarnings []AllergyWarning `json:"allergyWarnings,omitempty"`
+
+	// Interactions of the medications with each other and with medications the patient actively takes, set by the service
+	Interactions []DrugInteraction `json:"interactions,omitempty"`
+
+	// Medications of former visits reconciled at this visit
+	Reconciliations []MedicationReconciliation `json:"reconciliations,omitempty"`
+
 	// Name of the attending physician
 	DoctorName string `json:"doctorName,omitempty"`
 
@@ -12,9 +12,15 @@ package mdm
 
 type Medication struct {
 
+	// Unique identifier of the prescribed medication, set by the service
+	Id string `json:"id,omitempty"`
+
 	// Name of the medication
 	Name string `json:"name,omitempty"`
 
+	// ATC code of the medicinal substance
+	AtcCode string `json:"atcCode,omitempty"`
+
 	// Dosage amount
 	Dosage string `json:"dosage,omitempty"`
 
@@ -23,4 +29,13 @@ type Medication struct {
 
 	// Duration of treatment
 	Duration string `json:"duration,omitempty"`
+
+	// First day of the treatment
+	StartDate string `json:"startDate,omitempty"`
+
+	// Last day of the treatment, none for long-term treatment
+	EndDate string `json:"endDate,omitempty"`
+
+	// Outcome of the last reconciliation of the medication
+	Status string `json:"status,omitempty"`
 }
@@ -0,0 +1,32 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+type MedicationReconciliation struct {
+
+	// Medication of a former visit being reconciled
+	MedicationId string `json:"medicationId"`
+
+	// Medical record the medication was prescribed in, set by the service
+	RecordId string `json:"recordId,omitempty"`
+
+	// Name of the reconciled medication, set by the service
+	Name string `json:"name,omitempty"`
+
+	// Whether the medication is continued, changed or stopped
+	Action string `json:"action"`
+
+	// Medication replacing the changed one, prescribed at the visit
+	Medication *Medication `json:"medication,omitempty"`
+
+	// Reason of the change or stop
+	Reason string `json:"reason,omitempty"`
+}
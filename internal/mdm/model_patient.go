@@ -14,6 +14,9 @@ import (
 	"time"
 )
 
+// PatientStatuses are the values of the status of the patient
+var PatientStatuses = []string{"Stable", "Critical", "Recovering", "Discharged"}
+
 type Patient struct {
 
 	// Unique identifier of the patient
@@ -40,12 +43,16 @@ type Patient struct {
 	// Current patient status
 	Status string `json:"status,omitempty"`
 
-	// Patient allergies (free text)
-	Allergies string `json:"allergies,omitempty"`
+	// Patient allergies
+	Allergies []Allergy `json:"allergies,omitempty"`
 
 	// General medical notes (free text)
 	MedicalNotes string `json:"medicalNotes,omitempty"`
 
+	Address Address `json:"address,omitempty"`
+
+	EmergencyContact EmergencyContact `json:"emergencyContact,omitempty"`
+
 	// When the patient record was created
 	CreatedAt time.Time `json:"createdAt,omitempty"`
 
@@ -0,0 +1,33 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type PatientEarlyWarning struct {
+
+	// NEWS2 score of the latest observations of the patient
+	Score int `json:"score"`
+
+	// Clinical risk of the score (Low, Low-medium, Medium, High)
+	Risk string `json:"risk"`
+
+	// Whether all the parameters required by NEWS2 were observed recently
+	Complete bool `json:"complete"`
+
+	// Status proposed for the patient as the score reached the configured threshold
+	ProposedStatus string `json:"proposedStatus,omitempty"`
+
+	// When the score was calculated
+	CalculatedAt time.Time `json:"calculatedAt"`
+}
@@ -0,0 +1,29 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type PatientMedication struct {
+
+	// Medical record the medication was prescribed in
+	RecordId string `json:"recordId"`
+
+	// Date and time of the visit the medication was prescribed at
+	DateOfVisit time.Time `json:"dateOfVisit"`
+
+	// Whether the patient currently takes the medication
+	Active bool `json:"active"`
+
+	Medication Medication `json:"medication"`
+}
@@ -0,0 +1,36 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type VitalSign struct {
+
+	// Unique identifier of the patient, set by the service
+	PatientId string `json:"patientId,omitempty"`
+
+	// Kind of the vital sign
+	Type string `json:"type"`
+
+	// Measured value in the unit
+	Value float64 `json:"value"`
+
+	// Unit of the value, the standard unit of the type when omitted
+	Unit string `json:"unit,omitempty"`
+
+	// When the vital sign was measured
+	MeasuredAt time.Time `json:"measuredAt"`
+
+	// Who or which device measured the vital sign
+	Source string `json:"source,omitempty"`
+}
@@ -0,0 +1,39 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type VitalSignSummary struct {
+
+	// Kind of the vital sign
+	Type string `json:"type"`
+
+	// Standard unit of the type
+	Unit string `json:"unit"`
+
+	// Start of the interval
+	Start time.Time `json:"start"`
+
+	// Number of vital signs measured within the interval
+	Count int `json:"count"`
+
+	// Average value of the interval
+	Average float64 `json:"average"`
+
+	// Minimal value of the interval
+	Min float64 `json:"min"`
+
+	// Maximal value of the interval
+	Max float64 `json:"max"`
+}
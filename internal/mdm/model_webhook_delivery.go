@@ -0,0 +1,51 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type WebhookDelivery struct {
+
+	// Unique identifier of the delivery
+	Id string `json:"id"`
+
+	// Unique identifier of the webhook subscription
+	SubscriptionId string `json:"subscriptionId"`
+
+	// Unique identifier of the delivered event
+	EventId string `json:"eventId"`
+
+	// Type of the delivered event
+	EventType string `json:"eventType"`
+
+	// Delivery state, Pending deliveries are retried with exponential backoff
+	Status string `json:"status"`
+
+	// Number of delivery attempts made so far
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// HTTP status code returned by the subscriber on the last attempt
+	ResponseStatus int32 `json:"responseStatus,omitempty"`
+
+	// Error of the last failed attempt
+	LastError string `json:"lastError,omitempty"`
+
+	// When the last attempt was made
+	LastAttemptAt time.Time `json:"lastAttemptAt,omitempty"`
+
+	// When the next attempt is scheduled
+	NextAttemptAt time.Time `json:"nextAttemptAt,omitempty"`
+
+	// When the delivery was created
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+}
@@ -0,0 +1,42 @@
+/*
+ * Patient Management Api
+ *
+ * Patient and Medical Records management for Web-In-Cloud system
+ *
+ * API version: 1.0.0
+ * Contact: your-email@example.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package mdm
+
+import (
+	"time"
+)
+
+type WebhookSubscription struct {
+
+	// Unique identifier of the webhook subscription
+	Id string `json:"id"`
+
+	// Absolute http(s) URL the events are delivered to
+	Url string `json:"url"`
+
+	// Shared secret used to sign deliveries, returned only on creation
+	Secret string `json:"secret,omitempty"`
+
+	// Types of events delivered to the subscriber
+	EventTypes []string `json:"eventTypes"`
+
+	// Whether events are delivered to the subscriber
+	Active bool `json:"active"`
+
+	// Human readable description of the subscriber
+	Description string `json:"description,omitempty"`
+
+	// When the subscription was created
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	// When the subscription was last updated
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
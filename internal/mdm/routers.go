@@ -63,14 +63,70 @@ func DefaultHandleFunc(c *gin.Context) {
 
 type ApiHandleFunctions struct {
 
+	// Routes for the CodesAPI part of the API
+	CodesAPI CodesAPI
+	// Routes for the EarlyWarningAPI part of the API
+	EarlyWarningAPI EarlyWarningAPI
+	// Routes for the EventsAPI part of the API
+	EventsAPI EventsAPI
+	// Routes for the InteractionsAPI part of the API
+	InteractionsAPI InteractionsAPI
 	// Routes for the MedicalRecordsAPI part of the API
 	MedicalRecordsAPI MedicalRecordsAPI
+	// Routes for the MedicationsAPI part of the API
+	MedicationsAPI MedicationsAPI
 	// Routes for the PatientsAPI part of the API
 	PatientsAPI PatientsAPI
+	// Routes for the VitalsAPI part of the API
+	VitalsAPI VitalsAPI
+	// Routes for the WebhooksAPI part of the API
+	WebhooksAPI WebhooksAPI
 }
 
 func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 	return []Route{ 
+		{
+			"SearchAtcCodes",
+			http.MethodGet,
+			"/api/codes/atc",
+			handleFunctions.CodesAPI.SearchAtcCodes,
+		},
+		{
+			"SearchIcd10Codes",
+			http.MethodGet,
+			"/api/codes/icd10",
+			handleFunctions.CodesAPI.SearchIcd10Codes,
+		},
+		{
+			"GetEarlyWarningScores",
+			http.MethodGet,
+			"/api/early-warning",
+			handleFunctions.EarlyWarningAPI.GetEarlyWarningScores,
+		},
+		{
+			"GetPatientEarlyWarningScore",
+			http.MethodGet,
+			"/api/patients/:patientId/early-warning",
+			handleFunctions.EarlyWarningAPI.GetPatientEarlyWarningScore,
+		},
+		{
+			"StreamEvents",
+			http.MethodGet,
+			"/api/events",
+			handleFunctions.EventsAPI.StreamEvents,
+		},
+		{
+			"StreamEventsWebSocket",
+			http.MethodGet,
+			"/api/events/ws",
+			handleFunctions.EventsAPI.StreamEventsWebSocket,
+		},
+		{
+			"CheckInteractions",
+			http.MethodPost,
+			"/api/interactions\\:check",
+			handleFunctions.InteractionsAPI.CheckInteractions,
+		},
 		{
 			"CreateMedicalRecord",
 			http.MethodPost,
@@ -95,6 +151,18 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/api/patients/:patientId/medical-records/:recordId",
 			handleFunctions.MedicalRecordsAPI.UpdateMedicalRecord,
 		},
+		{
+			"GetPatientMedications",
+			http.MethodGet,
+			"/api/patients/:patientId/medications",
+			handleFunctions.MedicationsAPI.GetPatientMedications,
+		},
+		{
+			"ReconcileMedications",
+			http.MethodPost,
+			"/api/patients/:patientId/medical-records/:recordId/reconciliation",
+			handleFunctions.MedicationsAPI.ReconcileMedications,
+		},
 		{
 			"CreatePatient",
 			http.MethodPost,
@@ -125,5 +193,59 @@ func getRoutes(handleFunctions ApiHandleFunctions) []Route {
 			"/api/patients/:patientId",
 			handleFunctions.PatientsAPI.UpdatePatient,
 		},
+		{
+			"GetPatientVitals",
+			http.MethodGet,
+			"/api/patients/:patientId/vitals",
+			handleFunctions.VitalsAPI.GetPatientVitals,
+		},
+		{
+			"GetPatientVitalsSummary",
+			http.MethodGet,
+			"/api/patients/:patientId/vitals/summary",
+			handleFunctions.VitalsAPI.GetPatientVitalsSummary,
+		},
+		{
+			"RecordPatientVitals",
+			http.MethodPost,
+			"/api/patients/:patientId/vitals",
+			handleFunctions.VitalsAPI.RecordPatientVitals,
+		},
+		{
+			"CreateWebhookSubscription",
+			http.MethodPost,
+			"/api/webhooks",
+			handleFunctions.WebhooksAPI.CreateWebhookSubscription,
+		},
+		{
+			"DeleteWebhookSubscription",
+			http.MethodDelete,
+			"/api/webhooks/:subscriptionId",
+			handleFunctions.WebhooksAPI.DeleteWebhookSubscription,
+		},
+		{
+			"GetWebhookDeliveries",
+			http.MethodGet,
+			"/api/webhooks/:subscriptionId/deliveries",
+			handleFunctions.WebhooksAPI.GetWebhookDeliveries,
+		},
+		{
+			"GetWebhookSubscription",
+			http.MethodGet,
+			"/api/webhooks/:subscriptionId",
+			handleFunctions.WebhooksAPI.GetWebhookSubscription,
+		},
+		{
+			"GetWebhookSubscriptions",
+			http.MethodGet,
+			"/api/webhooks",
+			handleFunctions.WebhooksAPI.GetWebhookSubscriptions,
+		},
+		{
+			"UpdateWebhookSubscription",
+			http.MethodPut,
+			"/api/webhooks/:subscriptionId",
+			handleFunctions.WebhooksAPI.UpdateWebhookSubscription,
+		},
 	}
 }
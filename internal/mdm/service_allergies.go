@@ -0,0 +1,145 @@
+package mdm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samsvi/mdm-webapi/internal/codes"
+)
+
+// severities of allergies, the severity is unknown when empty
+var allergySeverities = map[string]bool{"": true, "Mild": true, "Moderate": true, "Severe": true}
+
+// allergyClasses lists names and ATC groups of medications cross-reacting
+// with the allergy recorded by the substance or by the drug class, names are
+// without diacritics. Medications whose name contains the substance itself
+// always conflict.
+var allergyClasses = []struct {
+	substances  []string
+	medications []string
+	atcGroups   []string
+}{
+	{
+		substances:  []string{"penicil", "betalaktam", "beta-laktam"},
+		medications: []string{"penicil", "amoxicil", "ampicil", "oxacil", "piperacil", "augmentin", "amoksiklav", "ospamox"},
+		atcGroups:   []string{"J01C"},
+	},
+	{
+		substances:  []string{"cefalosporin"},
+		medications: []string{"cef", "zinnat"},
+		atcGroups:   []string{"J01DB", "J01DC", "J01DD", "J01DE"},
+	},
+	{
+		substances:  []string{"sulfonamid", "sulfa"},
+		medications: []string{"sulfa", "biseptol", "kotrimoxazol", "cotrimoxazol"},
+		atcGroups:   []string{"J01E"},
+	},
+	{
+		substances:  []string{"makrolid"},
+		medications: []string{"klaritromycin", "azitromycin", "erytromycin", "sumamed", "fromilid"},
+		atcGroups:   []string{"J01FA"},
+	},
+	{
+		substances:  []string{"ibuprofen", "nsaid", "nesteroidn", "aspirin", "acetylsalicyl", "diklofenak", "naproxen"},
+		medications: []string{"ibuprofen", "diklofenak", "naproxen", "ketoprofen", "nimesulid", "aspirin", "acetylsalicyl", "nurofen", "brufen", "voltaren", "aulin"},
+		atcGroups:   []string{"M01A", "B01AC06", "N02BA"},
+	},
+	{
+		substances:  []string{"jod"},
+		medications: []string{"jod", "povidon", "betadine"},
+		atcGroups:   []string{"D08AG"},
+	},
+	{
+		substances:  []string{"opiat", "opioid", "kodein", "morfin"},
+		medications: []string{"kodein", "morfin", "tramadol", "oxykodon", "fentanyl"},
+		atcGroups:   []string{"N02A", "R05DA04"},
+	},
+}
+
+// AllergyConflictError is returned when prescribed medications conflict with
+// allergies of the patient and the record does not override the conflicts
+type AllergyConflictError struct {
+	Warnings []AllergyWarning
+}
+
+func (e *AllergyConflictError) Error() string {
+	conflicts := make([]string, 0, len(e.Warnings))
+	for _, warning := range e.Warnings {
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", warning.Medication, warning.Substance))
+	}
+	return "medications conflict with allergies of the patient: " + strings.Join(conflicts, ", ")
+}
+
+// validateAllergies requires substance and known severity of every allergy
+func validateAllergies(allergies []Allergy) error {
+	for _, allergy := range allergies {
+		if strings.TrimSpace(allergy.Substance) == "" {
+			return &ValidationError{Message: "Allergy substance is required"}
+		}
+		if !allergySeverities[allergy.Severity] {
+			return &ValidationError{Message: fmt.Sprintf("Unknown allergy severity %s, expected Mild, Moderate or Severe", allergy.Severity)}
+		}
+	}
+	return nil
+}
+
+// AllergyConflicts returns warnings for the medications whose name matches
+// substance of an allergy or a medication cross-reacting with it, ignoring
+// case and diacritics, or whose ATC code is in a group cross-reacting with it
+func AllergyConflicts(allergies []Allergy, medications []Medication) []AllergyWarning {
+	var warnings []AllergyWarning
+	for _, medication := range medications {
+		name := codes.Fold(medication.Name)
+		if name == "" && medication.AtcCode == "" {
+			continue
+		}
+		for _, allergy := range allergies {
+			if conflictsWith(name, medication.AtcCode, allergy) {
+				warnings = append(warnings, AllergyWarning{
+					Medication: medication.Name,
+					Substance:  allergy.Substance,
+					Severity:   allergy.Severity,
+					Verified:   allergy.Verified,
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+func conflictsWith(medication string, atcCode string, allergy Allergy) bool {
+	substance := codes.Fold(strings.TrimSpace(allergy.Substance))
+	if substance == "" {
+		return false
+	}
+	if medication != "" && strings.Contains(medication, substance) {
+		return true
+	}
+	for _, class := range allergyClasses {
+		if !containsAny(substance, class.substances) {
+			continue
+		}
+		if (medication != "" && containsAny(medication, class.medications)) || hasAnyPrefix(atcCode, class.atcGroups) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(code string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if code != "" && strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(text string, values []string) bool {
+	for _, value := range values {
+		if strings.Contains(text, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+package mdm
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Clinical risks of the NEWS2 score
+const (
+	RiskLow       = "Low"
+	RiskLowMedium = "Low-medium"
+	RiskMedium    = "Medium"
+	RiskHigh      = "High"
+)
+
+// Actions taken when the early warning score reaches the threshold
+const (
+	EarlyWarningPropose = "propose"
+	EarlyWarningAlert   = "alert"
+	EarlyWarningBoth    = "both"
+)
+
+// EarlyWarningConfig sets the score at which the patient is proposed the
+// Critical status, alerted by the patient.early-warning event, or both
+type EarlyWarningConfig struct {
+	Threshold int
+	Action    string
+	// observations measured earlier are not scored
+	MaxAge time.Duration
+}
+
+// DefaultEarlyWarningConfig proposes Critical status and alerts at high
+// clinical risk scored from observations of the last day
+var DefaultEarlyWarningConfig = EarlyWarningConfig{Threshold: 7, Action: EarlyWarningBoth, MaxAge: 24 * time.Hour}
+
+func (c EarlyWarningConfig) proposes() bool {
+	return c.Action == EarlyWarningPropose || c.Action == EarlyWarningBoth
+}
+
+func (c EarlyWarningConfig) alerts() bool {
+	return c.Action == EarlyWarningAlert || c.Action == EarlyWarningBoth
+}
+
+// news2Parameters are the vital signs scored by NEWS2 in the order of the
+// chart. Patients without a recent observation of supplemental oxygen are
+// scored as breathing air.
+var news2Parameters = []string{
+	VitalRespiratoryRate,
+	VitalSpO2,
+	VitalSupplementalOxygen,
+	VitalSystolicPressure,
+	VitalHeartRate,
+	VitalConsciousness,
+	VitalTemperature,
+}
+
+// news2Band scores values up to the bound
+type news2Band struct {
+	upTo  float64
+	score int
+}
+
+// news2Bands score the values of the parameters, values above the last band
+// score the last score. SpO2 is scored on scale 1, scale 2 for patients with
+// hypercapnic respiratory failure is not supported.
+var news2Bands = map[string][]news2Band{
+	VitalRespiratoryRate:  {{8, 3}, {11, 1}, {20, 0}, {24, 2}, {0, 3}},
+	VitalSpO2:             {{91, 3}, {93, 2}, {95, 1}, {0, 0}},
+	VitalSystolicPressure: {{90, 3}, {100, 2}, {110, 1}, {219, 0}, {0, 3}},
+	VitalHeartRate:        {{40, 3}, {50, 1}, {90, 0}, {110, 1}, {130, 2}, {0, 3}},
+	VitalTemperature:      {{35, 3}, {36, 1}, {38, 0}, {39, 1}, {0, 2}},
+}
+
+// News2ParameterScore scores the value of the vital sign in its standard unit
+func News2ParameterScore(vitalType string, value float64) int {
+	switch vitalType {
+	case VitalConsciousness:
+		// new confusion, voice, pain and unresponsive score alike
+		if value > ConsciousnessAlert {
+			return 3
+		}
+		return 0
+	case VitalSupplementalOxygen:
+		if value > 0 {
+			return 2
+		}
+		return 0
+	}
+	bands := news2Bands[vitalType]
+	for i, band := range bands {
+		if i == len(bands)-1 || value <= band.upTo {
+			return band.score
+		}
+	}
+	return 0
+}
+
+// News2Score scores the latest of the vital signs of each NEWS2 parameter.
+// Required parameters without any vital sign are reported missing and do not
+// contribute to the score.
+func News2Score(patientId string, vitals []VitalSign, now time.Time) EarlyWarningScore {
+	latest := map[string]VitalSign{}
+	for _, vital := range vitals {
+		if previous, ok := latest[vital.Type]; !ok || vital.MeasuredAt.After(previous.MeasuredAt) {
+			latest[vital.Type] = vital
+		}
+	}
+
+	score := EarlyWarningScore{PatientId: patientId, Parameters: []EarlyWarningParameter{}, CalculatedAt: now}
+	red := false
+	for _, parameter := range news2Parameters {
+		vital, ok := latest[parameter]
+		if !ok {
+			if parameter != VitalSupplementalOxygen {
+				score.Missing = append(score.Missing, parameter)
+			}
+			continue
+		}
+		parameterScore := News2ParameterScore(parameter, vital.Value)
+		score.Score += parameterScore
+		red = red || parameterScore == 3
+		score.Parameters = append(score.Parameters, EarlyWarningParameter{
+			Type:       parameter,
+			Value:      vital.Value,
+			Unit:       vitalSignTypes[parameter].unit,
+			MeasuredAt: vital.MeasuredAt,
+			Score:      parameterScore,
+		})
+	}
+	score.Complete = len(score.Missing) == 0
+
+	switch {
+	case len(score.Parameters) == 0:
+	case score.Score >= 7:
+		score.Risk = RiskHigh
+	case score.Score >= 5:
+		score.Risk = RiskMedium
+	case red:
+		// a single parameter scoring 3 requires urgent review
+		score.Risk = RiskLowMedium
+	default:
+		score.Risk = RiskLow
+	}
+	return score
+}
+
+// GetEarlyWarningScore scores the latest observations of the patient
+func (s *VitalsService) GetEarlyWarningScore(ctx context.Context, patientId string) (*EarlyWarningScore, error) {
+	patient, err := s.patients.FindDocument(ctx, patientId)
+	if err != nil {
+		return nil, err
+	}
+	return s.latestScore(ctx, *patient)
+}
+
+// GetPatientEarlyWarning summarizes the score of the latest observations of
+// the patient, it is nil when no NEWS2 parameter was observed recently
+func (s *VitalsService) GetPatientEarlyWarning(ctx context.Context, patient Patient) (*PatientEarlyWarning, error) {
+	score, err := s.latestScore(ctx, patient)
+	if err != nil || len(score.Parameters) == 0 {
+		return nil, err
+	}
+	return &PatientEarlyWarning{
+		Score:          score.Score,
+		Risk:           score.Risk,
+		Complete:       score.Complete,
+		ProposedStatus: score.ProposedStatus,
+		CalculatedAt:   score.CalculatedAt,
+	}, nil
+}
+
+func (s *VitalsService) latestScore(ctx context.Context, patient Patient) (*EarlyWarningScore, error) {
+	now := time.Now()
+	vitals, err := s.db.Latest(ctx, news2Filter(bson.M{"patientid": patient.Id}), now.Add(-s.earlyWarning.MaxAge))
+	if err != nil {
+		return nil, err
+	}
+	score := s.scorePatient(patient, vitals, now)
+	return &score, nil
+}
+
+// GetEarlyWarningScores scores the latest observations of all the patients
+// not discharged from the highest score
+func (s *VitalsService) GetEarlyWarningScores(ctx context.Context) ([]EarlyWarningScore, error) {
+	patients, err := s.patients.FindAllDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	vitals, err := s.db.Latest(ctx, news2Filter(bson.M{}), now.Add(-s.earlyWarning.MaxAge))
+	if err != nil {
+		return nil, err
+	}
+	vitalsOfPatients := map[string][]VitalSign{}
+	for _, vital := range vitals {
+		vitalsOfPatients[vital.PatientId] = append(vitalsOfPatients[vital.PatientId], vital)
+	}
+
+	scores := []EarlyWarningScore{}
+	for _, patient := range patients {
+		if patient.Status == "Discharged" {
+			continue
+		}
+		scores = append(scores, s.scorePatient(patient, vitalsOfPatients[patient.Id], now))
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].PatientId < scores[j].PatientId
+	})
+	return scores, nil
+}
+
+// earlyWarningEvent returns context carrying the patient.early-warning event
+// when the vital signs being recorded raise the score of the patient from
+// below the threshold to at least the threshold. Concurrent recordings are
+// not serialized, so an alert may be missed or repeated when they race.
+func (s *VitalsService) earlyWarningEvent(ctx context.Context, patient Patient, vitals []VitalSign) (context.Context, error) {
+	if !s.earlyWarning.alerts() {
+		return ctx, nil
+	}
+	if !slices.ContainsFunc(vitals, func(vital VitalSign) bool { return slices.Contains(news2Parameters, vital.Type) }) {
+		return ctx, nil
+	}
+
+	now := time.Now()
+	since := now.Add(-s.earlyWarning.MaxAge)
+	latest, err := s.db.Latest(ctx, news2Filter(bson.M{"patientid": patient.Id}), since)
+	if err != nil {
+		return ctx, err
+	}
+	before := s.scorePatient(patient, latest, now)
+	for _, vital := range vitals {
+		if !vital.MeasuredAt.Before(since) {
+			latest = append(latest, vital)
+		}
+	}
+	after := s.scorePatient(patient, latest, now)
+	if before.Score >= s.earlyWarning.Threshold || after.Score < s.earlyWarning.Threshold {
+		return ctx, nil
+	}
+	return withEvent(ctx, events.PatientEarlyWarning, patient.Id, "", after), nil
+}
+
+func (s *VitalsService) scorePatient(patient Patient, vitals []VitalSign, now time.Time) EarlyWarningScore {
+	score := News2Score(patient.Id, vitals, now)
+	score.Status = patient.Status
+	if s.earlyWarning.proposes() && score.Score >= s.earlyWarning.Threshold && patient.Status != "Critical" {
+		score.ProposedStatus = "Critical"
+	}
+	return score
+}
+
+func news2Filter(filter bson.M) bson.M {
+	filter["type"] = bson.M{"$in": news2Parameters}
+	return filter
+}
@@ -0,0 +1,127 @@
+package mdm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNews2ParameterScore(t *testing.T) {
+	cases := []struct {
+		vitalType string
+		bands     map[float64]int
+	}{
+		{VitalRespiratoryRate, map[float64]int{5: 3, 8: 3, 9: 1, 11: 1, 12: 0, 20: 0, 21: 2, 24: 2, 25: 3, 40: 3}},
+		{VitalSpO2, map[float64]int{85: 3, 91: 3, 92: 2, 93: 2, 94: 1, 95: 1, 96: 0, 100: 0}},
+		{VitalSystolicPressure, map[float64]int{70: 3, 90: 3, 91: 2, 100: 2, 101: 1, 110: 1, 111: 0, 219: 0, 220: 3}},
+		{VitalHeartRate, map[float64]int{30: 3, 40: 3, 41: 1, 50: 1, 51: 0, 90: 0, 91: 1, 110: 1, 111: 2, 130: 2, 131: 3}},
+		{VitalTemperature, map[float64]int{34: 3, 35: 3, 35.1: 1, 36: 1, 36.1: 0, 38: 0, 38.1: 1, 39: 1, 39.1: 2, 41: 2}},
+		{VitalConsciousness, map[float64]int{ConsciousnessAlert: 0, ConsciousnessConfusion: 3, ConsciousnessVoice: 3, ConsciousnessPain: 3, ConsciousnessUnresponsive: 3}},
+		{VitalSupplementalOxygen, map[float64]int{0: 0, 1: 2}},
+	}
+	for _, c := range cases {
+		for value, want := range c.bands {
+			if got := News2ParameterScore(c.vitalType, value); got != want {
+				t.Errorf("News2ParameterScore(%s, %v) = %d, want %d", c.vitalType, value, got, want)
+			}
+		}
+	}
+}
+
+// news2Vitals returns the vital signs of the parameters, parameters not given
+// are observed in the normal range
+func news2Vitals(values map[string]float64, measuredAt time.Time) []VitalSign {
+	normal := map[string]float64{
+		VitalRespiratoryRate:  16,
+		VitalSpO2:             97,
+		VitalSystolicPressure: 120,
+		VitalHeartRate:        70,
+		VitalConsciousness:    ConsciousnessAlert,
+		VitalTemperature:      36.8,
+	}
+	for vitalType, value := range values {
+		normal[vitalType] = value
+	}
+	vitals := []VitalSign{}
+	for vitalType, value := range normal {
+		vitals = append(vitals, VitalSign{PatientId: "pat1", Type: vitalType, Value: value, MeasuredAt: measuredAt})
+	}
+	return vitals
+}
+
+func TestNews2Score(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name   string
+		values map[string]float64
+		score  int
+		risk   string
+	}{
+		{"normal", nil, 0, RiskLow},
+		{"aggregate 4", map[string]float64{VitalRespiratoryRate: 22, VitalSupplementalOxygen: 1}, 4, RiskLow},
+		{"single parameter 3", map[string]float64{VitalConsciousness: ConsciousnessVoice}, 3, RiskLowMedium},
+		{"aggregate 5", map[string]float64{VitalRespiratoryRate: 22, VitalHeartRate: 120, VitalTemperature: 38.5}, 5, RiskMedium},
+		{"single parameter 3 in aggregate 5", map[string]float64{VitalSpO2: 90, VitalSupplementalOxygen: 1}, 5, RiskMedium},
+		{"aggregate 6", map[string]float64{VitalRespiratoryRate: 25, VitalSpO2: 95, VitalHeartRate: 120}, 6, RiskMedium},
+		{"aggregate 7", map[string]float64{VitalRespiratoryRate: 25, VitalSpO2: 93, VitalSystolicPressure: 105, VitalTemperature: 38.5}, 7, RiskHigh},
+	}
+	for _, c := range cases {
+		score := News2Score("pat1", news2Vitals(c.values, now.Add(-time.Hour)), now)
+		if score.Score != c.score || score.Risk != c.risk || !score.Complete {
+			t.Errorf("%s: score %d, risk %s, complete %v, want score %d, risk %s", c.name, score.Score, score.Risk, score.Complete, c.score, c.risk)
+		}
+	}
+}
+
+func TestNews2ScoreOfLatestVitals(t *testing.T) {
+	now := time.Now()
+	vitals := []VitalSign{
+		{Type: VitalHeartRate, Value: 140, MeasuredAt: now.Add(-2 * time.Hour)},
+		{Type: VitalHeartRate, Value: 80, MeasuredAt: now.Add(-time.Hour)},
+	}
+	score := News2Score("pat1", vitals, now)
+	if score.Score != 0 || score.Risk != RiskLow || score.Complete || len(score.Missing) != 5 {
+		t.Fatalf("score %+v, want the latest heart rate scored and the other parameters missing", score)
+	}
+
+	if empty := News2Score("pat1", nil, now); empty.Risk != "" || len(empty.Parameters) != 0 {
+		t.Fatalf("score without vital signs %+v, want no risk", empty)
+	}
+}
+
+// latestVitals returns the vital signs as the latest ones of the patient
+type latestVitals struct {
+	db_service.TimeSeriesService[VitalSign]
+	vitals []VitalSign
+}
+
+func (l latestVitals) Latest(ctx context.Context, filter bson.M, since time.Time) ([]VitalSign, error) {
+	return l.vitals, nil
+}
+
+func TestGetPatientEarlyWarning(t *testing.T) {
+	patient := Patient{Id: "pat1", Status: "Stable"}
+	vitals := news2Vitals(map[string]float64{VitalRespiratoryRate: 25, VitalSpO2: 93, VitalSystolicPressure: 105, VitalTemperature: 38.5}, time.Now())
+	service := NewVitalsService(latestVitals{vitals: vitals}, nil, DefaultEarlyWarningConfig)
+
+	earlyWarning, err := service.GetPatientEarlyWarning(context.Background(), patient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if earlyWarning == nil || earlyWarning.Score != 7 || earlyWarning.Risk != RiskHigh || earlyWarning.ProposedStatus != "Critical" {
+		t.Fatalf("early warning %+v, want score 7 proposing Critical status", earlyWarning)
+	}
+
+	patient.Status = "Critical"
+	if earlyWarning, _ := service.GetPatientEarlyWarning(context.Background(), patient); earlyWarning.ProposedStatus != "" {
+		t.Fatalf("early warning %+v proposes status of the critical patient", earlyWarning)
+	}
+
+	service = NewVitalsService(latestVitals{}, nil, DefaultEarlyWarningConfig)
+	if earlyWarning, err := service.GetPatientEarlyWarning(context.Background(), patient); earlyWarning != nil || err != nil {
+		t.Fatalf("early warning %+v, %v of patient without vital signs, want none", earlyWarning, err)
+	}
+}
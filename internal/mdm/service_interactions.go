@@ -0,0 +1,111 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/codes"
+	"github.com/samsvi/mdm-webapi/internal/interactions"
+)
+
+// prescription is a medication actively taken by the patient with the record
+// it was prescribed in
+type prescription struct {
+	recordId   string
+	medication Medication
+}
+
+// codeMedications validates that ATC codes of the medications exist and
+// fills in their canonical form. Medications without the code get it by the
+// name of the substance when the name is in the ATC list. Codes missing in the
+// list are rejected, since the interactions of their substances are unknown.
+func codeMedications(medications []Medication) error {
+	for i := range medications {
+		medication := &medications[i]
+		if medication.AtcCode == "" {
+			if code, ok := codes.Atc.LookupDisplay(medication.Name); ok {
+				medication.AtcCode = code.Code
+			}
+			continue
+		}
+		code, ok := codes.Atc.Lookup(medication.AtcCode)
+		if !ok {
+			if !codes.IsAtcCode(medication.AtcCode) {
+				return &ValidationError{Message: fmt.Sprintf("Invalid ATC code %s", medication.AtcCode)}
+			}
+			return &ValidationError{Message: fmt.Sprintf("Unknown ATC code %s", medication.AtcCode)}
+		}
+		medication.AtcCode = code.Code
+	}
+	return nil
+}
+
+// CheckInteractions validates the medications and returns their interactions
+// with each other and, when the patient is given, with medications the
+// patient actively takes, except those of the record being updated
+func (s *MedicalRecordsService) CheckInteractions(ctx context.Context, patientId string, recordId string, medications []Medication) ([]DrugInteraction, error) {
+	if err := codeMedications(medications); err != nil {
+		return nil, err
+	}
+
+	var records []MedicalRecord
+	if patientId != "" && len(medications) > 0 {
+		var err error
+		if records, err = s.GetPatientMedicalRecords(ctx, patientId); err != nil {
+			return nil, err
+		}
+	}
+	return drugInteractions(medications, activePrescriptions(records, recordId, time.Now())), nil
+}
+
+// drugInteractions returns interactions of the medications with each other and
+// with the active prescriptions ordered from the most severe. Medications
+// without ATC code are not checked.
+func drugInteractions(medications []Medication, active []prescription) []DrugInteraction {
+	found := []DrugInteraction{}
+	for i, medication := range medications {
+		for _, other := range medications[i+1:] {
+			if interaction, ok := drugInteraction(medication, other); ok {
+				found = append(found, interaction)
+			}
+		}
+		for _, prescribed := range active {
+			other := prescribed.medication
+			if other.AtcCode == "" {
+				// records written before the medications were coded
+				if code, ok := codes.Atc.LookupDisplay(other.Name); ok {
+					other.AtcCode = code.Code
+				}
+			}
+			if interaction, ok := drugInteraction(medication, other); ok {
+				interaction.InteractsWithRecordId = prescribed.recordId
+				found = append(found, interaction)
+			}
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return interactions.Compare(found[i].Severity, found[j].Severity) > 0
+	})
+	return found
+}
+
+func drugInteraction(medication, other Medication) (DrugInteraction, bool) {
+	// the same substance prescribed again is not an interaction
+	if medication.AtcCode == "" || other.AtcCode == "" || medication.AtcCode == other.AtcCode {
+		return DrugInteraction{}, false
+	}
+	rule, ok := interactions.Interaction(medication.AtcCode, other.AtcCode)
+	if !ok {
+		return DrugInteraction{}, false
+	}
+	return DrugInteraction{
+		Medication:           medication.Name,
+		AtcCode:              medication.AtcCode,
+		InteractsWith:        other.Name,
+		InteractsWithAtcCode: other.AtcCode,
+		Severity:             rule.Severity,
+		Description:          rule.Description,
+	}, true
+}
@@ -0,0 +1,41 @@
+package mdm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCodeMedications(t *testing.T) {
+	medications := []Medication{
+		{Name: "Ibuprofén", AtcCode: "m01ae01"},
+		{Name: "warfarín"},
+		{Name: "Bylinný čaj"},
+	}
+	if err := codeMedications(medications); err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"M01AE01", "B01AA03", ""} {
+		if medications[i].AtcCode != want {
+			t.Errorf("medication %+v, want ATC code %q", medications[i], want)
+		}
+	}
+
+	cases := []struct {
+		code    string
+		message string
+	}{
+		{"M01A", "Invalid ATC code M01A"},
+		{"M01AE1", "Invalid ATC code M01AE1"},
+		{"M01AE01X", "Invalid ATC code M01AE01X"},
+		// well-formed codes missing in the catalogue have unknown interactions
+		{"M01AC01", "Unknown ATC code M01AC01"},
+		{"N02BE99", "Unknown ATC code N02BE99"},
+	}
+	for _, c := range cases {
+		var validationErr *ValidationError
+		err := codeMedications([]Medication{{Name: "Liek", AtcCode: c.code}})
+		if !errors.As(err, &validationErr) || validationErr.Message != c.message {
+			t.Errorf("code %q returned %v, want *ValidationError %q", c.code, err, c.message)
+		}
+	}
+}
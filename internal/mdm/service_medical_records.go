@@ -0,0 +1,187 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/codes"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MedicalRecordIndexes are indexes of the medical records collection,
+// records of a patient are listed from the latest visit, records are reported
+// by the coded primary diagnosis
+var MedicalRecordIndexes = []db_service.Index{
+	{Name: "id", Keys: []string{"id"}, Unique: true},
+	{Name: "patientid", Keys: []string{"patientid", "-dateofvisit"}},
+	{Name: "primarydiagnosis_code", Keys: []string{"primarydiagnosis.code", "-dateofvisit"}},
+}
+
+// LogValue limits logged medical record to its identifiers
+func (r MedicalRecord) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("id", r.Id), slog.String("patientId", r.PatientId))
+}
+
+// MedicalRecordsService implements validation and persistence of medical
+// records shared by all the APIs exposing them. Prescribed medications are
+// checked against allergies of the patient loaded from the patients.
+type MedicalRecordsService struct {
+	db       db_service.DbService[MedicalRecord]
+	patients db_service.DbService[Patient]
+}
+
+func NewMedicalRecordsService(db db_service.DbService[MedicalRecord], patients db_service.DbService[Patient]) *MedicalRecordsService {
+	return &MedicalRecordsService{db: db, patients: patients}
+}
+
+func (s *MedicalRecordsService) CreateMedicalRecord(ctx context.Context, patientId string, record *MedicalRecord) error {
+	if err := codeDiagnoses(record); err != nil {
+		return err
+	}
+	if record.Diagnosis == "" || record.DateOfVisit.IsZero() {
+		return &ValidationError{Message: "Missing required fields (diagnosis, dateOfVisit)"}
+	}
+	if err := s.checkMedications(ctx, patientId, "", record); err != nil {
+		return err
+	}
+
+	if record.Id == "" || record.Id == "@new" {
+		record.Id = uuid.NewString()
+	}
+
+	record.PatientId = patientId
+
+	now := time.Now()
+	record.CreatedAt = now
+	record.UpdatedAt = now
+
+	ctx = withEvent(ctx, events.MedicalRecordCreated, patientId, record.Id, record)
+	return s.db.CreateDocument(ctx, record.Id, record)
+}
+
+func (s *MedicalRecordsService) GetPatientMedicalRecords(ctx context.Context, patientId string) ([]MedicalRecord, error) {
+	filter := bson.M{"patientid": patientId}
+	return s.db.FindDocumentsByCondition(ctx, filter)
+}
+
+// GetMedicalRecordsOfPatients loads records of many patients with single query
+func (s *MedicalRecordsService) GetMedicalRecordsOfPatients(ctx context.Context, patientIds []string) ([]MedicalRecord, error) {
+	filter := bson.M{"patientid": bson.M{"$in": patientIds}}
+	return s.db.FindDocumentsByCondition(ctx, filter)
+}
+
+func (s *MedicalRecordsService) UpdateMedicalRecord(ctx context.Context, patientId string, recordId string, record *MedicalRecord) error {
+	if record.Id != "" && record.Id != recordId {
+		return ErrIdMismatch
+	}
+	if err := codeDiagnoses(record); err != nil {
+		return err
+	}
+	if err := s.checkMedications(ctx, patientId, recordId, record); err != nil {
+		return err
+	}
+
+	record.Id = recordId
+	record.PatientId = patientId
+	record.UpdatedAt = time.Now()
+
+	ctx = withEvent(ctx, events.MedicalRecordUpdated, patientId, recordId, record)
+	return s.db.UpdateDocument(ctx, recordId, record)
+}
+
+func (s *MedicalRecordsService) DeleteMedicalRecord(ctx context.Context, patientId string, recordId string) error {
+	ctx = withEvent(ctx, events.MedicalRecordDeleted, patientId, recordId, nil)
+	return s.db.DeleteDocument(ctx, recordId)
+}
+
+// checkMedications schedules the medications of the record and checks them
+// against allergies of the patient and for interactions with the medications
+// the patient takes
+func (s *MedicalRecordsService) checkMedications(ctx context.Context, patientId string, recordId string, record *MedicalRecord) error {
+	if err := ScheduleMedications(record); err != nil {
+		return err
+	}
+	found, err := s.CheckInteractions(ctx, patientId, recordId, record.Medications)
+	if err != nil {
+		return err
+	}
+	if err := s.checkAllergies(ctx, patientId, record); err != nil {
+		return err
+	}
+	record.Interactions = found
+	if len(found) == 0 {
+		record.Interactions = nil
+	}
+	return nil
+}
+
+// checkAllergies rejects medications conflicting with allergies of the
+// patient unless the record overrides the conflicts, overridden conflicts are
+// kept in the record as warnings
+func (s *MedicalRecordsService) checkAllergies(ctx context.Context, patientId string, record *MedicalRecord) error {
+	record.AllergyWarnings = nil
+	if len(record.Medications) == 0 {
+		return nil
+	}
+
+	patient, err := s.patients.FindDocument(ctx, patientId)
+	if err != nil {
+		return err
+	}
+	warnings := AllergyConflicts(patient.Allergies, record.Medications)
+	if len(warnings) > 0 && !record.AllergyOverride {
+		return &AllergyConflictError{Warnings: warnings}
+	}
+	record.AllergyWarnings = warnings
+	return nil
+}
+
+// codeDiagnoses validates the codes of the coded diagnoses and fills in their
+// canonical codes and the names from the ICD-10 list. The free-text diagnosis
+// defaults to the name of the primary diagnosis.
+func codeDiagnoses(record *MedicalRecord) error {
+	if record.PrimaryDiagnosis != nil {
+		if err := lookupDiagnosis(record.PrimaryDiagnosis); err != nil {
+			return err
+		}
+		if record.Diagnosis == "" {
+			record.Diagnosis = record.PrimaryDiagnosis.Display
+		}
+	}
+	for i := range record.SecondaryDiagnoses {
+		if err := lookupDiagnosis(&record.SecondaryDiagnoses[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func lookupDiagnosis(diagnosis *CodedDiagnosis) error {
+	if strings.TrimSpace(diagnosis.Code) == "" {
+		return &ValidationError{Message: "Diagnosis code is required"}
+	}
+	code, ok := codes.Icd10.Lookup(diagnosis.Code)
+	if !ok {
+		if !codes.IsIcd10Code(diagnosis.Code) {
+			return &ValidationError{Message: fmt.Sprintf("Invalid ICD-10 code %s", diagnosis.Code)}
+		}
+		return &ValidationError{Message: fmt.Sprintf("Unknown ICD-10 code %s", diagnosis.Code)}
+	}
+	diagnosis.Code = code.Code
+	diagnosis.Display = code.Display
+	return nil
+}
+
+// SortByDateOfVisit orders records from the most recent visit
+func SortByDateOfVisit(records []MedicalRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].DateOfVisit.After(records[j].DateOfVisit)
+	})
+}
@@ -0,0 +1,36 @@
+package mdm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupDiagnosis(t *testing.T) {
+	listed := CodedDiagnosis{Code: "j069", Display: "ignored"}
+	if err := lookupDiagnosis(&listed); err != nil {
+		t.Fatal(err)
+	}
+	if listed.Code != "J06.9" || listed.Display != "Akútna infekcia horných dýchacích ciest, bližšie neurčená" {
+		t.Fatalf("listed diagnosis %+v", listed)
+	}
+
+	cases := []struct {
+		code    string
+		message string
+	}{
+		{"", "Diagnosis code is required"},
+		{"J6.9", "Invalid ICD-10 code J6.9"},
+		{"123", "Invalid ICD-10 code 123"},
+		{"J06.9.1", "Invalid ICD-10 code J06.9.1"},
+		// well-formed codes missing in the list, e.g. typos
+		{"J45.99", "Unknown ICD-10 code J45.99"},
+		{"Z99.9", "Unknown ICD-10 code Z99.9"},
+	}
+	for _, c := range cases {
+		var validationErr *ValidationError
+		err := lookupDiagnosis(&CodedDiagnosis{Code: c.code, Display: "Marfanov syndróm"})
+		if !errors.As(err, &validationErr) || validationErr.Message != c.message {
+			t.Errorf("code %q returned %v, want *ValidationError %q", c.code, err, c.message)
+		}
+	}
+}
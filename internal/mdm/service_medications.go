@@ -0,0 +1,330 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+)
+
+// Actions of the medication reconciliation, the action is kept as the status
+// of the reconciled medication
+const (
+	MedicationContinued = "Continued"
+	MedicationChanged   = "Changed"
+	MedicationStopped   = "Stopped"
+)
+
+// statuses of prescribed medications, the medication was not reconciled yet
+// when empty
+var medicationStatuses = map[string]bool{"": true, MedicationContinued: true, MedicationChanged: true, MedicationStopped: true}
+
+// ScheduleMedications assigns identifiers to the medications of the record
+// and validates their dates. The treatment starts on the day of the visit
+// unless given and ends after the textual duration counted from its start,
+// e.g. "7 dní" or "2 weeks", unless the end is given. Medications without
+// recognizable duration are long-term.
+func ScheduleMedications(record *MedicalRecord) error {
+	for i := range record.Medications {
+		medication := &record.Medications[i]
+		if medication.Id == "" {
+			medication.Id = uuid.NewString()
+		}
+		if !medicationStatuses[medication.Status] {
+			return &ValidationError{Message: fmt.Sprintf("Unknown status %s of medication %s", medication.Status, medication.Name)}
+		}
+
+		if medication.StartDate == "" {
+			medication.StartDate = record.DateOfVisit.Format(time.DateOnly)
+		}
+		start, err := time.Parse(time.DateOnly, medication.StartDate)
+		if err != nil {
+			return &ValidationError{Message: fmt.Sprintf("Invalid start date %s of medication %s", medication.StartDate, medication.Name)}
+		}
+		if medication.EndDate == "" {
+			if duration, ok := parseTreatmentDuration(medication.Duration); ok {
+				medication.EndDate = lastDayOfTreatment(start, duration)
+			}
+			continue
+		}
+		end, err := time.Parse(time.DateOnly, medication.EndDate)
+		if err != nil {
+			return &ValidationError{Message: fmt.Sprintf("Invalid end date %s of medication %s", medication.EndDate, medication.Name)}
+		}
+		if end.Before(start) {
+			return &ValidationError{Message: fmt.Sprintf("Medication %s ends before it starts", medication.Name)}
+		}
+	}
+	return nil
+}
+
+// GetPatientMedications lists medications prescribed to the patient in all
+// the medical records from the latest started
+func (s *MedicalRecordsService) GetPatientMedications(ctx context.Context, patientId string) ([]PatientMedication, error) {
+	records, err := s.GetPatientMedicalRecords(ctx, patientId)
+	if err != nil {
+		return nil, err
+	}
+	return PatientMedications(records, time.Now()), nil
+}
+
+// ReconcileMedications marks medications of former visits as continued,
+// changed or stopped at the visit of the record. Stopped and changed
+// medications end on the day of the visit, medications replacing the changed
+// ones are prescribed in the record starting that day. The reconciliations
+// are appended to the record, which is returned updated.
+//
+// The records are read and written in one transaction of the runner, so
+// either all the reconciled records and the record of the visit are updated
+// or none of them.
+func (s *MedicalRecordsService) ReconcileMedications(ctx context.Context, transactions db_service.TransactionRunner, patientId string, recordId string, reconciliations []MedicationReconciliation) (*MedicalRecord, error) {
+	if len(reconciliations) == 0 {
+		return nil, &ValidationError{Message: "At least one medication to reconcile is required"}
+	}
+
+	var record *MedicalRecord
+	err := transactions.WithTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, each attempt starts from the request
+		var err error
+		record, err = s.reconcileMedications(ctx, patientId, recordId, slices.Clone(reconciliations))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *MedicalRecordsService) reconcileMedications(ctx context.Context, patientId string, recordId string, reconciliations []MedicationReconciliation) (*MedicalRecord, error) {
+	records, err := s.GetPatientMedicalRecords(ctx, patientId)
+	if err != nil {
+		return nil, err
+	}
+	visit := -1
+	for i := range records {
+		if records[i].Id == recordId {
+			visit = i
+		}
+	}
+	if visit < 0 {
+		return nil, db_service.ErrNotFound
+	}
+	record := &records[visit]
+	visitDate := record.DateOfVisit.Format(time.DateOnly)
+
+	reconciled := make([]bool, len(records))
+	seen := map[string]bool{}
+	// medications replacing the changed ones by index of the reconciliation
+	replacements := map[int]int{}
+	for i := range reconciliations {
+		reconciliation := &reconciliations[i]
+		source, medication := findMedication(records, reconciliation.MedicationId)
+		switch {
+		case medication == nil:
+			return nil, &ValidationError{Message: fmt.Sprintf("Unknown medication %s", reconciliation.MedicationId)}
+		case seen[medication.Id]:
+			return nil, &ValidationError{Message: fmt.Sprintf("Medication %s is reconciled more than once", medication.Name)}
+		case source == visit:
+			return nil, &ValidationError{Message: fmt.Sprintf("Medication %s is prescribed at the reconciled visit", medication.Name)}
+		case medication.Status == MedicationChanged || medication.Status == MedicationStopped:
+			return nil, &ValidationError{Message: fmt.Sprintf("Medication %s is already %s", medication.Name, strings.ToLower(medication.Status))}
+		}
+		// medications prescribed before the dates were structured get them
+		medication.StartDate, medication.EndDate = medicationPeriod(records[source], *medication)
+		if medication.StartDate > visitDate {
+			return nil, &ValidationError{Message: fmt.Sprintf("Medication %s starts after the visit", medication.Name)}
+		}
+
+		switch reconciliation.Action {
+		case MedicationContinued:
+			reconciliation.Medication = nil
+		case MedicationStopped:
+			reconciliation.Medication = nil
+			medication.EndDate = visitDate
+		case MedicationChanged:
+			if reconciliation.Medication == nil {
+				return nil, &ValidationError{Message: fmt.Sprintf("Changed medication %s requires the medication replacing it", medication.Name)}
+			}
+			replacement := *reconciliation.Medication
+			if replacement.Name == "" {
+				replacement.Name = medication.Name
+				replacement.AtcCode = medication.AtcCode
+			}
+			replacement.Id = ""
+			replacement.Status = ""
+			replacements[i] = len(record.Medications)
+			record.Medications = append(record.Medications, replacement)
+			medication.EndDate = visitDate
+		default:
+			return nil, &ValidationError{Message: fmt.Sprintf("Unknown reconciliation action %s of medication %s", reconciliation.Action, medication.Name)}
+		}
+
+		medication.Status = reconciliation.Action
+		reconciliation.RecordId = records[source].Id
+		reconciliation.Name = medication.Name
+		reconciled[source] = true
+		seen[medication.Id] = true
+	}
+
+	if err := ScheduleMedications(record); err != nil {
+		return nil, err
+	}
+	if err := codeMedications(record.Medications); err != nil {
+		return nil, err
+	}
+	for i, index := range replacements {
+		replacement := record.Medications[index]
+		reconciliations[i].Medication = &replacement
+	}
+	if err := s.checkAllergies(ctx, patientId, record); err != nil {
+		return nil, err
+	}
+	record.Interactions = drugInteractions(record.Medications, activePrescriptions(records, recordId, time.Now()))
+	if len(record.Interactions) == 0 {
+		record.Interactions = nil
+	}
+	record.Reconciliations = append(record.Reconciliations, reconciliations...)
+
+	now := time.Now()
+	for i := range records {
+		if !reconciled[i] {
+			continue
+		}
+		records[i].UpdatedAt = now
+		updateCtx := withEvent(ctx, events.MedicalRecordUpdated, patientId, records[i].Id, &records[i])
+		if err := s.db.UpdateDocument(updateCtx, records[i].Id, &records[i]); err != nil {
+			return nil, err
+		}
+	}
+	record.UpdatedAt = now
+	ctx = withEvent(ctx, events.MedicalRecordUpdated, patientId, recordId, record)
+	if err := s.db.UpdateDocument(ctx, recordId, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// findMedication returns the index of the record prescribing the medication
+// and the medication itself, which is nil when there is no such medication
+func findMedication(records []MedicalRecord, medicationId string) (int, *Medication) {
+	for i := range records {
+		for j := range records[i].Medications {
+			if medicationId != "" && records[i].Medications[j].Id == medicationId {
+				return i, &records[i].Medications[j]
+			}
+		}
+	}
+	return -1, nil
+}
+
+// PatientMedications returns medications prescribed in the records with their
+// start and end dates from the latest started, marking the ones the patient
+// takes at the time
+func PatientMedications(records []MedicalRecord, now time.Time) []PatientMedication {
+	today := now.Format(time.DateOnly)
+	medications := []PatientMedication{}
+	for _, record := range records {
+		for _, medication := range record.Medications {
+			medication.StartDate, medication.EndDate = medicationPeriod(record, medication)
+			medications = append(medications, PatientMedication{
+				RecordId:    record.Id,
+				DateOfVisit: record.DateOfVisit,
+				Active:      isActiveMedication(medication, today),
+				Medication:  medication,
+			})
+		}
+	}
+	sort.SliceStable(medications, func(i, j int) bool {
+		return medications[i].Medication.StartDate > medications[j].Medication.StartDate
+	})
+	return medications
+}
+
+// ActiveMedications returns medications whose treatment has started and has
+// not ended yet and which were not stopped or changed at a later visit
+func ActiveMedications(records []MedicalRecord, now time.Time) []Medication {
+	active := []Medication{}
+	for _, prescribed := range activePrescriptions(records, "", now) {
+		active = append(active, prescribed.medication)
+	}
+	return active
+}
+
+// activePrescriptions returns medications the patient takes at the time,
+// except those of the record being updated
+func activePrescriptions(records []MedicalRecord, exceptRecordId string, now time.Time) []prescription {
+	today := now.Format(time.DateOnly)
+	var active []prescription
+	for _, record := range records {
+		if exceptRecordId != "" && record.Id == exceptRecordId {
+			continue
+		}
+		for _, medication := range record.Medications {
+			medication.StartDate, medication.EndDate = medicationPeriod(record, medication)
+			if isActiveMedication(medication, today) {
+				active = append(active, prescription{recordId: record.Id, medication: medication})
+			}
+		}
+	}
+	return active
+}
+
+func isActiveMedication(medication Medication, today string) bool {
+	if medication.Status == MedicationChanged || medication.Status == MedicationStopped {
+		return false
+	}
+	return medication.StartDate <= today && (medication.EndDate == "" || today <= medication.EndDate)
+}
+
+// medicationPeriod returns the first and the last day of the treatment, the
+// last day is empty for long-term treatment. Medications prescribed before
+// the dates were structured start on the day of the visit and end after their
+// textual duration.
+func medicationPeriod(record MedicalRecord, medication Medication) (string, string) {
+	if medication.StartDate != "" {
+		return medication.StartDate, medication.EndDate
+	}
+	if duration, ok := parseTreatmentDuration(medication.Duration); ok {
+		return record.DateOfVisit.Format(time.DateOnly), lastDayOfTreatment(record.DateOfVisit, duration)
+	}
+	return record.DateOfVisit.Format(time.DateOnly), ""
+}
+
+func lastDayOfTreatment(start time.Time, duration time.Duration) string {
+	return start.Add(duration).AddDate(0, 0, -1).Format(time.DateOnly)
+}
+
+var treatmentDurationPattern = regexp.MustCompile(`(\d+)\s*(\pL+)`)
+
+func parseTreatmentDuration(text string) (time.Duration, bool) {
+	match := treatmentDurationPattern.FindStringSubmatch(strings.ToLower(text))
+	if match == nil {
+		return 0, false
+	}
+	count, err := strconv.Atoi(match[1])
+	if err != nil || count == 0 {
+		return 0, false
+	}
+
+	day := 24 * time.Hour
+	unit := match[2]
+	switch {
+	case strings.HasPrefix(unit, "d"):
+		return time.Duration(count) * day, true
+	case strings.HasPrefix(unit, "t"), strings.HasPrefix(unit, "w"):
+		return time.Duration(count) * 7 * day, true
+	case strings.HasPrefix(unit, "m"):
+		return time.Duration(count) * 30 * day, true
+	case strings.HasPrefix(unit, "r"), strings.HasPrefix(unit, "y"):
+		return time.Duration(count) * 365 * day, true
+	}
+	return 0, false
+}
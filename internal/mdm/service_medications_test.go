@@ -0,0 +1,114 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type stagedKey struct{}
+
+// transactionalRecords keeps medical records in memory. Updates are staged in
+// the transaction of the context and applied when it commits, updates outside
+// of a transaction fail the test.
+type transactionalRecords struct {
+	db_service.DbService[MedicalRecord]
+	t       *testing.T
+	records map[string]MedicalRecord
+	failing string
+}
+
+func (r *transactionalRecords) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	staged := map[string]MedicalRecord{}
+	if err := fn(context.WithValue(ctx, stagedKey{}, staged)); err != nil {
+		return err
+	}
+	for id, record := range staged {
+		r.records[id] = record
+	}
+	return nil
+}
+
+func (r *transactionalRecords) FindDocumentsByCondition(ctx context.Context, filter bson.M) ([]MedicalRecord, error) {
+	records := []MedicalRecord{}
+	for _, record := range r.records {
+		record.Medications = append([]Medication(nil), record.Medications...)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (r *transactionalRecords) UpdateDocument(ctx context.Context, id string, document *MedicalRecord) error {
+	staged, ok := ctx.Value(stagedKey{}).(map[string]MedicalRecord)
+	if !ok {
+		r.t.Errorf("record %s updated outside of the transaction", id)
+		return errors.New("no transaction")
+	}
+	if id == r.failing {
+		return errors.New("write failed")
+	}
+	staged[id] = *document
+	return nil
+}
+
+type staticPatients struct {
+	db_service.DbService[Patient]
+}
+
+func (staticPatients) FindDocument(ctx context.Context, id string) (*Patient, error) {
+	return &Patient{Id: id}, nil
+}
+
+func newTransactionalRecords(t *testing.T) *transactionalRecords {
+	return &transactionalRecords{t: t, records: map[string]MedicalRecord{
+		"rec1": {
+			Id: "rec1", PatientId: "pat1", Diagnosis: "Lumbago",
+			DateOfVisit: time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+			Medications: []Medication{{Id: "med1", Name: "Ibuprofén", AtcCode: "M01AE01", StartDate: "2024-01-10"}},
+		},
+		"rec2": {
+			Id: "rec2", PatientId: "pat1", Diagnosis: "Kontrola",
+			DateOfVisit: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}}
+}
+
+func TestReconcileMedicationsInTransaction(t *testing.T) {
+	db := newTransactionalRecords(t)
+	service := NewMedicalRecordsService(db, staticPatients{})
+
+	record, err := service.ReconcileMedications(context.Background(), db, "pat1", "rec2",
+		[]MedicationReconciliation{{MedicationId: "med1", Action: MedicationStopped}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Reconciliations) != 1 || db.records["rec2"].Reconciliations == nil {
+		t.Fatalf("reconciliations of the visit %+v are not stored", record.Reconciliations)
+	}
+	stopped := db.records["rec1"].Medications[0]
+	if stopped.Status != MedicationStopped || stopped.EndDate != "2024-03-01" {
+		t.Fatalf("reconciled medication %+v is not stopped at the visit", stopped)
+	}
+}
+
+func TestReconcileMedicationsWritesNothingOnError(t *testing.T) {
+	db := newTransactionalRecords(t)
+	db.failing = "rec2"
+	service := NewMedicalRecordsService(db, staticPatients{})
+
+	_, err := service.ReconcileMedications(context.Background(), db, "pat1", "rec2",
+		[]MedicationReconciliation{{MedicationId: "med1", Action: MedicationStopped}})
+	if err == nil || err.Error() != "write failed" {
+		t.Fatalf("error %v, want the failed write", err)
+	}
+	if medication := db.records["rec1"].Medications[0]; medication.Status != "" || medication.EndDate != "" {
+		t.Fatalf("medication %+v of the former visit changed by failed reconciliation", medication)
+	}
+	if db.records["rec2"].Reconciliations != nil {
+		t.Fatal("record of the visit changed by failed reconciliation")
+	}
+}
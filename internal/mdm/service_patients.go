@@ -0,0 +1,129 @@
+package mdm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ValidationError reports input not satisfying requirements of the API
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+var ErrIdMismatch = fmt.Errorf("ID in path and request body do not match")
+
+// PatientIndexes are indexes of the patients collection. The insurance
+// number identifies the patient, patients imported without it are allowed.
+var PatientIndexes = []db_service.Index{
+	{Name: "id", Keys: []string{"id"}, Unique: true},
+	{
+		Name:          "insurancenumber",
+		Keys:          []string{"insurancenumber"},
+		Unique:        true,
+		PartialFilter: bson.D{{Key: "insurancenumber", Value: bson.D{{Key: "$gt", Value: ""}}}},
+	},
+}
+
+// conflictMessages describe conflicts with existing documents by the field
+// of the violated unique index
+var conflictMessages = map[string]string{
+	"insurancenumber": "Insurance number already registered",
+}
+
+// ConflictMessage describes the conflict of the document with an existing
+// one, the message is used for conflicts of the document id
+func ConflictMessage(err error, message string) string {
+	var conflictErr *db_service.ConflictError
+	if errors.As(err, &conflictErr) {
+		if fieldMessage, ok := conflictMessages[conflictErr.Field()]; ok {
+			return fieldMessage
+		}
+	}
+	return message
+}
+
+// LogValue limits logged patient to the attributes without personal data
+func (p Patient) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("id", p.Id), slog.String("status", p.Status))
+}
+
+// PatientsService implements validation and persistence of patients shared
+// by all the APIs exposing them
+type PatientsService struct {
+	db db_service.DbService[Patient]
+}
+
+func NewPatientsService(db db_service.DbService[Patient]) *PatientsService {
+	return &PatientsService{db: db}
+}
+
+func (s *PatientsService) CreatePatient(ctx context.Context, patient *Patient) error {
+	if patient.FirstName == "" || patient.LastName == "" ||
+		patient.DateOfBirth == "" || patient.Gender == "" ||
+		patient.InsuranceNumber == "" {
+		return &ValidationError{Message: "Missing required fields"}
+	}
+	if err := validateAllergies(patient.Allergies); err != nil {
+		return err
+	}
+
+	if patient.Id == "" || patient.Id == "@new" {
+		patient.Id = uuid.NewString()
+	}
+
+	now := time.Now()
+	patient.CreatedAt = now
+	patient.UpdatedAt = now
+
+	ctx = withEvent(ctx, events.PatientCreated, patient.Id, "", patient)
+	return s.db.CreateDocument(ctx, patient.Id, patient)
+}
+
+func (s *PatientsService) GetAllPatients(ctx context.Context) ([]Patient, error) {
+	return s.db.FindAllDocuments(ctx)
+}
+
+func (s *PatientsService) GetPatient(ctx context.Context, patientId string) (*Patient, error) {
+	return s.db.FindDocument(ctx, patientId)
+}
+
+func (s *PatientsService) UpdatePatient(ctx context.Context, patientId string, patient *Patient) error {
+	if patient.Id != "" && patient.Id != patientId {
+		return ErrIdMismatch
+	}
+	if err := validateAllergies(patient.Allergies); err != nil {
+		return err
+	}
+
+	patient.Id = patientId
+	patient.UpdatedAt = time.Now()
+
+	previousPatient, err := s.db.FindDocument(ctx, patientId)
+	if err != nil {
+		return err
+	}
+
+	ctx = withEvent(ctx, events.PatientUpdated, patientId, "", patient)
+	if patient.Status != previousPatient.Status && notifiedStatuses[patient.Status] {
+		ctx = withEvent(ctx, events.PatientStatusChanged, patientId, "", patient)
+	}
+
+	return s.db.UpdateDocument(ctx, patientId, patient)
+}
+
+func (s *PatientsService) DeletePatient(ctx context.Context, patientId string) error {
+	ctx = withEvent(ctx, events.PatientDeleted, patientId, "", nil)
+	return s.db.DeleteDocument(ctx, patientId)
+}
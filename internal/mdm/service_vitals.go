@@ -0,0 +1,282 @@
+package mdm
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Kinds of vital signs
+const (
+	VitalSystolicPressure  = "systolicPressure"
+	VitalDiastolicPressure = "diastolicPressure"
+	VitalHeartRate         = "heartRate"
+	VitalTemperature       = "temperature"
+	VitalSpO2              = "spo2"
+	VitalWeight            = "weight"
+	VitalRespiratoryRate   = "respiratoryRate"
+	// level of consciousness on the ACVPU scale
+	VitalConsciousness = "consciousness"
+	// flow of supplemental oxygen, zero when breathing air
+	VitalSupplementalOxygen = "supplementalOxygen"
+)
+
+// Levels of consciousness on the ACVPU scale, the values of consciousness
+// vital signs
+const (
+	ConsciousnessAlert        = 0
+	ConsciousnessConfusion    = 1
+	ConsciousnessVoice        = 2
+	ConsciousnessPain         = 3
+	ConsciousnessUnresponsive = 4
+)
+
+const (
+	// MaxVitalsBatch limits the number of vital signs recorded at once
+	MaxVitalsBatch = 1000
+	// MaxVitals limits the number of vital signs listed as measured, longer
+	// ranges are downsampled
+	MaxVitals = 5000
+	// MaxVitalsPoints limits the number of intervals of downsampled vital signs
+	// of each kind
+	MaxVitalsPoints = 1000
+	// MinVitalsInterval is the shortest interval of downsampled vital signs
+	MinVitalsInterval = time.Minute
+	// tolerated skew of clocks of the devices measuring the vital signs
+	vitalsClockSkew = 5 * time.Minute
+)
+
+// VitalSignSeries names the fields of the vital signs stored in the vitals
+// time-series collection, which is created by the migrations
+var VitalSignSeries = db_service.TimeSeries{
+	TimeField:   "measuredat",
+	MetaField:   "patientid",
+	GroupField:  "type",
+	ValueField:  "value",
+	Granularity: "minutes",
+}
+
+// vitalSignType is the standard unit of a kind of vital sign with the range
+// of plausible values in that unit and conversions from other accepted units.
+// Values of ordinal scales are integers.
+type vitalSignType struct {
+	unit    string
+	min     float64
+	max     float64
+	integer bool
+	units   map[string]func(float64) float64
+}
+
+func sameValue(value float64) float64 {
+	return value
+}
+
+var vitalSignTypes = map[string]vitalSignType{
+	VitalSystolicPressure: {unit: "mmHg", min: 40, max: 300, units: map[string]func(float64) float64{
+		"kpa": func(value float64) float64 { return value * 7.50062 },
+	}},
+	VitalDiastolicPressure: {unit: "mmHg", min: 20, max: 200, units: map[string]func(float64) float64{
+		"kpa": func(value float64) float64 { return value * 7.50062 },
+	}},
+	VitalHeartRate: {unit: "/min", min: 20, max: 300, units: map[string]func(float64) float64{
+		"bpm": sameValue,
+	}},
+	VitalTemperature: {unit: "°C", min: 25, max: 45, units: map[string]func(float64) float64{
+		"°c": sameValue,
+		"c":  sameValue,
+		"°f": func(value float64) float64 { return (value - 32) * 5 / 9 },
+		"f":  func(value float64) float64 { return (value - 32) * 5 / 9 },
+	}},
+	VitalSpO2: {unit: "%", min: 50, max: 100},
+	VitalWeight: {unit: "kg", min: 0.2, max: 500, units: map[string]func(float64) float64{
+		"g":  func(value float64) float64 { return value / 1000 },
+		"lb": func(value float64) float64 { return value * 0.45359237 },
+	}},
+	VitalRespiratoryRate: {unit: "/min", min: 2, max: 80, units: map[string]func(float64) float64{
+		"breaths/min": sameValue,
+	}},
+	VitalConsciousness:      {unit: "ACVPU", min: ConsciousnessAlert, max: ConsciousnessUnresponsive, integer: true},
+	VitalSupplementalOxygen: {unit: "L/min", min: 0, max: 60},
+}
+
+// LogValue limits logged vital sign to its patient and kind
+func (v VitalSign) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("patientId", v.PatientId), slog.String("type", v.Type))
+}
+
+// VitalsService validates vital signs of the patients and stores them in the
+// time-series collection. The latest vital signs are scored by NEWS2 to
+// detect deterioration of the patients.
+type VitalsService struct {
+	db           db_service.TimeSeriesService[VitalSign]
+	patients     db_service.DbService[Patient]
+	earlyWarning EarlyWarningConfig
+}
+
+func NewVitalsService(db db_service.TimeSeriesService[VitalSign], patients db_service.DbService[Patient], earlyWarning EarlyWarningConfig) *VitalsService {
+	return &VitalsService{db: db, patients: patients, earlyWarning: earlyWarning}
+}
+
+// RecordVitals stores the batch of vital signs of the existing patient. The
+// values are converted to the standard units of their kinds. The
+// patient.early-warning event is emitted when the vital signs raise the early
+// warning score of the patient to the threshold.
+func (s *VitalsService) RecordVitals(ctx context.Context, patientId string, vitals []VitalSign) error {
+	if len(vitals) == 0 {
+		return &ValidationError{Message: "At least one vital sign is required"}
+	}
+	if len(vitals) > MaxVitalsBatch {
+		return &ValidationError{Message: fmt.Sprintf("At most %d vital signs can be recorded at once", MaxVitalsBatch)}
+	}
+
+	now := time.Now()
+	for i := range vitals {
+		if err := NormalizeVitalSign(&vitals[i], now); err != nil {
+			return &ValidationError{Message: fmt.Sprintf("Vital sign %d: %s", i, err.Error())}
+		}
+		vitals[i].PatientId = patientId
+	}
+
+	patient, err := s.patients.FindDocument(ctx, patientId)
+	if err != nil {
+		return err
+	}
+	ctx, err = s.earlyWarningEvent(ctx, *patient, vitals)
+	if err != nil {
+		return err
+	}
+	return s.db.InsertMeasurements(ctx, vitals)
+}
+
+// GetVitals lists vital signs of the patient of the given kinds, all kinds
+// when none are given, measured within the range from the oldest
+func (s *VitalsService) GetVitals(ctx context.Context, patientId string, types []string, from time.Time, to time.Time) ([]VitalSign, error) {
+	filter, err := vitalsFilter(patientId, types, from, to)
+	if err != nil {
+		return nil, err
+	}
+	vitals, err := s.db.FindMeasurements(ctx, filter, from, to, MaxVitals+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(vitals) > MaxVitals {
+		return nil, &ValidationError{Message: fmt.Sprintf("More than %d vital signs were measured within the range, downsample them to intervals", MaxVitals)}
+	}
+	return vitals, nil
+}
+
+// SummarizeVitals downsamples vital signs of the patient of the given kinds
+// measured within the range to intervals, e.g. for charts. Intervals without
+// any vital sign are omitted.
+func (s *VitalsService) SummarizeVitals(ctx context.Context, patientId string, types []string, from time.Time, to time.Time, interval time.Duration) ([]VitalSignSummary, error) {
+	filter, err := vitalsFilter(patientId, types, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if interval < MinVitalsInterval {
+		return nil, &ValidationError{Message: fmt.Sprintf("Interval must be at least %s", MinVitalsInterval)}
+	}
+	if to.Sub(from)/interval > MaxVitalsPoints {
+		return nil, &ValidationError{Message: fmt.Sprintf("Range can be downsampled to at most %d intervals", MaxVitalsPoints)}
+	}
+
+	buckets, err := s.db.Downsample(ctx, filter, from, to, interval)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]VitalSignSummary, 0, len(buckets))
+	for _, bucket := range buckets {
+		summaries = append(summaries, VitalSignSummary{
+			Type:    bucket.Group,
+			Unit:    vitalSignTypes[bucket.Group].unit,
+			Start:   bucket.Start,
+			Count:   bucket.Count,
+			Average: bucket.Average,
+			Min:     bucket.Min,
+			Max:     bucket.Max,
+		})
+	}
+	return summaries, nil
+}
+
+// VitalsInterval returns the interval downsampling the range to at most the
+// given number of points, rounded up to whole minutes
+func VitalsInterval(from time.Time, to time.Time, points int) time.Duration {
+	if points <= 0 {
+		points = 1
+	}
+	interval := (to.Sub(from) + time.Duration(points) - 1) / time.Duration(points)
+	interval = (interval + MinVitalsInterval - 1).Truncate(MinVitalsInterval)
+	if interval < MinVitalsInterval {
+		return MinVitalsInterval
+	}
+	return interval
+}
+
+// NormalizeVitalSign validates the kind, the time and the value of the vital
+// sign and converts the value to the standard unit of its kind
+func NormalizeVitalSign(vital *VitalSign, now time.Time) error {
+	vitalType, ok := vitalSignTypes[vital.Type]
+	if !ok {
+		return fmt.Errorf("unknown type %s, expected one of %s", vital.Type, strings.Join(VitalSignTypes(), ", "))
+	}
+	if vital.MeasuredAt.IsZero() {
+		return fmt.Errorf("measuredAt is required")
+	}
+	if vital.MeasuredAt.After(now.Add(vitalsClockSkew)) {
+		return fmt.Errorf("measuredAt is in the future")
+	}
+	// the database keeps milliseconds
+	vital.MeasuredAt = vital.MeasuredAt.UTC().Truncate(time.Millisecond)
+
+	if vital.Unit != "" && vital.Unit != vitalType.unit {
+		convert, ok := vitalType.units[strings.ToLower(vital.Unit)]
+		if !ok {
+			return fmt.Errorf("unit %s of %s is not supported, expected %s", vital.Unit, vital.Type, vitalType.unit)
+		}
+		vital.Value = convert(vital.Value)
+	}
+	vital.Unit = vitalType.unit
+
+	if vitalType.integer && vital.Value != math.Trunc(vital.Value) {
+		return fmt.Errorf("%s %g is not an integer", vital.Type, vital.Value)
+	}
+	if vital.Value < vitalType.min || vital.Value > vitalType.max {
+		return fmt.Errorf("%s %g %s is out of range %g-%g %s", vital.Type, vital.Value, vital.Unit, vitalType.min, vitalType.max, vital.Unit)
+	}
+	return nil
+}
+
+// VitalSignTypes returns the known kinds of vital signs in alphabetical order
+func VitalSignTypes() []string {
+	types := make([]string, 0, len(vitalSignTypes))
+	for vitalType := range vitalSignTypes {
+		types = append(types, vitalType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func vitalsFilter(patientId string, types []string, from time.Time, to time.Time) (bson.M, error) {
+	if !from.Before(to) {
+		return nil, &ValidationError{Message: "Range must start before it ends"}
+	}
+	filter := bson.M{"patientid": patientId}
+	if len(types) == 0 {
+		return filter, nil
+	}
+	for _, vitalType := range types {
+		if _, ok := vitalSignTypes[vitalType]; !ok {
+			return nil, &ValidationError{Message: fmt.Sprintf("Unknown type %s of vital sign", vitalType)}
+		}
+	}
+	filter["type"] = bson.M{"$in": types}
+	return filter, nil
+}
@@ -0,0 +1,118 @@
+package mdm
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNormalizeVitalSign(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name       string
+		vital      VitalSign
+		value      float64
+		unit       string
+		measuredAt time.Time
+		err        string
+	}{
+		{"standard unit", VitalSign{Type: VitalHeartRate, Value: 72, MeasuredAt: now}, 72, "/min", now, ""},
+		{"omitted unit", VitalSign{Type: VitalSpO2, Value: 97, MeasuredAt: now}, 97, "%", now, ""},
+		{"alias of standard unit", VitalSign{Type: VitalHeartRate, Value: 72, Unit: "BPM", MeasuredAt: now}, 72, "/min", now, ""},
+		{"fahrenheit", VitalSign{Type: VitalTemperature, Value: 98.6, Unit: "°F", MeasuredAt: now}, 37, "°C", now, ""},
+		{"fahrenheit without degree", VitalSign{Type: VitalTemperature, Value: 104, Unit: "F", MeasuredAt: now}, 40, "°C", now, ""},
+		{"kilopascals", VitalSign{Type: VitalSystolicPressure, Value: 16, Unit: "kPa", MeasuredAt: now}, 120.00992, "mmHg", now, ""},
+		{"pounds", VitalSign{Type: VitalWeight, Value: 154, Unit: "lb", MeasuredAt: now}, 69.85322498, "kg", now, ""},
+		{"ACVPU", VitalSign{Type: VitalConsciousness, Value: ConsciousnessVoice, MeasuredAt: now}, ConsciousnessVoice, "ACVPU", now, ""},
+		{"time in another zone", VitalSign{Type: VitalHeartRate, Value: 72, MeasuredAt: now.Add(1500 * time.Microsecond).In(time.FixedZone("CET", 3600))},
+			72, "/min", now.Add(time.Millisecond), ""},
+		{"within clock skew", VitalSign{Type: VitalHeartRate, Value: 72, MeasuredAt: now.Add(5 * time.Minute)}, 72, "/min", now.Add(5 * time.Minute), ""},
+		{"beyond clock skew", VitalSign{Type: VitalHeartRate, Value: 72, MeasuredAt: now.Add(5*time.Minute + time.Second)}, 0, "", time.Time{}, "measuredAt is in the future"},
+		{"missing time", VitalSign{Type: VitalHeartRate, Value: 72}, 0, "", time.Time{}, "measuredAt is required"},
+		{"unknown type", VitalSign{Type: "bloodSugar", Value: 5, MeasuredAt: now}, 0, "", time.Time{},
+			"unknown type bloodSugar, expected one of consciousness, diastolicPressure, heartRate, respiratoryRate, spo2, supplementalOxygen, systolicPressure, temperature, weight"},
+		{"unsupported unit", VitalSign{Type: VitalTemperature, Value: 310, Unit: "K", MeasuredAt: now}, 0, "", time.Time{}, "unit K of temperature is not supported, expected °C"},
+		{"fractional ACVPU", VitalSign{Type: VitalConsciousness, Value: 1.5, MeasuredAt: now}, 0, "", time.Time{}, "consciousness 1.5 is not an integer"},
+		{"ACVPU out of range", VitalSign{Type: VitalConsciousness, Value: 5, MeasuredAt: now}, 0, "", time.Time{}, "consciousness 5 ACVPU is out of range 0-4 ACVPU"},
+		{"out of range after conversion", VitalSign{Type: VitalTemperature, Value: 122, Unit: "°F", MeasuredAt: now}, 0, "", time.Time{},
+			"temperature 50 °C is out of range 25-45 °C"},
+	}
+	for _, c := range cases {
+		vital := c.vital
+		err := NormalizeVitalSign(&vital, now)
+		if c.err != "" {
+			if err == nil || err.Error() != c.err {
+				t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if math.Abs(vital.Value-c.value) > 1e-6 || vital.Unit != c.unit || !vital.MeasuredAt.Equal(c.measuredAt) || vital.MeasuredAt.Location() != time.UTC {
+			t.Errorf("%s: normalized %+v, want %g %s at %v UTC", c.name, vital, c.value, c.unit, c.measuredAt)
+		}
+	}
+}
+
+func TestVitalsInterval(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		length   time.Duration
+		points   int
+		interval time.Duration
+	}{
+		{24 * time.Hour, 1000, 2 * time.Minute},
+		{24 * time.Hour, 24, time.Hour},
+		{24*time.Hour + time.Second, 24, 61 * time.Minute},
+		{7 * 24 * time.Hour, 1000, 11 * time.Minute},
+		{time.Hour, 1000, MinVitalsInterval},
+		{time.Second, 1, MinVitalsInterval},
+		{90 * time.Second, 1, 2 * time.Minute},
+		{10 * time.Minute, 0, 10 * time.Minute},
+		{10 * time.Minute, -5, 10 * time.Minute},
+	}
+	for _, c := range cases {
+		if got := VitalsInterval(from, from.Add(c.length), c.points); got != c.interval {
+			t.Errorf("VitalsInterval(%v, %d) = %v, want %v", c.length, c.points, got, c.interval)
+		}
+	}
+}
+
+func TestVitalsFilter(t *testing.T) {
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	cases := []struct {
+		name   string
+		types  []string
+		from   time.Time
+		to     time.Time
+		filter bson.M
+		err    string
+	}{
+		{"all types", nil, from, to, bson.M{"patientid": "pat1"}, ""},
+		{"selected types", []string{VitalHeartRate, VitalSpO2}, from, to,
+			bson.M{"patientid": "pat1", "type": bson.M{"$in": []string{VitalHeartRate, VitalSpO2}}}, ""},
+		{"unknown type", []string{VitalHeartRate, "pulse"}, from, to, nil, "Unknown type pulse of vital sign"},
+		{"empty range", nil, from, from, nil, "Range must start before it ends"},
+		{"reversed range", nil, to, from, nil, "Range must start before it ends"},
+	}
+	for _, c := range cases {
+		filter, err := vitalsFilter("pat1", c.types, c.from, c.to)
+		if c.err != "" {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Message != c.err {
+				t.Errorf("%s: error %v, want *ValidationError %q", c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(filter, c.filter) {
+			t.Errorf("%s: filter %v, %v, want %v", c.name, filter, err, c.filter)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	dbOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "mdm",
+		Subsystem: "db",
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of database operations by collection and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"collection", "operation"})
+
+	dbOperationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "mdm",
+		Subsystem: "db",
+		Name:      "operation_errors_total",
+		Help:      "Number of failed database operations by collection, operation and kind of error.",
+	}, []string{"collection", "operation", "error"})
+)
+
+// dbService records latency and errors of operations of the wrapped service
+type dbService[DocType interface{}] struct {
+	collection string
+	inner      db_service.DbService[DocType]
+}
+
+// WrapDbService returns DbService recording metrics of the operations made
+// on the collection by the inner service
+func WrapDbService[DocType interface{}](collection string, inner db_service.DbService[DocType]) db_service.DbService[DocType] {
+	return &dbService[DocType]{collection: collection, inner: inner}
+}
+
+func (s *dbService[DocType]) observe(operation string, started time.Time, err error) {
+	observeDbOperation(s.collection, operation, started, err)
+}
+
+func observeDbOperation(collection string, operation string, started time.Time, err error) {
+	dbOperationDuration.WithLabelValues(collection, operation).Observe(time.Since(started).Seconds())
+	switch {
+	case err == nil:
+	case err == db_service.ErrNotFound:
+		dbOperationErrors.WithLabelValues(collection, operation, "not_found").Inc()
+	case errors.Is(err, db_service.ErrConflict):
+		dbOperationErrors.WithLabelValues(collection, operation, "conflict").Inc()
+	case errors.Is(err, db_service.ErrUnavailable):
+		dbOperationErrors.WithLabelValues(collection, operation, "unavailable").Inc()
+	default:
+		dbOperationErrors.WithLabelValues(collection, operation, "other").Inc()
+	}
+}
+
+func (s *dbService[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	started := time.Now()
+	err := s.inner.CreateDocument(ctx, id, document)
+	s.observe("create", started, err)
+	return err
+}
+
+func (s *dbService[DocType]) FindAllDocuments(ctx context.Context) ([]DocType, error) {
+	started := time.Now()
+	documents, err := s.inner.FindAllDocuments(ctx)
+	s.observe("find_all", started, err)
+	return documents, err
+}
+
+func (s *dbService[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
+	started := time.Now()
+	document, err := s.inner.FindDocument(ctx, id)
+	s.observe("find", started, err)
+	return document, err
+}
+
+func (s *dbService[DocType]) FindDocumentsByCondition(ctx context.Context, filter bson.M) ([]DocType, error) {
+	started := time.Now()
+	documents, err := s.inner.FindDocumentsByCondition(ctx, filter)
+	s.observe("find_by_condition", started, err)
+	return documents, err
+}
+
+func (s *dbService[DocType]) CountDocumentsByField(ctx context.Context, field string) (map[string]int, error) {
+	started := time.Now()
+	counts, err := s.inner.CountDocumentsByField(ctx, field)
+	s.observe("count_by_field", started, err)
+	return counts, err
+}
+
+func (s *dbService[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	started := time.Now()
+	err := s.inner.UpdateDocument(ctx, id, document)
+	s.observe("update", started, err)
+	return err
+}
+
+func (s *dbService[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	started := time.Now()
+	err := s.inner.DeleteDocument(ctx, id)
+	s.observe("delete", started, err)
+	return err
+}
+
+func (s *dbService[DocType]) WatchInsertedDocuments(ctx context.Context) (<-chan DocType, error) {
+	return s.inner.WatchInsertedDocuments(ctx)
+}
+
+func (s *dbService[DocType]) EnsureIndexes(ctx context.Context, indexes ...db_service.Index) error {
+	started := time.Now()
+	err := s.inner.EnsureIndexes(ctx, indexes...)
+	s.observe("ensure_indexes", started, err)
+	return err
+}
+
+func (s *dbService[DocType]) Ping(ctx context.Context) error {
+	started := time.Now()
+	err := s.inner.Ping(ctx)
+	s.observe("ping", started, err)
+	return err
+}
+
+func (s *dbService[DocType]) Disconnect(ctx context.Context) error {
+	return s.inner.Disconnect(ctx)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// leaseService records latency and errors of operations of the wrapped
+// service
+type leaseService[DocType interface{}] struct {
+	db_service.DbService[DocType]
+	collection string
+	inner      db_service.LeaseService[DocType]
+}
+
+// WrapLeaseService returns LeaseService recording metrics of the operations
+// made on the collection by the inner service
+func WrapLeaseService[DocType interface{}](collection string, inner db_service.LeaseService[DocType]) db_service.LeaseService[DocType] {
+	return &leaseService[DocType]{
+		DbService:  WrapDbService[DocType](collection, inner),
+		collection: collection,
+		inner:      inner,
+	}
+}
+
+func (s *leaseService[DocType]) ClaimDocuments(ctx context.Context, filter bson.M, owner string, limit int, lease time.Duration) ([]DocType, error) {
+	started := time.Now()
+	documents, err := s.inner.ClaimDocuments(ctx, filter, owner, limit, lease)
+	observeDbOperation(s.collection, "claim", started, err)
+	return documents, err
+}
+
+func (s *leaseService[DocType]) ReleaseDocument(ctx context.Context, owner string, id string, document *DocType) error {
+	started := time.Now()
+	err := s.inner.ReleaseDocument(ctx, owner, id, document)
+	observeDbOperation(s.collection, "release", started, err)
+	return err
+}
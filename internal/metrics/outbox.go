@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+)
+
+// outboxService records latency and errors of operations of the wrapped
+// service
+type outboxService struct {
+	db_service.DbService[db_service.OutboxMessage]
+	collection string
+	inner      db_service.OutboxService
+}
+
+// WrapOutboxService returns OutboxService recording metrics of the operations
+// made on the collection by the inner service
+func WrapOutboxService(collection string, inner db_service.OutboxService) db_service.OutboxService {
+	return &outboxService{
+		DbService:  WrapDbService[db_service.OutboxMessage](collection, inner),
+		collection: collection,
+		inner:      inner,
+	}
+}
+
+func (s *outboxService) ClaimMessages(ctx context.Context, owner string, limit int, lease time.Duration) ([]db_service.OutboxMessage, error) {
+	started := time.Now()
+	messages, err := s.inner.ClaimMessages(ctx, owner, limit, lease)
+	observeDbOperation(s.collection, "claim", started, err)
+	return messages, err
+}
+
+func (s *outboxService) ReleaseMessage(ctx context.Context, owner string, message *db_service.OutboxMessage) error {
+	started := time.Now()
+	err := s.inner.ReleaseMessage(ctx, owner, message)
+	observeDbOperation(s.collection, "release", started, err)
+	return err
+}
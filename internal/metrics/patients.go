@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// otherStatus reports patients without status or with a status other than
+// mdm.PatientStatuses, which keeps the number of series bounded whatever is
+// stored. The known statuses are always reported, so that a status dropping
+// to zero patients is visible.
+const otherStatus = "other"
+
+var patientsByStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "mdm",
+	Name:      "patients",
+	Help:      "Number of patients by status.",
+}, []string{"status"})
+
+// PatientsGauge periodically counts patients by status. Counting on every
+// scrape would load the database proportionally to the number of scrapers.
+type PatientsGauge struct {
+	db       db_service.DbService[mdm.Patient]
+	interval time.Duration
+}
+
+func NewPatientsGauge(db db_service.DbService[mdm.Patient], interval time.Duration) *PatientsGauge {
+	if interval == 0 {
+		interval = 30 * time.Second
+	}
+	return &PatientsGauge{db: db, interval: interval}
+}
+
+// Run refreshes the gauge until the context is cancelled
+func (g *PatientsGauge) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	for {
+		if err := g.refresh(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to count patients by status", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (g *PatientsGauge) refresh(ctx context.Context) error {
+	counts, err := g.db.CountDocumentsByField(ctx, "status")
+	if err != nil {
+		return err
+	}
+	for status, count := range statusCounts(counts) {
+		patientsByStatus.WithLabelValues(status).Set(float64(count))
+	}
+	return nil
+}
+
+// statusCounts maps the counts of the stored statuses onto the statuses of
+// the schema and otherStatus
+func statusCounts(stored map[string]int) map[string]int {
+	counts := map[string]int{otherStatus: 0}
+	for _, status := range mdm.PatientStatuses {
+		counts[status] = 0
+	}
+	for status, count := range stored {
+		if _, known := counts[status]; !known {
+			status = otherStatus
+		}
+		counts[status] += count
+	}
+	return counts
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestStatusCounts(t *testing.T) {
+	counts := statusCounts(map[string]int{
+		"Stable":   3,
+		"Critical": 1,
+		"":         2,
+		"stable":   1,
+		"Deceased": 4,
+	})
+
+	want := map[string]int{
+		"Stable":     3,
+		"Critical":   1,
+		"Recovering": 0,
+		"Discharged": 0,
+		"other":      7,
+	}
+	if !maps.Equal(counts, want) {
+		t.Fatalf("counts %v, want %v", counts, want)
+	}
+}
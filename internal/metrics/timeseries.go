@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// timeSeriesService records latency and errors of operations of the wrapped
+// service
+type timeSeriesService[DocType interface{}] struct {
+	collection string
+	inner      db_service.TimeSeriesService[DocType]
+}
+
+// WrapTimeSeriesService returns TimeSeriesService recording metrics of the
+// operations made on the collection by the inner service
+func WrapTimeSeriesService[DocType interface{}](collection string, inner db_service.TimeSeriesService[DocType]) db_service.TimeSeriesService[DocType] {
+	return &timeSeriesService[DocType]{collection: collection, inner: inner}
+}
+
+func (s *timeSeriesService[DocType]) InsertMeasurements(ctx context.Context, measurements []DocType) error {
+	started := time.Now()
+	err := s.inner.InsertMeasurements(ctx, measurements)
+	observeDbOperation(s.collection, "insert_measurements", started, err)
+	return err
+}
+
+func (s *timeSeriesService[DocType]) FindMeasurements(ctx context.Context, filter bson.M, from time.Time, to time.Time, limit int) ([]DocType, error) {
+	started := time.Now()
+	measurements, err := s.inner.FindMeasurements(ctx, filter, from, to, limit)
+	observeDbOperation(s.collection, "find_measurements", started, err)
+	return measurements, err
+}
+
+func (s *timeSeriesService[DocType]) Downsample(ctx context.Context, filter bson.M, from time.Time, to time.Time, interval time.Duration) ([]db_service.Bucket, error) {
+	started := time.Now()
+	buckets, err := s.inner.Downsample(ctx, filter, from, to, interval)
+	observeDbOperation(s.collection, "downsample", started, err)
+	return buckets, err
+}
+
+func (s *timeSeriesService[DocType]) Latest(ctx context.Context, filter bson.M, since time.Time) ([]DocType, error) {
+	started := time.Now()
+	documents, err := s.inner.Latest(ctx, filter, since)
+	observeDbOperation(s.collection, "latest", started, err)
+	return documents, err
+}
+
+func (s *timeSeriesService[DocType]) Ping(ctx context.Context) error {
+	started := time.Now()
+	err := s.inner.Ping(ctx)
+	observeDbOperation(s.collection, "ping", started, err)
+	return err
+}
+
+func (s *timeSeriesService[DocType]) Disconnect(ctx context.Context) error {
+	return s.inner.Disconnect(ctx)
+}
@@ -0,0 +1,336 @@
+// Package migrations applies versioned changes of the database schema, e.g.
+// collections and indexes, and records them in the schema_migrations
+// collection, so every change is applied exactly once.
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// MigrationsCollection records applied migrations
+	MigrationsCollection = "schema_migrations"
+	// LockCollection holds the lock of the running migration
+	LockCollection = "schema_migrations_lock"
+)
+
+// Migration changes the schema of the database. Versions are applied in
+// ascending order and must never be changed once released, later changes
+// are new migrations.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	// reverts Up, nil when the migration cannot be reverted
+	Down func(ctx context.Context, db *mongo.Database) error
+}
+
+// Status of a migration known to the binary or found in the database
+type Status struct {
+	Version     int        `json:"version"`
+	Description string     `json:"description"`
+	AppliedAt   *time.Time `json:"appliedAt,omitempty"`
+	// applied by a newer version of the service
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+type appliedMigration struct {
+	Version     int
+	Description string
+	AppliedAt   time.Time
+}
+
+type migrationLock struct {
+	Id        string `bson:"_id"`
+	Owner     string
+	LockedAt  time.Time
+	ExpiresAt time.Time
+}
+
+type Config struct {
+	// time after which the lock of a crashed run is taken over, the lock of
+	// a run in progress is renewed every third of the time
+	LockTTL time.Duration
+	// interval of checking the lock held by another run
+	LockPollInterval time.Duration
+}
+
+// Migrator applies the migrations. Runs of several replicas starting at once
+// are serialized by a lock, the later runs find the migrations applied.
+type Migrator struct {
+	Config
+	client     *db_service.MongoClient
+	migrations []Migration
+	owner      string
+}
+
+func NewMigrator(client *db_service.MongoClient, config Config, migrations ...Migration) (*Migrator, error) {
+	m := &Migrator{Config: config, client: client}
+	if m.LockTTL == 0 {
+		m.LockTTL = 5 * time.Minute
+	}
+	if m.LockPollInterval == 0 {
+		m.LockPollInterval = time.Second
+	}
+
+	m.migrations = slices.Clone(migrations)
+	slices.SortFunc(m.migrations, func(a, b Migration) int { return a.Version - b.Version })
+	for i, migration := range m.migrations {
+		if migration.Version <= 0 || migration.Up == nil {
+			return nil, fmt.Errorf("migration %d: version must be positive and Up must be set", migration.Version)
+		}
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return nil, fmt.Errorf("migration %d is defined twice", migration.Version)
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	m.owner = fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), uuid.NewString()[:8])
+	return m, nil
+}
+
+// Status lists the known migrations and those applied by newer versions
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	db, err := m.client.Database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, Status{
+			Version:     record.Version,
+			Description: record.Description,
+			AppliedAt:   &record.AppliedAt,
+			Unknown:     true,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b Status) int { return a.Version - b.Version })
+	return statuses, nil
+}
+
+// Up applies pending migrations up to the target version, all of them when
+// the target is 0, and returns the applied ones
+func (m *Migrator) Up(ctx context.Context, target int) ([]Status, error) {
+	var done []Status
+	err := m.locked(ctx, func(ctx context.Context, db *mongo.Database) error {
+		applied, err := m.applied(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if target > 0 && migration.Version > target {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			slog.InfoContext(ctx, "Applying migration", "version", migration.Version, "description", migration.Description)
+			if err := migration.Up(ctx, db); err != nil {
+				return fmt.Errorf("migration %d failed: %w", migration.Version, err)
+			}
+			record := appliedMigration{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now().UTC()}
+			if _, err := db.Collection(MigrationsCollection).InsertOne(ctx, record); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+			}
+			done = append(done, Status{Version: record.Version, Description: record.Description, AppliedAt: &record.AppliedAt})
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts applied migrations above the target version, starting with
+// the latest one, and returns the reverted ones
+func (m *Migrator) Down(ctx context.Context, target int) ([]Status, error) {
+	var done []Status
+	err := m.locked(ctx, func(ctx context.Context, db *mongo.Database) error {
+		applied, err := m.applied(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if migration.Version <= target {
+				break
+			}
+			record, ok := applied[migration.Version]
+			if !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d cannot be reverted", migration.Version)
+			}
+
+			slog.InfoContext(ctx, "Reverting migration", "version", migration.Version, "description", migration.Description)
+			if err := migration.Down(ctx, db); err != nil {
+				return fmt.Errorf("revert of migration %d failed: %w", migration.Version, err)
+			}
+			if _, err := db.Collection(MigrationsCollection).DeleteOne(ctx, bson.M{"version": migration.Version}); err != nil {
+				return fmt.Errorf("failed to record revert of migration %d: %w", migration.Version, err)
+			}
+			done = append(done, Status{Version: record.Version, Description: record.Description})
+		}
+		return nil
+	})
+	return done, err
+}
+
+func (m *Migrator) applied(ctx context.Context, db *mongo.Database) (map[int]appliedMigration, error) {
+	cursor, err := db.Collection(MigrationsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	var records []appliedMigration
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]appliedMigration, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// errLockLost cancels the run whose lock expired and was taken over
+var errLockLost = errors.New("migration lock was taken over by another instance")
+
+// locked runs the function holding the migration lock, waiting while it is
+// held by another run until the context is done. The lock is renewed while
+// the function runs, its context is cancelled when the lock is lost.
+func (m *Migrator) locked(ctx context.Context, run func(ctx context.Context, db *mongo.Database) error) error {
+	db, err := m.client.Database(ctx)
+	if err != nil {
+		return err
+	}
+	locks := db.Collection(LockCollection)
+
+	for {
+		now := time.Now().UTC()
+		_, err := locks.InsertOne(ctx, migrationLock{
+			Id:        "migrations",
+			Owner:     m.owner,
+			LockedAt:  now,
+			ExpiresAt: now.Add(m.LockTTL),
+		})
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		// take over the lock of a run which crashed
+		result, err := locks.DeleteOne(ctx, bson.M{"_id": "migrations", "expiresat": bson.M{"$lt": now}})
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if result.DeletedCount > 0 {
+			slog.WarnContext(ctx, "Took over expired migration lock")
+			continue
+		}
+
+		var holder migrationLock
+		locks.FindOne(ctx, bson.M{"_id": "migrations"}).Decode(&holder)
+		slog.InfoContext(ctx, "Waiting for migrations run by another instance", "owner", holder.Owner)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("migrations are locked by %s: %w", holder.Owner, ctx.Err())
+		case <-time.After(m.LockPollInterval):
+		}
+	}
+
+	defer func() {
+		// release even if the context was cancelled meanwhile
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if _, err := locks.DeleteOne(releaseCtx, bson.M{"_id": "migrations", "owner": m.owner}); err != nil {
+			slog.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	runCtx, cancel := context.WithCancelCause(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		m.renew(runCtx, locks, cancel)
+	}()
+	err = run(runCtx, db)
+	if cause := context.Cause(runCtx); err != nil && errors.Is(cause, errLockLost) {
+		err = fmt.Errorf("%w: %w", cause, err)
+	}
+	cancel(nil)
+	<-renewed
+	return err
+}
+
+// renew extends the lock of the run until the context is done. The run is
+// cancelled once the lock is found taken over, e.g. after renewals failed
+// for longer than the TTL.
+func (m *Migrator) renew(ctx context.Context, locks *mongo.Collection, lost context.CancelCauseFunc) {
+	ticker := time.NewTicker(m.LockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		result, err := locks.UpdateOne(ctx,
+			bson.M{"_id": "migrations", "owner": m.owner},
+			bson.M{"$set": bson.M{"expiresat": time.Now().UTC().Add(m.LockTTL)}})
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "Failed to renew migration lock", "error", err)
+			}
+		case result.MatchedCount == 0:
+			slog.ErrorContext(ctx, "Migration lock was taken over by another instance")
+			lost(errLockLost)
+			return
+		}
+	}
+}
+
+// IgnoreCodes returns nil if the error is a server error with one of the
+// codes, e.g. of an index already existing, so migrations can be rerun
+func IgnoreCodes(err error, codes ...int) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		for _, code := range codes {
+			if serverErr.HasErrorCode(code) {
+				return nil
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func up(ctx context.Context, db *mongo.Database) error {
+	return nil
+}
+
+func TestNewMigratorOrdersMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, Config{},
+		Migration{Version: 3, Up: up},
+		Migration{Version: 1, Up: up},
+		Migration{Version: 20, Up: up},
+		Migration{Version: 2, Up: up},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := []int{}
+	for _, migration := range migrator.migrations {
+		versions = append(versions, migration.Version)
+	}
+	if len(versions) != 4 || versions[0] != 1 || versions[1] != 2 || versions[2] != 3 || versions[3] != 20 {
+		t.Fatalf("migrations ordered %v, want ascending versions", versions)
+	}
+	if migrator.LockTTL != 5*time.Minute || migrator.LockPollInterval != time.Second || migrator.owner == "" {
+		t.Fatalf("migrator %+v without defaults", migrator.Config)
+	}
+}
+
+func TestNewMigratorRejectsInvalidMigrations(t *testing.T) {
+	cases := []struct {
+		name       string
+		migrations []Migration
+		err        string
+	}{
+		{"duplicate version", []Migration{{Version: 2, Up: up}, {Version: 1, Up: up}, {Version: 2, Up: up}}, "migration 2 is defined twice"},
+		{"zero version", []Migration{{Version: 0, Up: up}}, "migration 0: version must be positive and Up must be set"},
+		{"negative version", []Migration{{Version: -1, Up: up}}, "migration -1: version must be positive and Up must be set"},
+		{"missing up", []Migration{{Version: 1, Up: up}, {Version: 2}}, "migration 2: version must be positive and Up must be set"},
+	}
+	for _, c := range cases {
+		if _, err := NewMigrator(nil, Config{}, c.migrations...); err == nil || err.Error() != c.err {
+			t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+		}
+	}
+
+	if _, err := NewMigrator(nil, Config{}, All...); err != nil {
+		t.Fatalf("released migrations are invalid: %v", err)
+	}
+}
+
+func TestIgnoreCodes(t *testing.T) {
+	// IndexOptionsConflict and NamespaceExists
+	codes := []int{85, 48}
+	other := errors.New("connection refused")
+	cases := []struct {
+		name    string
+		err     error
+		ignored bool
+	}{
+		{"no error", nil, true},
+		{"command error with ignored code", mongo.CommandError{Code: 85, Message: "index already exists with different options"}, true},
+		{"another ignored code", mongo.CommandError{Code: 48, Message: "collection already exists"}, true},
+		{"write error with ignored code", mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 85}}}, true},
+		{"wrapped ignored code", errors.Join(errors.New("create index"), mongo.CommandError{Code: 85}), true},
+		{"command error with other code", mongo.CommandError{Code: 11000, Message: "duplicate key"}, false},
+		{"error other than server error", other, false},
+	}
+	for _, c := range cases {
+		err := IgnoreCodes(c.err, codes...)
+		if c.ignored && err != nil {
+			t.Errorf("%s: IgnoreCodes returned %v, want nil", c.name, err)
+		}
+		if !c.ignored && err == nil {
+			t.Errorf("%s: IgnoreCodes ignored the error", c.name)
+		}
+	}
+	if err := IgnoreCodes(other, codes...); err != other {
+		t.Errorf("IgnoreCodes returned %v, want the error unchanged", err)
+	}
+	if err := IgnoreCodes(mongo.CommandError{Code: 85}); err == nil {
+		t.Error("IgnoreCodes without codes ignored the error")
+	}
+}
@@ -0,0 +1,243 @@
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// codes of server errors making the migrations safe to rerun on databases
+// initialized by the former init-db.js script
+const (
+	codeIndexNotFound         = 27
+	codeNamespaceNotFound     = 26
+	codeNamespaceExists       = 48
+	codeIndexOptionsConflict  = 85
+	codeIndexKeySpecsConflict = 86
+)
+
+// All are the migrations of the service schema in order of their versions.
+// Migrations reference collections and fields by their names, not by the
+// service types, so they keep their meaning when the types change.
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "create patients and medical-records collections with their indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{"patients", "medical-records"} {
+				if err := IgnoreCodes(db.CreateCollection(ctx, collection), codeNamespaceExists); err != nil {
+					return err
+				}
+			}
+			if err := createIndexes(ctx, db.Collection("patients"), "id", "insurancenumber"); err != nil {
+				return err
+			}
+			return createIndexes(ctx, db.Collection("medical-records"), "id", "patientid")
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{"patients", "medical-records"} {
+				if err := db.Collection(collection).Drop(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		// init-db.js indexed the camelCase field names of the API, while the
+		// documents are stored with lowercase field names
+		Version:     2,
+		Description: "drop unused indexes on camelCase fields created by init-db.js",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			if err := dropIndex(ctx, db.Collection("patients"), "insuranceNumber_1"); err != nil {
+				return err
+			}
+			return dropIndex(ctx, db.Collection("medical-records"), "patientId_1")
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			if _, err := db.Collection("patients").Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "insuranceNumber", Value: 1}}}); err != nil {
+				return err
+			}
+			_, err := db.Collection("medical-records").Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "patientId", Value: 1}}})
+			return err
+		},
+	},
+	{
+		// allergies were free text separated by commas, the structured
+		// allergies of the migrated text have unknown severity and are not
+		// verified
+		Version:     3,
+		Description: "convert free-text patient allergies to list of allergies",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			return convertAllergies(ctx, db.Collection("patients"), bson.TypeString, func(value bson.RawValue) interface{} {
+				allergies := bson.A{}
+				for _, substance := range splitAllergies(value.StringValue()) {
+					allergies = append(allergies, bson.D{
+						{Key: "substance", Value: substance},
+						{Key: "reaction", Value: ""},
+						{Key: "severity", Value: ""},
+						{Key: "verified", Value: false},
+					})
+				}
+				return allergies
+			})
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return convertAllergies(ctx, db.Collection("patients"), bson.TypeArray, func(value bson.RawValue) interface{} {
+				values, _ := value.Array().Values()
+				substances := make([]string, 0, len(values))
+				for _, allergy := range values {
+					if substance, ok := allergy.Document().Lookup("substance").StringValueOK(); ok && substance != "" {
+						substances = append(substances, substance)
+					}
+				}
+				return strings.Join(substances, ", ")
+			})
+		},
+	},
+	{
+		// reconciliation of medications refers to them by their identifiers,
+		// dates of the treatment of the former medications are derived from
+		// the visit and the textual duration when they are read
+		Version:     4,
+		Description: "assign identifiers to prescribed medications",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			records := db.Collection("medical-records")
+			cursor, err := records.Find(ctx,
+				bson.D{{Key: "medications", Value: bson.D{{Key: "$elemMatch", Value: bson.D{{Key: "id", Value: bson.D{{Key: "$exists", Value: false}}}}}}}},
+				options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "medications", Value: 1}}),
+			)
+			if err != nil {
+				return err
+			}
+			defer cursor.Close(ctx)
+
+			for cursor.Next(ctx) {
+				var record struct {
+					Id          interface{} `bson:"_id"`
+					Medications []bson.D    `bson:"medications"`
+				}
+				if err := cursor.Decode(&record); err != nil {
+					return err
+				}
+				for i, medication := range record.Medications {
+					if !hasKey(medication, "id") {
+						record.Medications[i] = append(medication, bson.E{Key: "id", Value: uuid.NewString()})
+					}
+				}
+				update := bson.D{{Key: "$set", Value: bson.D{{Key: "medications", Value: record.Medications}}}}
+				if _, err := records.UpdateByID(ctx, record.Id, update); err != nil {
+					return err
+				}
+			}
+			return cursor.Err()
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("medical-records").UpdateMany(ctx,
+				bson.D{{Key: "medications.id", Value: bson.D{{Key: "$exists", Value: true}}}},
+				bson.D{{Key: "$unset", Value: bson.D{{Key: "medications.$[].id", Value: ""}}}},
+			)
+			return err
+		},
+	},
+	{
+		// vital signs are bucketed by the patient and the time of measurement
+		Version:     5,
+		Description: "create vitals time-series collection",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			timeSeries := options.TimeSeries().
+				SetTimeField("measuredat").
+				SetMetaField("patientid").
+				SetGranularity("minutes")
+			return IgnoreCodes(db.CreateCollection(ctx, "vitals", options.CreateCollection().SetTimeSeriesOptions(timeSeries)), codeNamespaceExists)
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return db.Collection("vitals").Drop(ctx)
+		},
+	},
+	{
+		// bodies of the deliveries are read from the outbox, the removed
+		// patient data is not restored on revert
+		Version:     6,
+		Description: "remove payloads from webhook delivery log",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("webhook-deliveries").UpdateMany(ctx,
+				bson.D{{Key: "payload", Value: bson.D{{Key: "$exists", Value: true}}}},
+				bson.D{{Key: "$unset", Value: bson.D{{Key: "payload", Value: ""}}}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return nil
+		},
+	},
+}
+
+// convertAllergies replaces allergies of the given type stored in the patients
+// by the converted value
+func convertAllergies(ctx context.Context, patients *mongo.Collection, from bsontype.Type, convert func(bson.RawValue) interface{}) error {
+	cursor, err := patients.Find(ctx,
+		bson.D{{Key: "allergies", Value: bson.D{{Key: "$type", Value: from}}}},
+		options.Find().SetProjection(bson.D{{Key: "_id", Value: 1}, {Key: "allergies", Value: 1}}),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		id := cursor.Current.Lookup("_id")
+		update := bson.D{{Key: "$set", Value: bson.D{{Key: "allergies", Value: convert(cursor.Current.Lookup("allergies"))}}}}
+		if _, err := patients.UpdateByID(ctx, id, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func hasKey(document bson.D, key string) bool {
+	for _, element := range document {
+		if element.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAllergies splits the free text by commas, semicolons and new lines
+func splitAllergies(text string) []string {
+	substances := []string{}
+	for _, substance := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	}) {
+		if substance = strings.TrimSpace(substance); substance != "" {
+			substances = append(substances, substance)
+		}
+	}
+	return substances
+}
+
+// createIndexes creates ascending index on every field, named by the field.
+// Index of the same field already existing under another name is kept.
+func createIndexes(ctx context.Context, collection *mongo.Collection, fields ...string) error {
+	for _, field := range fields {
+		_, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: field, Value: 1}},
+			Options: options.Index().SetName(field),
+		})
+		if err := IgnoreCodes(err, codeIndexOptionsConflict, codeIndexKeySpecsConflict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dropIndex(ctx context.Context, collection *mongo.Collection, name string) error {
+	_, err := collection.Indexes().DropOne(ctx, name)
+	return IgnoreCodes(err, codeIndexNotFound, codeNamespaceNotFound)
+}
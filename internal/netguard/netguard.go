@@ -0,0 +1,100 @@
+// Package netguard keeps outgoing requests to URLs given through the API,
+// e.g. webhook subscriptions, from reaching the internal network of the
+// service. Targets are checked when the URL is registered and again when the
+// connection is dialed, so a host resolving to another address later is
+// refused as well.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress reports target in a private or reserved network
+var ErrForbiddenAddress = errors.New("address is in a private or reserved network")
+
+// reservedPrefixes are special-purpose ranges not covered by the checks of
+// netip.Addr, see the IANA special-purpose address registries
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("64:ff9b:1::/48"),
+	netip.MustParsePrefix("100::/64"),
+	netip.MustParsePrefix("2001::/23"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// CheckAddress rejects loopback, private, link-local, multicast and other
+// reserved addresses
+func CheckAddress(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return fmt.Errorf("%v: %w", addr, ErrForbiddenAddress)
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%v: %w", addr, ErrForbiddenAddress)
+		}
+	}
+	return nil
+}
+
+// CheckHost resolves the host and rejects it when any of its addresses is
+// not public
+func CheckHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil {
+		return CheckAddress(addr)
+	}
+	if strings.EqualFold(strings.TrimSuffix(host, "."), "localhost") {
+		return fmt.Errorf("%s: %w", host, ErrForbiddenAddress)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("host %s cannot be resolved: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := CheckAddress(addr); err != nil {
+			return fmt.Errorf("%s: %w", host, err)
+		}
+	}
+	return nil
+}
+
+// Transport returns HTTP transport refusing connections to addresses which
+// are not public. Proxies are not used, so the checked address is the one of
+// the target.
+func Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		// called with the resolved address right before connecting
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return CheckAddress(addrPort.Addr())
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
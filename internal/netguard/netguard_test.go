@@ -0,0 +1,100 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestCheckAddress(t *testing.T) {
+	cases := []struct {
+		address   string
+		forbidden bool
+	}{
+		{"93.184.216.34", false},
+		{"8.8.8.8", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+		{"127.0.0.1", true},
+		{"127.10.0.1", true},
+		{"::1", true},
+		{"0.0.0.0", true},
+		{"::", true},
+		{"10.0.0.1", true},
+		{"172.16.0.1", true},
+		{"172.31.255.255", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"169.254.0.1", true},
+		{"fe80::1", true},
+		{"fc00::1", true},
+		{"fd12:3456:789a::1", true},
+		{"100.64.0.1", true},
+		{"224.0.0.1", true},
+		{"ff02::1", true},
+		{"192.0.2.1", true},
+		{"2001:db8::1", true},
+		{"255.255.255.255", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"::ffff:169.254.169.254", true},
+		{"::ffff:93.184.216.34", false},
+		{"64:ff9b::a00:1", true},
+	}
+	for _, c := range cases {
+		err := CheckAddress(netip.MustParseAddr(c.address))
+		if forbidden := errors.Is(err, ErrForbiddenAddress); forbidden != c.forbidden {
+			t.Errorf("CheckAddress(%s) = %v, want forbidden %v", c.address, err, c.forbidden)
+		}
+	}
+	if err := CheckAddress(netip.Addr{}); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("CheckAddress of invalid address = %v, want forbidden", err)
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	cases := []struct {
+		host      string
+		forbidden bool
+	}{
+		{"93.184.216.34", false},
+		{"[2606:2800:220:1:248:1893:25c8:1946]", false},
+		{"127.0.0.1", true},
+		{"[::1]", true},
+		{"[::ffff:169.254.169.254]", true},
+		{"169.254.169.254", true},
+		{"localhost", true},
+		{"LOCALHOST.", true},
+	}
+	for _, c := range cases {
+		err := CheckHost(context.Background(), c.host)
+		if forbidden := errors.Is(err, ErrForbiddenAddress); forbidden != c.forbidden || (!c.forbidden && err != nil) {
+			t.Errorf("CheckHost(%s) = %v, want forbidden %v", c.host, err, c.forbidden)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := CheckHost(ctx, "subscriber.invalid"); err == nil {
+		t.Error("CheckHost of host which cannot be resolved succeeded")
+	}
+}
+
+func TestTransportRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the server on the loopback address")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: Transport()}
+	response, err := client.Get(server.URL)
+	if err == nil {
+		response.Body.Close()
+	}
+	if !errors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("request to %s returned %v, want forbidden address", server.URL, err)
+	}
+}
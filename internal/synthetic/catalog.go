@@ -0,0 +1,221 @@
+package synthetic
+
+// name has the male and female form, surnames of women differ in Slovak
+type name struct {
+	male, female string
+}
+
+var firstNames = []name{
+	{"Ján", "Mária"}, {"Peter", "Anna"}, {"Jozef", "Zuzana"}, {"Michal", "Katarína"},
+	{"Martin", "Eva"}, {"Tomáš", "Jana"}, {"Lukáš", "Lucia"}, {"Marek", "Martina"},
+	{"Juraj", "Veronika"}, {"Štefan", "Ľudmila"}, {"Ľubomír", "Helena"}, {"Dušan", "Simona"},
+	{"Miroslav", "Alžbeta"}, {"Pavol", "Monika"}, {"Matej", "Barbora"}, {"Radoslav", "Kristína"},
+	{"Rastislav", "Daniela"}, {"Igor", "Ivana"}, {"Vladimír", "Jarmila"}, {"Jakub", "Terézia"},
+	{"Andrej", "Dominika"}, {"Samuel", "Nikola"}, {"Ondrej", "Soňa"}, {"Ľuboš", "Oľga"},
+}
+
+var lastNames = []name{
+	{"Novák", "Nováková"}, {"Horváth", "Horváthová"}, {"Kováč", "Kováčová"}, {"Varga", "Vargová"},
+	{"Tóth", "Tóthová"}, {"Nagy", "Nagyová"}, {"Baláž", "Balážová"}, {"Szabó", "Szabóová"},
+	{"Molnár", "Molnárová"}, {"Lukáč", "Lukáčová"}, {"Šimko", "Šimková"}, {"Krajčí", "Krajčíová"},
+	{"Polák", "Poláková"}, {"Kollár", "Kollárová"}, {"Hudák", "Hudáková"}, {"Oravec", "Oravcová"},
+	{"Žiak", "Žiaková"}, {"Mráz", "Mrázová"}, {"Bielik", "Bieliková"}, {"Šťastný", "Šťastná"},
+	{"Kráľ", "Kráľová"}, {"Čierny", "Čierna"}, {"Ďurica", "Ďuricová"}, {"Hraško", "Hrašková"},
+}
+
+type city struct {
+	name, postalCode string
+}
+
+var cities = []city{
+	{"Bratislava", "81101"}, {"Košice", "04001"}, {"Prešov", "08001"}, {"Žilina", "01001"},
+	{"Nitra", "94901"}, {"Banská Bystrica", "97401"}, {"Trnava", "91701"}, {"Trenčín", "91101"},
+	{"Martin", "03601"}, {"Poprad", "05801"}, {"Zvolen", "96001"}, {"Michalovce", "07101"},
+	{"Piešťany", "92101"}, {"Liptovský Mikuláš", "03101"}, {"Ružomberok", "03401"}, {"Levice", "93401"},
+}
+
+var streets = []string{
+	"Hlavná", "Štúrova", "Námestie SNP", "Hviezdoslavova", "Kollárova", "Mierová",
+	"Školská", "Záhradná", "Železničná", "Partizánska", "Jesenského", "Dlhá",
+	"Družstevná", "Komenského", "Sládkovičova", "Ružová",
+}
+
+type weighted struct {
+	value  string
+	weight int
+}
+
+// bloodTypes are weighted by their approximate frequency in Slovakia
+var bloodTypes = []weighted{
+	{"A+", 36}, {"O+", 27}, {"B+", 12}, {"AB+", 6}, {"A-", 7}, {"O-", 6}, {"B-", 2}, {"AB-", 1},
+}
+
+var statuses = []weighted{
+	{"Stable", 70}, {"Recovering", 18}, {"Critical", 4}, {"Discharged", 8},
+}
+
+var allergens = []string{"Penicilín", "Arašidy", "Peľ", "Roztoče", "Laktóza", "Ibuprofén", "Jód", "Latex"}
+
+var allergyReactions = []string{"vyrážka", "žihľavka", "opuch", "svrbenie", "dýchavičnosť", "anafylaxia"}
+
+var allergySeverities = []weighted{{"Mild", 45}, {"Moderate", 35}, {"Severe", 10}, {"", 10}}
+
+var chronicNotes = []string{
+	"Pacient má chronické problémy s tlakom",
+	"Fajčiar, 20 cigariet denne",
+	"Stav po operácii slepého čreva",
+	"Pravidelne sleduje hladinu cukru",
+	"Nosí okuliare, -2,5 dioptrie",
+	"Športovec, pravidelná záťaž",
+}
+
+var relationships = map[string][]string{
+	"M": {"manžel", "syn", "otec", "brat"},
+	"F": {"manželka", "dcéra", "matka", "sestra"},
+}
+
+var doctors = []string{
+	"MUDr. Peter Kováč", "MUDr. Eva Horáková", "MUDr. Martin Šimko", "MUDr. Zuzana Bieliková",
+	"MUDr. Jozef Oravec", "MUDr. Katarína Mrázová",
+}
+
+type medication struct {
+	name, dosage, frequency, duration string
+	atc                               string // code of the substance in ATC
+}
+
+// scenario is a diagnosis with its typical symptoms and treatment
+type scenario struct {
+	diagnosis   string
+	icd10       string // code of the diagnosis in MKCH-10
+	symptoms    []string
+	treatment   string
+	medications []medication
+	// weight of the scenario among visits
+	weight int
+	// follow-up visit after days, none when 0
+	followUpDays int
+}
+
+var scenarios = []scenario{
+	{
+		diagnosis: "Akútna respiračná infekcia",
+		icd10:     "J06.9",
+		symptoms:  []string{"kašeľ", "teploty", "bolesti hrdla", "nádcha", "únava"},
+		treatment: "Odpočinok, zvýšený príjem tekutín",
+		medications: []medication{
+			{name: "Paralen", dosage: "500mg", frequency: "3x denne", duration: "5 dní", atc: "N02BE01"},
+			{name: "Ambroxol", dosage: "30mg", frequency: "3x denne", duration: "7 dní", atc: "R05CB06"},
+		},
+		weight: 20, followUpDays: 7,
+	},
+	{
+		diagnosis: "Akútna bronchitída",
+		icd10:     "J20.9",
+		symptoms:  []string{"kašeľ", "dýchavičnosť", "teploty", "bolesti na hrudi"},
+		treatment: "Predpísané antibiotiká, odpočinok, zvýšený príjem tekutín",
+		medications: []medication{
+			{name: "Amoxicilín", dosage: "1g", frequency: "2x denne", duration: "7 dní", atc: "J01CA04"},
+			{name: "Klaritromycín", dosage: "500mg", frequency: "2x denne", duration: "7 dní", atc: "J01FA09"},
+		},
+		weight: 8, followUpDays: 10,
+	},
+	{
+		diagnosis: "Esenciálna hypertenzia",
+		icd10:     "I10",
+		symptoms:  []string{"bolesti hlavy", "závraty", "búšenie srdca"},
+		treatment: "Úprava životosprávy, obmedzenie soli, antihypertenzíva",
+		medications: []medication{
+			{name: "Amlodipín", dosage: "5mg", frequency: "1x denne", duration: "dlhodobo", atc: "C08CA01"},
+			{name: "Ramipril", dosage: "5mg", frequency: "1x denne", duration: "dlhodobo", atc: "C09AA05"},
+		},
+		weight: 12, followUpDays: 90,
+	},
+	{
+		diagnosis: "Diabetes mellitus 2. typu",
+		icd10:     "E11.9",
+		symptoms:  []string{"smäd", "časté močenie", "únava", "rozmazané videnie"},
+		treatment: "Diabetická diéta, pravidelná kontrola glykémie",
+		medications: []medication{
+			{name: "Metformín", dosage: "500mg", frequency: "2x denne", duration: "dlhodobo", atc: "A10BA02"},
+		},
+		weight: 8, followUpDays: 90,
+	},
+	{
+		diagnosis: "Migréna",
+		icd10:     "G43.9",
+		symptoms:  []string{"bolesti hlavy", "nevoľnosť", "citlivosť na svetlo"},
+		treatment: "Pokoj v tmavej miestnosti, analgetiká pri záchvate",
+		medications: []medication{
+			{name: "Ibuprofén", dosage: "400mg", frequency: "podľa potreby", duration: "pri záchvate", atc: "M01AE01"},
+			{name: "Sumatriptán", dosage: "50mg", frequency: "podľa potreby", duration: "pri záchvate", atc: "N02CC01"},
+		},
+		weight: 6,
+	},
+	{
+		diagnosis: "Akútna gastroenteritída",
+		icd10:     "A09",
+		symptoms:  []string{"hnačka", "vracanie", "bolesti brucha", "teploty"},
+		treatment: "Rehydratácia, šetriaca diéta",
+		medications: []medication{
+			{name: "Smecta", dosage: "3g", frequency: "3x denne", duration: "3 dni", atc: "A07BC05"},
+		},
+		weight: 7,
+	},
+	{
+		diagnosis: "Lumbago",
+		icd10:     "M54.5",
+		symptoms:  []string{"bolesti krížov", "obmedzená hybnosť"},
+		treatment: "Rehabilitácia, nesteroidné antiflogistiká",
+		medications: []medication{
+			{name: "Diklofenak", dosage: "50mg", frequency: "2x denne", duration: "5 dní", atc: "M01AB05"},
+		},
+		weight: 8, followUpDays: 14,
+	},
+	{
+		diagnosis: "Infekcia močových ciest",
+		icd10:     "N39.0",
+		symptoms:  []string{"pálenie pri močení", "časté močenie", "bolesti podbruška"},
+		treatment: "Zvýšený príjem tekutín, antibiotiká",
+		medications: []medication{
+			{name: "Furolin", dosage: "100mg", frequency: "3x denne", duration: "7 dní", atc: "J01XE01"},
+		},
+		weight: 6, followUpDays: 14,
+	},
+	{
+		diagnosis: "Alergická rinitída",
+		icd10:     "J30.4",
+		symptoms:  []string{"kýchanie", "nádcha", "svrbenie očí"},
+		treatment: "Vyhýbanie sa alergénom, antihistaminiká",
+		medications: []medication{
+			{name: "Cetirizín", dosage: "10mg", frequency: "1x denne", duration: "14 dní", atc: "R06AE07"},
+		},
+		weight: 6,
+	},
+	{
+		diagnosis: "Bronchiálna astma",
+		icd10:     "J45.9",
+		symptoms:  []string{"dýchavičnosť", "pískanie pri dýchaní", "kašeľ v noci"},
+		treatment: "Inhalačná liečba, edukácia o používaní inhalátora",
+		medications: []medication{
+			{name: "Ventolin", dosage: "100µg", frequency: "podľa potreby", duration: "dlhodobo", atc: "R03AC02"},
+		},
+		weight: 4, followUpDays: 180,
+	},
+	{
+		diagnosis: "Úzkostná porucha",
+		icd10:     "F41.9",
+		symptoms:  []string{"nespavosť", "nepokoj", "búšenie srdca"},
+		treatment: "Psychoterapia, anxiolytiká",
+		medications: []medication{
+			{name: "Sertralín", dosage: "50mg", frequency: "1x denne", duration: "dlhodobo", atc: "N06AB06"},
+		},
+		weight: 4, followUpDays: 30,
+	},
+	{
+		diagnosis: "Preventívna prehliadka",
+		icd10:     "Z00.0",
+		treatment: "Kontrola zdravotného stavu",
+		weight:    15,
+	},
+}
@@ -0,0 +1,300 @@
+// Package synthetic generates realistic Slovak patients and their medical
+// records for demos and load tests. The data are deterministic, the same
+// seed and reference time always produce the same documents.
+package synthetic
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/codes"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+type Config struct {
+	Seed uint64
+	// time the generated patients are created at, ages and visits are
+	// relative to it; the start of the current day when zero
+	Now time.Time
+	// medical records of each patient are between the bounds
+	MinRecords int
+	MaxRecords int
+}
+
+// Generator generates the documents, it is not safe for concurrent use
+type Generator struct {
+	Config
+	random *rand.Rand
+	// insurance numbers generated so far, they are unique
+	insuranceNumbers map[string]bool
+}
+
+func NewGenerator(config Config) *Generator {
+	g := &Generator{Config: config, insuranceNumbers: map[string]bool{}}
+	if g.Now.IsZero() {
+		g.Now = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if g.MaxRecords == 0 {
+		g.MaxRecords = 5
+	}
+	if g.MinRecords > g.MaxRecords {
+		g.MinRecords = g.MaxRecords
+	}
+	g.random = rand.New(rand.NewPCG(config.Seed, 0x6d646d))
+	return g
+}
+
+// Dataset generates the patients with their medical records
+func (g *Generator) Dataset(patients int) ([]mdm.Patient, []mdm.MedicalRecord) {
+	generatedPatients := make([]mdm.Patient, 0, patients)
+	var records []mdm.MedicalRecord
+	for range patients {
+		patient := g.Patient()
+		generatedPatients = append(generatedPatients, patient)
+		records = append(records, g.MedicalRecords(&patient)...)
+	}
+	return generatedPatients, records
+}
+
+// Patient generates a patient with a unique insurance number
+func (g *Generator) Patient() mdm.Patient {
+	gender := g.pick([]string{"M", "F"})
+	firstName := g.nameOf(firstNames, gender)
+	lastName := g.nameOf(lastNames, gender)
+
+	// ages up to 95 years, the day of birth lies before the reference time
+	dateOfBirth := g.Now.AddDate(-g.random.IntN(95), 0, -1-g.random.IntN(365)).Truncate(24 * time.Hour)
+
+	patient := mdm.Patient{
+		Id:              g.uuid(),
+		FirstName:       firstName,
+		LastName:        lastName,
+		DateOfBirth:     dateOfBirth.Format(time.DateOnly),
+		Gender:          gender,
+		InsuranceNumber: g.insuranceNumber(dateOfBirth, gender),
+		BloodType:       g.weighted(bloodTypes),
+		Status:          g.weighted(statuses),
+		Address:         g.address(),
+		EmergencyContact: mdm.EmergencyContact{
+			Relationship: g.pick(relationships[g.pick([]string{"M", "F"})]),
+			PhoneNumber:  g.phoneNumber(),
+		},
+		CreatedAt: g.Now,
+		UpdatedAt: g.Now,
+	}
+
+	contactGender := "M"
+	if slices.Contains(relationships["F"], patient.EmergencyContact.Relationship) {
+		contactGender = "F"
+	}
+	contactLastName := g.nameOf(lastNames, contactGender)
+	if strings.HasPrefix(patient.EmergencyContact.Relationship, "manžel") || g.random.IntN(4) > 0 {
+		// family members mostly share the surname
+		contactLastName = sameFamily(lastName, contactGender)
+	}
+	patient.EmergencyContact.Name = g.nameOf(firstNames, contactGender) + " " + contactLastName
+
+	if g.random.IntN(10) < 3 {
+		for _, substance := range g.sample(allergens, 1+g.random.IntN(2)) {
+			patient.Allergies = append(patient.Allergies, mdm.Allergy{
+				Substance: substance,
+				Reaction:  g.pick(allergyReactions),
+				Severity:  g.weighted(allergySeverities),
+				Verified:  g.random.IntN(2) == 0,
+			})
+		}
+	}
+	if g.random.IntN(10) < 3 {
+		patient.MedicalNotes = g.pick(chronicNotes)
+	}
+	return patient
+}
+
+// MedicalRecords generates visits of the patient before the reference time,
+// ordered from the oldest. Medications the patient is allergic to are never
+// prescribed.
+func (g *Generator) MedicalRecords(patient *mdm.Patient) []mdm.MedicalRecord {
+	count := g.MinRecords + g.random.IntN(g.MaxRecords-g.MinRecords+1)
+	dateOfBirth, _ := time.Parse(time.DateOnly, patient.DateOfBirth)
+
+	visits := make([]time.Time, 0, count)
+	for range count {
+		// visits within the last three years during office hours
+		visit := g.Now.AddDate(0, 0, -1-g.random.IntN(3*365)).Truncate(24 * time.Hour)
+		visit = visit.Add(time.Duration(7*60+g.random.IntN(9*4)*15) * time.Minute)
+		if visit.After(dateOfBirth) {
+			visits = append(visits, visit)
+		}
+	}
+	sort.Slice(visits, func(i, j int) bool { return visits[i].Before(visits[j]) })
+
+	records := make([]mdm.MedicalRecord, 0, len(visits))
+	for _, visit := range visits {
+		scenario := g.scenario()
+		record := mdm.MedicalRecord{
+			Id:          g.uuid(),
+			PatientId:   patient.Id,
+			DateOfVisit: visit,
+			Diagnosis:   scenario.diagnosis,
+			Symptoms:    []string{},
+			Treatment:   scenario.treatment,
+			Medications: []mdm.Medication{},
+			DoctorName:  g.pick(doctors),
+			CreatedAt:   visit,
+			UpdatedAt:   visit,
+		}
+		if code, ok := codes.Icd10.Lookup(scenario.icd10); ok {
+			record.PrimaryDiagnosis = &mdm.CodedDiagnosis{Code: code.Code, Display: code.Display}
+		}
+		if len(scenario.symptoms) > 0 {
+			record.Symptoms = g.sample(scenario.symptoms, 1+g.random.IntN(len(scenario.symptoms)))
+		}
+		for _, medication := range scenario.medications {
+			prescribed := mdm.Medication{
+				// derived from the record, so that the rest of the data
+				// stays the same for the seed
+				Id:        uuid.NewSHA1(uuid.MustParse(record.Id), []byte(medication.name)).String(),
+				Name:      medication.name,
+				Dosage:    medication.dosage,
+				Frequency: medication.frequency,
+				Duration:  medication.duration,
+				AtcCode:   medication.atc,
+			}
+			if isAllergic(patient, prescribed) || (len(record.Medications) > 0 && g.random.IntN(2) == 0) {
+				continue
+			}
+			record.Medications = append(record.Medications, prescribed)
+		}
+		// the medications have valid dates starting on the day of the visit
+		_ = mdm.ScheduleMedications(&record)
+		if scenario.followUpDays > 0 {
+			record.FollowUpDate = visit.AddDate(0, 0, scenario.followUpDays).Format(time.DateOnly)
+		}
+		if len(patient.Allergies) > 0 && g.random.IntN(3) == 0 {
+			substances := make([]string, 0, len(patient.Allergies))
+			for _, allergy := range patient.Allergies {
+				substances = append(substances, strings.ToLower(allergy.Substance))
+			}
+			record.Notes = "Pacient má alergiu: " + strings.Join(substances, ", ")
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (g *Generator) insuranceNumber(dateOfBirth time.Time, gender string) string {
+	for {
+		number, ok := RodneCislo(dateOfBirth, gender, g.random.IntN(1000))
+		if ok && !g.insuranceNumbers[number] {
+			g.insuranceNumbers[number] = true
+			return number
+		}
+	}
+}
+
+func (g *Generator) address() mdm.Address {
+	city := cities[g.random.IntN(len(cities))]
+	return mdm.Address{
+		Street:     fmt.Sprintf("%s %d", g.pick(streets), 1+g.random.IntN(150)),
+		City:       city.name,
+		PostalCode: city.postalCode,
+		Country:    "Slovensko",
+	}
+}
+
+// phoneNumber returns Slovak mobile number in the international format
+func (g *Generator) phoneNumber() string {
+	return fmt.Sprintf("+4219%02d%06d", pick(g.random, []int{0, 4, 5, 10, 11, 14, 15, 17, 18, 40, 44, 48}), g.random.IntN(1000000))
+}
+
+func (g *Generator) scenario() scenario {
+	total := 0
+	for _, s := range scenarios {
+		total += s.weight
+	}
+	n := g.random.IntN(total)
+	for _, s := range scenarios {
+		if n < s.weight {
+			return s
+		}
+		n -= s.weight
+	}
+	return scenarios[len(scenarios)-1]
+}
+
+func (g *Generator) weighted(values []weighted) string {
+	total := 0
+	for _, v := range values {
+		total += v.weight
+	}
+	n := g.random.IntN(total)
+	for _, v := range values {
+		if n < v.weight {
+			return v.value
+		}
+		n -= v.weight
+	}
+	return values[len(values)-1].value
+}
+
+func (g *Generator) nameOf(names []name, gender string) string {
+	n := names[g.random.IntN(len(names))]
+	if gender == "F" {
+		return n.female
+	}
+	return n.male
+}
+
+// sample returns count distinct values in random order
+func (g *Generator) sample(values []string, count int) []string {
+	picked := slices.Clone(values)
+	g.random.Shuffle(len(picked), func(i, j int) { picked[i], picked[j] = picked[j], picked[i] })
+	return picked[:min(count, len(picked))]
+}
+
+// uuid returns random UUID derived from the seed
+func (g *Generator) uuid() string {
+	var id uuid.UUID
+	for i := 0; i < len(id); i += 8 {
+		value := g.random.Uint64()
+		for j := range 8 {
+			id[i+j] = byte(value >> (8 * j))
+		}
+	}
+	// version 4, variant RFC 4122
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id.String()
+}
+
+func pick[T any](random *rand.Rand, values []T) T {
+	return values[random.IntN(len(values))]
+}
+
+func (g *Generator) pick(values []string) string {
+	return pick(g.random, values)
+}
+
+// sameFamily returns the surname of the family in the form of the gender
+func sameFamily(lastName, gender string) string {
+	for _, n := range lastNames {
+		if n.male == lastName || n.female == lastName {
+			if gender == "F" {
+				return n.female
+			}
+			return n.male
+		}
+	}
+	return lastName
+}
+
+// isAllergic reports whether the service would reject the medication for
+// allergies of the patient
+func isAllergic(patient *mdm.Patient, medication mdm.Medication) bool {
+	return len(mdm.AllergyConflicts(patient.Allergies, []mdm.Medication{medication})) > 0
+}
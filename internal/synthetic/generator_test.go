@@ -0,0 +1,102 @@
+package synthetic
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+func TestSameSeedGivesSameDataset(t *testing.T) {
+	config := Config{Seed: 42, Now: testNow, MinRecords: 1, MaxRecords: 4}
+	patients, records := NewGenerator(config).Dataset(50)
+	otherPatients, otherRecords := NewGenerator(config).Dataset(50)
+
+	if !reflect.DeepEqual(patients, otherPatients) {
+		t.Fatal("patients of the same seed differ")
+	}
+	if !reflect.DeepEqual(records, otherRecords) {
+		t.Fatal("medical records of the same seed differ")
+	}
+
+	config.Seed = 43
+	differentPatients, _ := NewGenerator(config).Dataset(50)
+	if reflect.DeepEqual(patients, differentPatients) {
+		t.Fatal("patients of different seeds are the same")
+	}
+}
+
+func TestGeneratedInsuranceNumbersAreValid(t *testing.T) {
+	patients, _ := NewGenerator(Config{Seed: 7, Now: testNow}).Dataset(500)
+
+	seen := map[string]bool{}
+	for _, patient := range patients {
+		number := patient.InsuranceNumber
+		if seen[number] {
+			t.Fatalf("insurance number %s generated twice", number)
+		}
+		seen[number] = true
+
+		dateOfBirth, gender, err := ParseRodneCislo(number)
+		if err != nil {
+			t.Fatalf("insurance number %s of patient born %s: %v", number, patient.DateOfBirth, err)
+		}
+		if dateOfBirth.Format(time.DateOnly) != patient.DateOfBirth {
+			t.Fatalf("insurance number %s encodes %s, patient born %s", number, dateOfBirth.Format(time.DateOnly), patient.DateOfBirth)
+		}
+		if gender != patient.Gender {
+			t.Fatalf("insurance number %s encodes gender %s, patient is %s", number, gender, patient.Gender)
+		}
+
+		digits := strings.ReplaceAll(number, "/", "")
+		if dateOfBirth.Year() >= 1954 {
+			value, _ := strconv.ParseInt(digits, 10, 64)
+			if len(digits) != 10 || value%11 != 0 {
+				t.Fatalf("insurance number %s of patient born %s is not 10 digits divisible by 11", number, patient.DateOfBirth)
+			}
+		} else if len(digits) != 9 {
+			t.Fatalf("insurance number %s of patient born %s does not have 9 digits", number, patient.DateOfBirth)
+		}
+	}
+}
+
+func TestRodneCislo(t *testing.T) {
+	cases := []struct {
+		dateOfBirth string
+		gender      string
+		serial      int
+		want        string
+		ok          bool
+	}{
+		{"1985-07-23", "M", 123, "850723/1238", true},
+		{"1985-07-23", "F", 123, "855723/1232", true},
+		{"1950-02-01", "M", 42, "500201/042", true},
+		// remainder 10 has no check digit
+		{"1985-07-23", "M", 4, "", false},
+	}
+	for _, c := range cases {
+		dateOfBirth, _ := time.Parse(time.DateOnly, c.dateOfBirth)
+		number, ok := RodneCislo(dateOfBirth, c.gender, c.serial)
+		if number != c.want || ok != c.ok {
+			t.Errorf("RodneCislo(%s, %s, %d) = %q, %v, want %q, %v", c.dateOfBirth, c.gender, c.serial, number, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestParseRodneCisloRejectsInvalidNumbers(t *testing.T) {
+	for _, number := range []string{
+		"850723/1235", // not divisible by 11
+		"850231/0003", // 31st of February
+		"851323/0000", // 13th month
+		"850723/123",  // nine digits after 1953
+		"85072x/1234",
+		"8507",
+	} {
+		if _, _, err := ParseRodneCislo(number); err == nil {
+			t.Errorf("ParseRodneCislo(%s) accepted invalid number", number)
+		}
+	}
+}
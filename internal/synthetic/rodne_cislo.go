@@ -0,0 +1,83 @@
+package synthetic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RodneCislo returns the Slovak birth number of the person born on the date
+// with the serial number distinguishing people born on the same day. The
+// month of women is increased by 50. Numbers of people born since 1954 have
+// ten digits divisible by 11; ok is false when no check digit makes the
+// number divisible, the caller then picks another serial.
+func RodneCislo(dateOfBirth time.Time, gender string, serial int) (number string, ok bool) {
+	month := int(dateOfBirth.Month())
+	if gender == "F" {
+		month += 50
+	}
+	prefix := fmt.Sprintf("%02d%02d%02d", dateOfBirth.Year()%100, month, dateOfBirth.Day())
+
+	if dateOfBirth.Year() < 1954 {
+		return fmt.Sprintf("%s/%03d", prefix, serial%1000), true
+	}
+
+	digits, _ := strconv.Atoi(fmt.Sprintf("%s%03d", prefix, serial%1000))
+	check := digits % 11
+	if check == 10 {
+		return "", false
+	}
+	// appending the digit multiplies the number by 10, which is -1 modulo 11
+	return fmt.Sprintf("%s/%03d%d", prefix, serial%1000, check), true
+}
+
+// ParseRodneCislo validates the birth number and returns the date of birth
+// and gender it encodes
+func ParseRodneCislo(number string) (dateOfBirth time.Time, gender string, err error) {
+	digits := strings.ReplaceAll(number, "/", "")
+	if len(digits) != 9 && len(digits) != 10 {
+		return time.Time{}, "", fmt.Errorf("birth number must have 9 or 10 digits")
+	}
+	value, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("birth number must have only digits")
+	}
+
+	year, _ := strconv.Atoi(digits[0:2])
+	month, _ := strconv.Atoi(digits[2:4])
+	day, _ := strconv.Atoi(digits[4:6])
+	gender = "M"
+	if month > 50 {
+		gender = "F"
+		month -= 50
+	}
+	// since 2004 the month is increased by another 20 when serials of the day run out
+	if month > 20 && len(digits) == 10 && year >= 4 && year < 54 {
+		month -= 20
+	}
+
+	if len(digits) == 9 {
+		// nine digits were issued until 1953
+		year += 1900
+		if year >= 1954 {
+			return time.Time{}, "", fmt.Errorf("birth number of people born since 1954 must have 10 digits")
+		}
+	} else {
+		year += 1900
+		if year < 1954 {
+			year += 100
+		}
+		// numbers issued until 1985 could have check digit 0 for remainder 10
+		prefix := value / 10
+		if value%11 != 0 && !(prefix%11 == 10 && value%10 == 0 && year < 1986) {
+			return time.Time{}, "", fmt.Errorf("birth number is not divisible by 11")
+		}
+	}
+
+	dateOfBirth = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if int(dateOfBirth.Month()) != month || dateOfBirth.Day() != day {
+		return time.Time{}, "", fmt.Errorf("birth number encodes invalid date")
+	}
+	return dateOfBirth, gender, nil
+}
@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// ServiceName identifies the service in traces unless OTEL_SERVICE_NAME is set
+const ServiceName = "mdm-webapi"
+
+// SetupTracing installs global tracer provider and W3C trace context
+// propagator. The exporter is one of:
+//
+//   - none (default) - spans are created for propagation but not exported
+//   - stdout - spans are printed to the standard output
+//   - otlp - spans are sent to the collector configured by the standard
+//     OTEL_EXPORTER_OTLP_* variables, OTEL_EXPORTER_OTLP_PROTOCOL selects
+//     grpc (default) or http/protobuf
+//
+// The returned function flushes the pending spans and stops the exporter.
+func SetupTracing(ctx context.Context, exporterName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName = strings.ToLower(exporterName); exporterName {
+	case "", "none":
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())))
+		otel.SetTracerProvider(provider)
+		return provider.Shutdown, nil
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "otlp":
+		if strings.HasPrefix(os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"), "http") {
+			exporter, err = otlptracehttp.New(ctx)
+		} else {
+			exporter, err = otlptracegrpc.New(ctx)
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, use none, stdout or otlp", exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	serviceResource, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(semconv.ServiceName(serviceName())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func serviceName() string {
+	if name := os.Getenv("OTEL_SERVICE_NAME"); name != "" {
+		return name
+	}
+	return ServiceName
+}
@@ -0,0 +1,289 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	"github.com/samsvi/mdm-webapi/internal/netguard"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Delivery states as stored in the delivery log
+const (
+	StatusPending   = "Pending"
+	StatusSucceeded = "Succeeded"
+	StatusFailed    = "Failed"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-MDM-Event"
+	HeaderDelivery  = "X-MDM-Delivery"
+	HeaderTimestamp = "X-MDM-Timestamp"
+	HeaderSignature = "X-MDM-Signature"
+)
+
+type DispatcherConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	PollInterval   time.Duration
+	BatchSize      int
+	// time the dispatcher holds claimed deliveries before dispatchers of
+	// other replicas can claim them
+	Lease time.Duration
+}
+
+// Dispatcher records a delivery for every subscription interested in the
+// published event and sends the deliveries in the background. Failed
+// deliveries are retried with exponential backoff until MaxAttempts is
+// reached. Deliveries are persisted, so pending retries survive restarts.
+// Due deliveries are claimed by a lease held in their time of the next
+// attempt, so each of them is sent by one replica of the service at a time.
+//
+// The delivery log keeps only the identity and state of the deliveries, the
+// body with the patient data is read from the outbox message of the event on
+// every attempt. Deliveries of events expired from the outbox fail.
+type Dispatcher struct {
+	DispatcherConfig
+	subscriptions db_service.DbService[mdm.WebhookSubscription]
+	deliveries    db_service.LeaseService[mdm.WebhookDelivery]
+	outbox        db_service.DbService[db_service.OutboxMessage]
+	client        *http.Client
+	wakeup        chan struct{}
+	// identifies the leases of this dispatcher among replicas of the service
+	owner string
+}
+
+func NewDispatcher(
+	subscriptions db_service.DbService[mdm.WebhookSubscription],
+	deliveries db_service.LeaseService[mdm.WebhookDelivery],
+	outbox db_service.DbService[db_service.OutboxMessage],
+	config DispatcherConfig,
+) *Dispatcher {
+	d := &Dispatcher{
+		DispatcherConfig: config,
+		subscriptions:    subscriptions,
+		deliveries:       deliveries,
+		outbox:           outbox,
+		wakeup:           make(chan struct{}, 1),
+		owner:            uuid.NewString(),
+	}
+
+	if d.MaxAttempts == 0 {
+		d.MaxAttempts = 8
+	}
+	if d.InitialBackoff == 0 {
+		d.InitialBackoff = 5 * time.Second
+	}
+	if d.MaxBackoff == 0 {
+		d.MaxBackoff = 30 * time.Minute
+	}
+	if d.RequestTimeout == 0 {
+		d.RequestTimeout = 10 * time.Second
+	}
+	if d.PollInterval == 0 {
+		d.PollInterval = 5 * time.Second
+	}
+	if d.BatchSize == 0 {
+		d.BatchSize = 20
+	}
+	if d.Lease == 0 {
+		d.Lease = 5 * time.Minute
+	}
+
+	// subscribers on the internal network are refused even when their host
+	// resolved to a public address at subscription
+	d.client = &http.Client{Timeout: d.RequestTimeout, Transport: netguard.Transport()}
+	return d
+}
+
+// Publish records pending deliveries of the event for all active subscriptions
+func (d *Dispatcher) Publish(ctx context.Context, event events.Event) error {
+	subscriptions, err := d.subscriptions.FindDocumentsByCondition(ctx, bson.M{
+		"active":     true,
+		"eventtypes": event.Type,
+	})
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, subscription := range subscriptions {
+		// deterministic id makes repeated publishing of the same event harmless
+		delivery := mdm.WebhookDelivery{
+			Id:             event.Id + "-" + subscription.Id,
+			SubscriptionId: subscription.Id,
+			EventId:        event.Id,
+			EventType:      event.Type,
+			Status:         StatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+		err := d.deliveries.CreateDocument(ctx, delivery.Id, &delivery)
+		if err != nil && !errors.Is(err, db_service.ErrConflict) {
+			return err
+		}
+	}
+
+	select {
+	case d.wakeup <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run sends due deliveries until the context is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wakeup:
+		}
+	}
+}
+
+func (d *Dispatcher) deliverPending(ctx context.Context) {
+	due, err := d.deliveries.ClaimDocuments(ctx, bson.M{"status": StatusPending}, d.owner, d.BatchSize, d.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Failed to claim pending webhook deliveries", "error", err)
+		}
+		return
+	}
+
+	// deliveries left pending are claimed again once their lease expires
+	for i := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		d.attempt(ctx, &due[i])
+	}
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, delivery *mdm.WebhookDelivery) {
+	subscription, err := d.subscriptions.FindDocument(ctx, delivery.SubscriptionId)
+	switch {
+	case err == db_service.ErrNotFound || (err == nil && !subscription.Active):
+		delivery.Status = StatusFailed
+		delivery.LastError = "subscription deleted or deactivated"
+	case err != nil:
+		slog.Error("Failed to find webhook subscription", "subscription_id", delivery.SubscriptionId, "error", err)
+		return
+	default:
+		message, err := d.outbox.FindDocument(ctx, delivery.EventId)
+		if err == db_service.ErrNotFound {
+			delivery.Status = StatusFailed
+			delivery.LastError = "event expired from the outbox"
+			break
+		}
+		if err != nil {
+			slog.Error("Failed to find outbox message of webhook delivery", "delivery_id", delivery.Id, "error", err)
+			return
+		}
+
+		responseStatus, err := d.send(ctx, subscription, delivery, message.Payload)
+		if ctx.Err() != nil {
+			// cancelled by the shutdown, the delivery is claimed again after the lease
+			return
+		}
+		now := time.Now()
+		delivery.Attempts++
+		delivery.LastAttemptAt = now
+		delivery.ResponseStatus = int32(responseStatus)
+
+		if err == nil {
+			delivery.Status = StatusSucceeded
+			delivery.LastError = ""
+		} else {
+			delivery.LastError = err.Error()
+			if int(delivery.Attempts) >= d.MaxAttempts {
+				delivery.Status = StatusFailed
+			} else {
+				delivery.NextAttemptAt = now.Add(d.backoff(int(delivery.Attempts)))
+			}
+		}
+	}
+
+	switch err := d.deliveries.ReleaseDocument(ctx, d.owner, delivery.Id, delivery); err {
+	case nil:
+	case db_service.ErrNotFound:
+		slog.Warn("Lease of webhook delivery expired before it was released", "delivery_id", delivery.Id)
+	default:
+		slog.Error("Failed to update webhook delivery", "delivery_id", delivery.Id, "error", err)
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, subscription *mdm.WebhookSubscription, delivery *mdm.WebhookDelivery, body []byte) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderDelivery, delivery.Id)
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// drain the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("subscriber responded with %v", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// backoff returns delay before the next attempt, doubling with every failed attempt
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.MaxBackoff {
+			return d.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Sign computes value of the signature header for the delivery body.
+// Subscribers verify deliveries by computing the same value.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
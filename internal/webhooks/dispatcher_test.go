@@ -0,0 +1,206 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/events"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSign(t *testing.T) {
+	// computed independently as HMAC-SHA256 of "<timestamp>.<body>"
+	body := []byte(`{"id":"evt1","type":"patient.created"}`)
+	want := "sha256=35da2d0fd835a3228a96c61e327bfed8f9d3b03f927d9ae5ad1cc0c4bf313305"
+	if got := Sign("whsec-test", "1700000000", body); got != want {
+		t.Fatalf("Sign = %s, want %s", got, want)
+	}
+	if Sign("other-secret", "1700000000", body) == want || Sign("whsec-test", "1700000001", body) == want {
+		t.Fatal("signature does not depend on the secret and the timestamp")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(nil, nil, nil, DispatcherConfig{InitialBackoff: 5 * time.Second, MaxBackoff: time.Minute})
+	cases := map[int]time.Duration{
+		1:  5 * time.Second,
+		2:  10 * time.Second,
+		3:  20 * time.Second,
+		4:  40 * time.Second,
+		5:  time.Minute,
+		20: time.Minute,
+	}
+	for attempts, want := range cases {
+		if got := d.backoff(attempts); got != want {
+			t.Errorf("backoff after %d attempts = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+type subscriptions struct {
+	db_service.DbService[mdm.WebhookSubscription]
+	subscriptions []mdm.WebhookSubscription
+}
+
+func (s subscriptions) FindDocumentsByCondition(ctx context.Context, filter bson.M) ([]mdm.WebhookSubscription, error) {
+	return s.subscriptions, nil
+}
+
+func (s subscriptions) FindDocument(ctx context.Context, id string) (*mdm.WebhookSubscription, error) {
+	for _, subscription := range s.subscriptions {
+		if subscription.Id == id {
+			return &subscription, nil
+		}
+	}
+	return nil, db_service.ErrNotFound
+}
+
+// deliveries keeps the deliveries in memory, all pending deliveries are
+// claimed and the released ones are recorded
+type deliveries struct {
+	db_service.LeaseService[mdm.WebhookDelivery]
+	deliveries map[string]mdm.WebhookDelivery
+	owners     []string
+}
+
+func (d *deliveries) CreateDocument(ctx context.Context, id string, document *mdm.WebhookDelivery) error {
+	if _, ok := d.deliveries[id]; ok {
+		return db_service.ErrConflict
+	}
+	d.deliveries[id] = *document
+	return nil
+}
+
+func (d *deliveries) ClaimDocuments(ctx context.Context, filter bson.M, owner string, limit int, lease time.Duration) ([]mdm.WebhookDelivery, error) {
+	claimed := []mdm.WebhookDelivery{}
+	for _, delivery := range d.deliveries {
+		if delivery.Status == filter["status"] {
+			claimed = append(claimed, delivery)
+		}
+	}
+	return claimed, nil
+}
+
+func (d *deliveries) ReleaseDocument(ctx context.Context, owner string, id string, document *mdm.WebhookDelivery) error {
+	d.owners = append(d.owners, owner)
+	d.deliveries[id] = *document
+	return nil
+}
+
+type outbox struct {
+	db_service.DbService[db_service.OutboxMessage]
+}
+
+func (outbox) FindDocument(ctx context.Context, id string) (*db_service.OutboxMessage, error) {
+	return &db_service.OutboxMessage{Id: id, Payload: []byte(`{"id":"` + id + `"}`)}, nil
+}
+
+func TestPublishRecordsDeliveryOfEachSubscription(t *testing.T) {
+	store := &deliveries{deliveries: map[string]mdm.WebhookDelivery{}}
+	d := NewDispatcher(subscriptions{subscriptions: []mdm.WebhookSubscription{{Id: "sub1"}, {Id: "sub2"}}}, store, outbox{}, DispatcherConfig{})
+
+	event := events.Event{Id: "evt1", Type: "patient.created"}
+	// repeated publishing of the event by the relay records no more deliveries
+	for i := 0; i < 2; i++ {
+		if err := d.Publish(context.Background(), event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(store.deliveries) != 2 {
+		t.Fatalf("deliveries %+v, want one of each subscription", store.deliveries)
+	}
+	for _, id := range []string{"evt1-sub1", "evt1-sub2"} {
+		if delivery, ok := store.deliveries[id]; !ok || delivery.Status != StatusPending || delivery.EventType != event.Type {
+			t.Errorf("delivery %s = %+v, want pending delivery of the event", id, delivery)
+		}
+	}
+}
+
+func TestDeliverPending(t *testing.T) {
+	responses := []int{http.StatusInternalServerError, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(HeaderSignature) != Sign("whsec-test", r.Header.Get(HeaderTimestamp), body) {
+			t.Errorf("delivery signature %s does not match the body", r.Header.Get(HeaderSignature))
+		}
+		if r.Header.Get(HeaderDelivery) != "evt1-sub1" || r.Header.Get(HeaderEvent) != "patient.created" {
+			t.Errorf("delivery headers %v", r.Header)
+		}
+		w.WriteHeader(responses[0])
+		responses = responses[1:]
+	}))
+	defer server.Close()
+
+	store := &deliveries{deliveries: map[string]mdm.WebhookDelivery{}}
+	subscribed := subscriptions{subscriptions: []mdm.WebhookSubscription{{Id: "sub1", Url: server.URL, Secret: "whsec-test", Active: true}}}
+	d := NewDispatcher(subscribed, store, outbox{}, DispatcherConfig{InitialBackoff: time.Minute})
+	// the test server listens on the loopback refused by the dispatcher
+	d.client = server.Client()
+
+	if err := d.Publish(context.Background(), events.Event{Id: "evt1", Type: "patient.created"}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.deliverPending(context.Background())
+	delivery := store.deliveries["evt1-sub1"]
+	if delivery.Status != StatusPending || delivery.Attempts != 1 || delivery.ResponseStatus != http.StatusInternalServerError ||
+		time.Until(delivery.NextAttemptAt) < 59*time.Second {
+		t.Fatalf("delivery %+v, want pending retry after a minute", delivery)
+	}
+
+	d.deliverPending(context.Background())
+	delivery = store.deliveries["evt1-sub1"]
+	if delivery.Status != StatusSucceeded || delivery.Attempts != 2 || delivery.LastError != "" {
+		t.Fatalf("delivery %+v, want succeeded on the second attempt", delivery)
+	}
+	if len(store.owners) != 2 || store.owners[0] != d.owner || store.owners[1] != d.owner {
+		t.Fatalf("deliveries released by %v, want the claiming dispatcher %s", store.owners, d.owner)
+	}
+
+	// succeeded deliveries are not claimed again
+	d.deliverPending(context.Background())
+	if len(store.owners) != 2 {
+		t.Fatal("succeeded delivery sent again")
+	}
+}
+
+func TestDeliveryOfDeactivatedSubscriptionFails(t *testing.T) {
+	store := &deliveries{deliveries: map[string]mdm.WebhookDelivery{
+		"evt1-sub1": {Id: "evt1-sub1", SubscriptionId: "sub1", EventId: "evt1", Status: StatusPending},
+		"evt1-sub2": {Id: "evt1-sub2", SubscriptionId: "sub2", EventId: "evt1", Status: StatusPending},
+	}}
+	d := NewDispatcher(subscriptions{subscriptions: []mdm.WebhookSubscription{{Id: "sub1", Active: false}}}, store, outbox{}, DispatcherConfig{})
+
+	d.deliverPending(context.Background())
+	for id, delivery := range store.deliveries {
+		if delivery.Status != StatusFailed || delivery.Attempts != 0 {
+			t.Errorf("delivery %s = %+v, want failed without attempts", id, delivery)
+		}
+	}
+}
+
+func TestDeliveryFailsAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	store := &deliveries{deliveries: map[string]mdm.WebhookDelivery{
+		"evt1-sub1": {Id: "evt1-sub1", SubscriptionId: "sub1", EventId: "evt1", Status: StatusPending, Attempts: 2},
+	}}
+	subscribed := subscriptions{subscriptions: []mdm.WebhookSubscription{{Id: "sub1", Url: server.URL, Active: true}}}
+	d := NewDispatcher(subscribed, store, outbox{}, DispatcherConfig{MaxAttempts: 3})
+	d.client = server.Client()
+
+	d.deliverPending(context.Background())
+	if delivery := store.deliveries["evt1-sub1"]; delivery.Status != StatusFailed || delivery.Attempts != 3 {
+		t.Fatalf("delivery %+v, want failed after the third attempt", delivery)
+	}
+}
@@ -0,0 +1,173 @@
+// Package mdmclient is a Go client of the Patient and Medical Records
+// management API described by api/mdm.openapi.yaml.
+//
+//	client, err := mdmclient.New("http://localhost:8080/api")
+//	if err != nil {
+//		return err
+//	}
+//	patient, err := client.GetPatient(ctx, "pat123456")
+//	if errors.Is(err, mdmclient.ErrNotFound) {
+//		...
+//	}
+package mdmclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the MDM API. It is safe for concurrent use.
+type Client struct {
+	baseUrl    *url.URL
+	httpClient *http.Client
+	maxRetries int
+	retryWait  time.Duration
+	maxWait    time.Duration
+	headers    http.Header
+}
+
+// Option customizes the Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests, http.DefaultClient is used by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times requests failed with 502 Bad Gateway or
+// 503 Service Unavailable are retried and the initial wait between attempts,
+// which doubles with every attempt. Defaults to 3 retries starting at 200ms,
+// zero retries disables retrying. Only idempotent requests are retried, since
+// a failed POST may have been applied by the server.
+func WithRetries(maxRetries int, wait time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryWait = wait
+	}
+}
+
+// WithHeader adds header sent with every request, e.g. Authorization
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// New returns client of the API at baseUrl, which includes the /api prefix
+func New(baseUrl string, options ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimSuffix(baseUrl, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseUrl)
+	}
+
+	client := &Client{
+		baseUrl:    parsed,
+		httpClient: http.DefaultClient,
+		maxRetries: 3,
+		retryWait:  200 * time.Millisecond,
+		maxWait:    10 * time.Second,
+		headers:    http.Header{},
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client, nil
+}
+
+// idempotentMethods are methods of requests which are safe to repeat, PUT
+// replaces the whole resource and repeated DELETE leaves it deleted
+var idempotentMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodHead:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+func (c *Client) endpoint(query url.Values, segments ...string) string {
+	endpoint := *c.baseUrl
+	for _, segment := range segments {
+		endpoint.Path += "/" + url.PathEscape(segment)
+	}
+	endpoint.RawPath = ""
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String()
+}
+
+// do sends the request and decodes JSON response into out if not nil.
+// Idempotent requests answered with 502 or 503 are retried with exponential
+// backoff, honouring the Retry-After header if present.
+func (c *Client) do(ctx context.Context, method string, endpoint string, in interface{}, out interface{}) (*http.Response, error) {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return nil, err
+		}
+	}
+
+	wait := c.retryWait
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range c.headers {
+			req.Header[key] = values
+		}
+		req.Header.Set("Accept", "application/json")
+		if in != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		retryable := idempotentMethods[method] &&
+			(resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable)
+		if retryable && attempt < c.maxRetries {
+			delay := wait
+			if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+				delay = time.Duration(seconds) * time.Second
+			}
+			if delay > c.maxWait {
+				delay = c.maxWait
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+			wait *= 2
+			continue
+		}
+
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return resp, newError(resp)
+		}
+		if out != nil && resp.StatusCode != http.StatusNoContent {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return resp, fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		return resp, nil
+	}
+}
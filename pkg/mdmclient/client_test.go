@@ -0,0 +1,381 @@
+package mdmclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/samsvi/mdm-webapi/internal/db_service"
+	"github.com/samsvi/mdm-webapi/internal/mdm"
+)
+
+// memoryDb keeps documents in memory in the order of creation. Operations
+// fail with the error returned by fault, if set, before touching the
+// documents.
+type memoryDb[DocType any] struct {
+	db_service.DbService[DocType]
+	lock      sync.Mutex
+	ids       []string
+	documents map[string]DocType
+	fault     func(operation string) error
+	calls     map[string]int
+}
+
+func newMemoryDb[DocType any]() *memoryDb[DocType] {
+	return &memoryDb[DocType]{documents: map[string]DocType{}, calls: map[string]int{}}
+}
+
+func (m *memoryDb[DocType]) call(operation string) error {
+	m.calls[operation]++
+	if m.fault != nil {
+		return m.fault(operation)
+	}
+	return nil
+}
+
+func (m *memoryDb[DocType]) CreateDocument(ctx context.Context, id string, document *DocType) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.call("create"); err != nil {
+		return err
+	}
+	if _, ok := m.documents[id]; ok {
+		return db_service.ErrConflict
+	}
+	m.ids = append(m.ids, id)
+	m.documents[id] = *document
+	return nil
+}
+
+func (m *memoryDb[DocType]) FindAllDocuments(ctx context.Context) ([]DocType, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.call("find_all"); err != nil {
+		return nil, err
+	}
+	documents := []DocType{}
+	for _, id := range m.ids {
+		documents = append(documents, m.documents[id])
+	}
+	return documents, nil
+}
+
+func (m *memoryDb[DocType]) FindDocument(ctx context.Context, id string) (*DocType, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.call("find"); err != nil {
+		return nil, err
+	}
+	document, ok := m.documents[id]
+	if !ok {
+		return nil, db_service.ErrNotFound
+	}
+	return &document, nil
+}
+
+func (m *memoryDb[DocType]) UpdateDocument(ctx context.Context, id string, document *DocType) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.call("update"); err != nil {
+		return err
+	}
+	if _, ok := m.documents[id]; !ok {
+		return db_service.ErrNotFound
+	}
+	m.documents[id] = *document
+	return nil
+}
+
+func (m *memoryDb[DocType]) DeleteDocument(ctx context.Context, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if err := m.call("delete"); err != nil {
+		return err
+	}
+	if _, ok := m.documents[id]; !ok {
+		return db_service.ErrNotFound
+	}
+	delete(m.documents, id)
+	for i, other := range m.ids {
+		if other == id {
+			m.ids = append(m.ids[:i], m.ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// newTestClient returns client of the patients API served by the real
+// handlers from the database
+func newTestClient(t *testing.T, db db_service.DbService[mdm.Patient], options ...Option) *Client {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("db_service", db)
+		c.Set("patients_db_service", db)
+		c.Next()
+	})
+	patientsAPI := mdm.NewPatientsAPI()
+	engine.GET("/api/patients", patientsAPI.GetAllPatients)
+	engine.POST("/api/patients", patientsAPI.CreatePatient)
+	engine.GET("/api/patients/:patientId", patientsAPI.GetPatient)
+	engine.PUT("/api/patients/:patientId", patientsAPI.UpdatePatient)
+	engine.DELETE("/api/patients/:patientId", patientsAPI.DeletePatient)
+
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	client, err := New(server.URL+"/api", options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func testPatient(id string) *Patient {
+	return &Patient{
+		Id:              id,
+		FirstName:       "Jana",
+		LastName:        "Nováková",
+		DateOfBirth:     "1980-05-12",
+		Gender:          "Female",
+		InsuranceNumber: "INS-" + id,
+		Status:          "Active",
+	}
+}
+
+func TestPatientsCrud(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newMemoryDb[mdm.Patient]())
+
+	created, err := client.CreatePatient(ctx, testPatient("pat1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Id != "pat1" || created.CreatedAt.IsZero() {
+		t.Fatalf("created patient %+v", created)
+	}
+
+	patient, err := client.GetPatient(ctx, "pat1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patient.LastName != "Nováková" {
+		t.Fatalf("patient %+v", patient)
+	}
+
+	patient.Status = "Critical"
+	updated, err := client.UpdatePatient(ctx, "pat1", patient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != "Critical" {
+		t.Fatalf("updated patient %+v", updated)
+	}
+
+	if err := client.DeletePatient(ctx, "pat1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetPatient(ctx, "pat1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("get of deleted patient returned %v, want ErrNotFound", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newMemoryDb[mdm.Patient]())
+	if _, err := client.CreatePatient(ctx, testPatient("pat1")); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		call       func() error
+		target     error
+		statusCode int
+	}{
+		{"missing fields", func() error {
+			_, err := client.CreatePatient(ctx, &Patient{FirstName: "Jana"})
+			return err
+		}, ErrBadRequest, http.StatusBadRequest},
+		{"id mismatch", func() error {
+			_, err := client.UpdatePatient(ctx, "pat1", testPatient("pat2"))
+			return err
+		}, ErrForbidden, http.StatusForbidden},
+		{"unknown patient", func() error {
+			_, err := client.GetPatient(ctx, "unknown")
+			return err
+		}, ErrNotFound, http.StatusNotFound},
+		{"existing patient", func() error {
+			_, err := client.CreatePatient(ctx, testPatient("pat1"))
+			return err
+		}, ErrConflict, http.StatusConflict},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.call()
+			if !errors.Is(err, c.target) {
+				t.Fatalf("error %v does not match %v", err, c.target)
+			}
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error %T is not *Error", err)
+			}
+			if apiErr.StatusCode != c.statusCode || apiErr.Message == "" || apiErr.Status == "" {
+				t.Fatalf("error %+v, want status %d with the message of the server", apiErr, c.statusCode)
+			}
+		})
+	}
+}
+
+func TestRetriesUnavailable(t *testing.T) {
+	ctx := context.Background()
+	db := newMemoryDb[mdm.Patient]()
+	// Retry-After of the server overrides the hour long backoff
+	client := newTestClient(t, db, WithRetries(3, time.Hour))
+	if _, err := client.CreatePatient(ctx, testPatient("pat1")); err != nil {
+		t.Fatal(err)
+	}
+
+	failures := 2
+	db.fault = func(operation string) error {
+		if operation == "find" && failures > 0 {
+			failures--
+			return &db_service.UnavailableError{RetryAfter: 0, Err: errors.New("primary stepped down")}
+		}
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetPatient(ctx, "pat1")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Retry-After of the server was not honoured")
+	}
+	if db.calls["find"] != 3 {
+		t.Fatalf("find called %d times, want 3", db.calls["find"])
+	}
+}
+
+func TestRetriesBadGatewayUntilExhausted(t *testing.T) {
+	ctx := context.Background()
+	db := newMemoryDb[mdm.Patient]()
+	db.fault = func(string) error { return errors.New("connection reset") }
+	client := newTestClient(t, db, WithRetries(2, time.Millisecond))
+
+	_, err := client.ListPatients(ctx, nil)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway || !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("error %v, want 502 matching ErrUnavailable", err)
+	}
+	if db.calls["find_all"] != 3 {
+		t.Fatalf("find_all called %d times, want 3", db.calls["find_all"])
+	}
+}
+
+func TestDoesNotRetryPost(t *testing.T) {
+	ctx := context.Background()
+	db := newMemoryDb[mdm.Patient]()
+	db.fault = func(string) error { return &db_service.UnavailableError{Err: errors.New("no primary")} }
+	client := newTestClient(t, db, WithRetries(3, time.Millisecond))
+
+	_, err := client.CreatePatient(ctx, testPatient("pat1"))
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("error %v, want ErrUnavailable", err)
+	}
+	if db.calls["create"] != 1 {
+		t.Fatalf("create called %d times, want 1", db.calls["create"])
+	}
+}
+
+func TestPagination(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, newMemoryDb[mdm.Patient]())
+	for i := 1; i <= 5; i++ {
+		if _, err := client.CreatePatient(ctx, testPatient(fmt.Sprintf("pat%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, err := client.ListPatients(ctx, &ListOptions{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.Total != 5 || !page.HasNext() || page.NextOffset() != 2 {
+		t.Fatalf("first page %+v", page)
+	}
+
+	page, err = client.ListPatients(ctx, &ListOptions{Limit: 2, Offset: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 1 || page.Items[0].Id != "pat5" || page.HasNext() {
+		t.Fatalf("last page %+v", page)
+	}
+
+	ids := []string{}
+	for patient, err := range client.AllPatients(ctx, 2) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, patient.Id)
+	}
+	if fmt.Sprint(ids) != "[pat1 pat2 pat3 pat4 pat5]" {
+		t.Fatalf("iterated %v", ids)
+	}
+}
+
+func TestPaginateStopsAtError(t *testing.T) {
+	failure := errors.New("failed")
+	fetched := 0
+	items := paginate(2, func(options *ListOptions) (*Page[int], error) {
+		fetched++
+		if options.Offset > 0 {
+			return nil, failure
+		}
+		return &Page[int]{Items: []int{1, 2}, Offset: 0, Total: 4}, nil
+	})
+
+	var values []int
+	var last error
+	for value, err := range items {
+		if err != nil {
+			last = err
+			continue
+		}
+		values = append(values, value)
+	}
+	if fmt.Sprint(values) != "[1 2]" || last != failure || fetched != 2 {
+		t.Fatalf("values %v, error %v, fetched %d pages", values, last, fetched)
+	}
+}
+
+func TestPageHasNext(t *testing.T) {
+	cases := []struct {
+		page Page[int]
+		want bool
+	}{
+		{Page[int]{Items: []int{1, 2}, Offset: 0, Total: 3}, true},
+		{Page[int]{Items: []int{3}, Offset: 2, Total: 3}, false},
+		{Page[int]{Items: []int{}, Offset: 3, Total: 5}, false},
+	}
+	for _, c := range cases {
+		if got := c.page.HasNext(); got != c.want {
+			t.Errorf("HasNext of %+v = %v, want %v", c.page, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// SearchAtcCodes returns medicinal substances whose ATC code starts with the
+// query or whose name contains its words, at most limit of them; the server
+// default applies when limit is 0
+func (c *Client) SearchAtcCodes(ctx context.Context, query string, limit int) ([]AtcCode, error) {
+	values := url.Values{"q": {query}}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	var substances []AtcCode
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(values, "codes", "atc"), nil, &substances); err != nil {
+		return nil, err
+	}
+	return substances, nil
+}
+
+// SearchIcd10Codes returns ICD-10 diagnoses whose code starts with the query
+// or whose name contains its words, at most limit of them; the server default
+// applies when limit is 0
+func (c *Client) SearchIcd10Codes(ctx context.Context, query string, limit int) ([]CodedDiagnosis, error) {
+	values := url.Values{"q": {query}}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	var diagnoses []CodedDiagnosis
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(values, "codes", "icd10"), nil, &diagnoses); err != nil {
+		return nil, err
+	}
+	return diagnoses, nil
+}
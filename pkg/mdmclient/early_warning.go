@@ -0,0 +1,33 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// GetEarlyWarningScore returns the NEWS2 score of the latest vital signs of
+// the patient
+func (c *Client) GetEarlyWarningScore(ctx context.Context, patientId string) (*EarlyWarningScore, error) {
+	score := &EarlyWarningScore{}
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(nil, "patients", patientId, "early-warning"), nil, score); err != nil {
+		return nil, err
+	}
+	return score, nil
+}
+
+// ListEarlyWarningScores returns NEWS2 scores of the patients who are not
+// discharged from the highest score, only those scoring at least minScore
+// if it is positive
+func (c *Client) ListEarlyWarningScores(ctx context.Context, minScore int) ([]EarlyWarningScore, error) {
+	query := url.Values{}
+	if minScore > 0 {
+		query.Set("minScore", strconv.Itoa(minScore))
+	}
+	var scores []EarlyWarningScore
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(query, "early-warning"), nil, &scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
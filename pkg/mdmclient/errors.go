@@ -0,0 +1,77 @@
+package mdmclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+var (
+	ErrBadRequest  = errors.New("bad request")
+	ErrForbidden   = errors.New("forbidden")
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrUnavailable = errors.New("service unavailable")
+	// ErrAllergyConflict is returned when prescribed medications conflict
+	// with allergies of the patient, see MedicalRecord.AllergyOverride
+	ErrAllergyConflict = errors.New("medications conflict with allergies")
+)
+
+// Error is returned for responses with 4xx and 5xx status codes. It carries
+// the error body of the server and matches ErrBadRequest, ErrForbidden,
+// ErrNotFound, ErrConflict, ErrAllergyConflict or ErrUnavailable with
+// errors.Is.
+type Error struct {
+	// HTTP status code of the response
+	StatusCode int `json:"-"`
+	// Status text reported by the server, e.g. "Not Found"
+	Status string `json:"status"`
+	// Human readable description of the failure
+	Message string `json:"message"`
+	// Underlying error reported by the server, if any
+	Detail string `json:"error,omitempty"`
+	// Medications conflicting with allergies of the patient, if any
+	Conflicts []AllergyWarning `json:"conflicts,omitempty"`
+}
+
+func newError(resp *http.Response) error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = string(body)
+	}
+	if apiErr.Status == "" {
+		apiErr.Status = http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	message := e.Status
+	if e.Message != "" {
+		message += ": " + e.Message
+	}
+	if e.Detail != "" {
+		message += " (" + e.Detail + ")"
+	}
+	return message
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrAllergyConflict:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusBadGateway || e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
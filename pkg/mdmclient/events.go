@@ -0,0 +1,132 @@
+package mdmclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventFilter restricts streamed events, empty filter streams all events
+type EventFilter struct {
+	PatientIds []string
+	// Statuses of patients, medical record events are not streamed when set
+	Statuses []string
+}
+
+func (f EventFilter) query() url.Values {
+	query := url.Values{}
+	for _, patientId := range f.PatientIds {
+		query.Add("patientId", patientId)
+	}
+	for _, status := range f.Statuses {
+		query.Add("status", status)
+	}
+	return query
+}
+
+// StreamEvents receives Server-Sent Events and calls handle for each of them
+// until ctx is cancelled, the stream ends or handle returns an error, which is
+// returned. Streams are not retried, callers reconnect as needed.
+func (c *Client) StreamEvents(ctx context.Context, filter EventFilter, handle func(Event) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint(filter.query(), "events"), nil)
+	if err != nil {
+		return err
+	}
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return newError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return fmt.Errorf("failed to decode event: %w", err)
+			}
+			data.Reset()
+			if err := handle(event); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return scanner.Err()
+}
+
+// StreamEventsWebSocket receives events over WebSocket and calls handle for
+// each of them until ctx is cancelled, the connection is closed or handle
+// returns an error, which is returned.
+func (c *Client) StreamEventsWebSocket(ctx context.Context, filter EventFilter, handle func(Event) error) error {
+	endpoint, _ := url.Parse(c.endpoint(filter.query(), "events", "ws"))
+	if endpoint.Scheme == "https" {
+		endpoint.Scheme = "wss"
+	} else {
+		endpoint.Scheme = "ws"
+	}
+
+	dialer := websocket.Dialer{Proxy: http.ProxyFromEnvironment}
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+	}
+	conn, resp, err := dialer.DialContext(ctx, endpoint.String(), c.headers.Clone())
+	if err != nil {
+		if resp != nil && resp.StatusCode >= 400 {
+			return newError(resp)
+		}
+		return err
+	}
+	defer conn.Close()
+
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	for {
+		var event Event
+		if err := conn.ReadJSON(&event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			var closeErr *websocket.CloseError
+			if errors.As(err, &closeErr) && closeErr.Code == websocket.CloseNormalClosure {
+				return nil
+			}
+			return err
+		}
+		if err := handle(event); err != nil {
+			return err
+		}
+	}
+}
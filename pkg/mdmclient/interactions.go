@@ -0,0 +1,21 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// CheckInteractions checks the medications for interactions with each other
+// and, when patientId is not empty, with medications the patient actively
+// takes, without creating any record
+func (c *Client) CheckInteractions(ctx context.Context, patientId string, medications []Medication) (*InteractionCheckResult, error) {
+	request := struct {
+		PatientId   string       `json:"patientId,omitempty"`
+		Medications []Medication `json:"medications"`
+	}{PatientId: patientId, Medications: medications}
+	var result InteractionCheckResult
+	if _, err := c.do(ctx, http.MethodPost, c.endpoint(nil, "interactions:check"), request, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
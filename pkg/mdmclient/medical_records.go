@@ -0,0 +1,53 @@
+package mdmclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// ListMedicalRecords returns a page of the patient's medical records, all
+// records if options are nil
+func (c *Client) ListMedicalRecords(ctx context.Context, patientId string, options *ListOptions) (*Page[MedicalRecord], error) {
+	var records []MedicalRecord
+	endpoint := c.endpoint(options.query(), "patients", patientId, "medical-records")
+	resp, err := c.do(ctx, http.MethodGet, endpoint, nil, &records)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(resp, records, options), nil
+}
+
+// AllMedicalRecords iterates over all medical records of the patient, loading
+// them in pages of given size
+func (c *Client) AllMedicalRecords(ctx context.Context, patientId string, pageSize int) iter.Seq2[MedicalRecord, error] {
+	return paginate(pageSize, func(options *ListOptions) (*Page[MedicalRecord], error) {
+		return c.ListMedicalRecords(ctx, patientId, options)
+	})
+}
+
+// CreateMedicalRecord creates the record and returns it as stored by the server
+func (c *Client) CreateMedicalRecord(ctx context.Context, patientId string, record *MedicalRecord) (*MedicalRecord, error) {
+	created := &MedicalRecord{}
+	endpoint := c.endpoint(nil, "patients", patientId, "medical-records")
+	if _, err := c.do(ctx, http.MethodPost, endpoint, record, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateMedicalRecord replaces the record and returns it as stored by the server
+func (c *Client) UpdateMedicalRecord(ctx context.Context, patientId string, recordId string, record *MedicalRecord) (*MedicalRecord, error) {
+	updated := &MedicalRecord{}
+	endpoint := c.endpoint(nil, "patients", patientId, "medical-records", recordId)
+	if _, err := c.do(ctx, http.MethodPut, endpoint, record, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Client) DeleteMedicalRecord(ctx context.Context, patientId string, recordId string) error {
+	endpoint := c.endpoint(nil, "patients", patientId, "medical-records", recordId)
+	_, err := c.do(ctx, http.MethodDelete, endpoint, nil, nil)
+	return err
+}
@@ -0,0 +1,34 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ListMedications returns medications prescribed to the patient in all the
+// medical records from the latest started, only those the patient currently
+// takes if activeOnly is set
+func (c *Client) ListMedications(ctx context.Context, patientId string, activeOnly bool) ([]PatientMedication, error) {
+	query := url.Values{}
+	if activeOnly {
+		query.Set("active", "true")
+	}
+	var medications []PatientMedication
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(query, "patients", patientId, "medications"), nil, &medications); err != nil {
+		return nil, err
+	}
+	return medications, nil
+}
+
+// ReconcileMedications continues, changes or stops medications of former
+// visits at the visit of the record and returns the record as updated by the
+// server
+func (c *Client) ReconcileMedications(ctx context.Context, patientId string, recordId string, reconciliations []MedicationReconciliation) (*MedicalRecord, error) {
+	updated := &MedicalRecord{}
+	endpoint := c.endpoint(nil, "patients", patientId, "medical-records", recordId, "reconciliation")
+	if _, err := c.do(ctx, http.MethodPost, endpoint, reconciliations, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
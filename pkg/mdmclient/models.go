@@ -0,0 +1,262 @@
+package mdmclient
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Models mirror schemas of api/mdm.openapi.yaml
+
+type Patient struct {
+	Id               string            `json:"id"`
+	FirstName        string            `json:"firstName"`
+	LastName         string            `json:"lastName"`
+	DateOfBirth      string            `json:"dateOfBirth"`
+	Gender           string            `json:"gender"`
+	InsuranceNumber  string            `json:"insuranceNumber"`
+	BloodType        string            `json:"bloodType,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	Allergies        []Allergy         `json:"allergies,omitempty"`
+	MedicalNotes     string            `json:"medicalNotes,omitempty"`
+	Address          *Address          `json:"address,omitempty"`
+	EmergencyContact *EmergencyContact `json:"emergencyContact,omitempty"`
+	CreatedAt        time.Time         `json:"createdAt,omitempty"`
+	UpdatedAt        time.Time         `json:"updatedAt,omitempty"`
+	// score of the latest vital signs returned by GetPatient, it is ignored
+	// when the patient is created or updated
+	EarlyWarning *PatientEarlyWarning `json:"earlyWarning,omitempty"`
+}
+
+// Allergy of the patient, Severity is Mild, Moderate, Severe or empty when
+// unknown
+type Allergy struct {
+	Substance string `json:"substance"`
+	Reaction  string `json:"reaction,omitempty"`
+	Severity  string `json:"severity,omitempty"`
+	Verified  bool   `json:"verified,omitempty"`
+}
+
+type Address struct {
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+type EmergencyContact struct {
+	Name         string `json:"name,omitempty"`
+	Relationship string `json:"relationship,omitempty"`
+	PhoneNumber  string `json:"phoneNumber,omitempty"`
+}
+
+type MedicalRecord struct {
+	Id                 string                     `json:"id"`
+	PatientId          string                     `json:"patientId"`
+	DateOfVisit        time.Time                  `json:"dateOfVisit"`
+	Diagnosis          string                     `json:"diagnosis"`
+	PrimaryDiagnosis   *CodedDiagnosis            `json:"primaryDiagnosis,omitempty"`
+	SecondaryDiagnoses []CodedDiagnosis           `json:"secondaryDiagnoses,omitempty"`
+	Symptoms           []string                   `json:"symptoms,omitempty"`
+	Treatment          string                     `json:"treatment,omitempty"`
+	Medications        []Medication               `json:"medications,omitempty"`
+	AllergyOverride    bool                       `json:"allergyOverride,omitempty"`
+	AllergyWarnings    []AllergyWarning           `json:"allergyWarnings,omitempty"`
+	Interactions       []DrugInteraction          `json:"interactions,omitempty"`
+	Reconciliations    []MedicationReconciliation `json:"reconciliations,omitempty"`
+	DoctorName         string                     `json:"doctorName,omitempty"`
+	Notes              string                     `json:"notes,omitempty"`
+	FollowUpDate       string                     `json:"followUpDate,omitempty"`
+	CreatedAt          time.Time                  `json:"createdAt,omitempty"`
+	UpdatedAt          time.Time                  `json:"updatedAt,omitempty"`
+}
+
+// CodedDiagnosis is a diagnosis coded by ICD-10 (MKCH-10), the server fills
+// in the display text
+type CodedDiagnosis struct {
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// AllergyWarning is a medication conflicting with allergy of the patient
+type AllergyWarning struct {
+	Medication string `json:"medication"`
+	Substance  string `json:"substance"`
+	Severity   string `json:"severity,omitempty"`
+	Verified   bool   `json:"verified,omitempty"`
+}
+
+type Medication struct {
+	Id        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	AtcCode   string `json:"atcCode,omitempty"`
+	Dosage    string `json:"dosage,omitempty"`
+	Frequency string `json:"frequency,omitempty"`
+	Duration  string `json:"duration,omitempty"`
+	// First and last day of the treatment as YYYY-MM-DD, the server starts
+	// it on the day of the visit and ends it after Duration if not given
+	StartDate string `json:"startDate,omitempty"`
+	EndDate   string `json:"endDate,omitempty"`
+	// Continued, Changed or Stopped by the last reconciliation
+	Status string `json:"status,omitempty"`
+}
+
+// Actions of MedicationReconciliation
+const (
+	MedicationContinued = "Continued"
+	MedicationChanged   = "Changed"
+	MedicationStopped   = "Stopped"
+)
+
+// MedicationReconciliation continues, changes or stops a medication of
+// a former visit at the visit of the record. Changed medication is replaced
+// by Medication prescribed at the visit.
+type MedicationReconciliation struct {
+	MedicationId string      `json:"medicationId"`
+	RecordId     string      `json:"recordId,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	Action       string      `json:"action"`
+	Medication   *Medication `json:"medication,omitempty"`
+	Reason       string      `json:"reason,omitempty"`
+}
+
+// PatientMedication is a medication prescribed to the patient in the record
+type PatientMedication struct {
+	RecordId    string     `json:"recordId"`
+	DateOfVisit time.Time  `json:"dateOfVisit"`
+	Active      bool       `json:"active"`
+	Medication  Medication `json:"medication"`
+}
+
+// Types of VitalSign
+const (
+	VitalSystolicPressure  = "systolicPressure"
+	VitalDiastolicPressure = "diastolicPressure"
+	VitalHeartRate         = "heartRate"
+	VitalTemperature       = "temperature"
+	VitalSpO2              = "spo2"
+	VitalWeight            = "weight"
+	VitalRespiratoryRate   = "respiratoryRate"
+	// level of consciousness on the ACVPU scale, 0 alert, 1 new confusion,
+	// 2 voice, 3 pain, 4 unresponsive
+	VitalConsciousness = "consciousness"
+	// flow of supplemental oxygen in L/min, zero when breathing air
+	VitalSupplementalOxygen = "supplementalOxygen"
+)
+
+// VitalSign is a vital sign of the patient, the value is converted to the
+// standard unit of its type by the server
+type VitalSign struct {
+	PatientId  string    `json:"patientId,omitempty"`
+	Type       string    `json:"type"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit,omitempty"`
+	MeasuredAt time.Time `json:"measuredAt"`
+	Source     string    `json:"source,omitempty"`
+}
+
+// VitalSignSummary summarizes vital signs of the type measured within the
+// interval starting at Start
+type VitalSignSummary struct {
+	Type    string    `json:"type"`
+	Unit    string    `json:"unit"`
+	Start   time.Time `json:"start"`
+	Count   int       `json:"count"`
+	Average float64   `json:"average"`
+	Min     float64   `json:"min"`
+	Max     float64   `json:"max"`
+}
+
+// EarlyWarningScore is the NEWS2 score of the latest vital signs of the
+// patient. ProposedStatus is Critical when the score reached the threshold
+// configured at the server.
+type EarlyWarningScore struct {
+	PatientId      string                  `json:"patientId"`
+	Status         string                  `json:"status,omitempty"`
+	Score          int                     `json:"score"`
+	Risk           string                  `json:"risk,omitempty"`
+	Complete       bool                    `json:"complete"`
+	Missing        []string                `json:"missing,omitempty"`
+	Parameters     []EarlyWarningParameter `json:"parameters"`
+	ProposedStatus string                  `json:"proposedStatus,omitempty"`
+	CalculatedAt   time.Time               `json:"calculatedAt"`
+}
+
+// PatientEarlyWarning is the NEWS2 score of the latest vital signs returned
+// with the patient, see EarlyWarningScore for its parameters
+type PatientEarlyWarning struct {
+	Score          int       `json:"score"`
+	Risk           string    `json:"risk"`
+	Complete       bool      `json:"complete"`
+	ProposedStatus string    `json:"proposedStatus,omitempty"`
+	CalculatedAt   time.Time `json:"calculatedAt"`
+}
+
+// EarlyWarningParameter is the latest vital sign of a NEWS2 parameter with
+// its score
+type EarlyWarningParameter struct {
+	Type       string    `json:"type"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit"`
+	MeasuredAt time.Time `json:"measuredAt"`
+	Score      int       `json:"score"`
+}
+
+// DrugInteraction is an interaction of a medication with another one of the
+// same record or with a medication the patient actively takes, the record of
+// which is in InteractsWithRecordId
+type DrugInteraction struct {
+	Medication            string `json:"medication"`
+	AtcCode               string `json:"atcCode"`
+	InteractsWith         string `json:"interactsWith"`
+	InteractsWithAtcCode  string `json:"interactsWithAtcCode"`
+	InteractsWithRecordId string `json:"interactsWithRecordId,omitempty"`
+	Severity              string `json:"severity"`
+	Description           string `json:"description,omitempty"`
+}
+
+// InteractionCheckResult lists the checked medications with their ATC codes
+// filled in and their interactions from the most severe
+type InteractionCheckResult struct {
+	Medications  []Medication      `json:"medications"`
+	Interactions []DrugInteraction `json:"interactions"`
+}
+
+// AtcCode is a medicinal substance of the ATC classification
+type AtcCode struct {
+	Code    string `json:"code"`
+	Display string `json:"display"`
+}
+
+type WebhookSubscription struct {
+	Id          string    `json:"id"`
+	Url         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	EventTypes  []string  `json:"eventTypes"`
+	Active      bool      `json:"active"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"createdAt,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt,omitempty"`
+}
+
+type WebhookDelivery struct {
+	Id             string    `json:"id"`
+	SubscriptionId string    `json:"subscriptionId"`
+	EventId        string    `json:"eventId"`
+	EventType      string    `json:"eventType"`
+	Status         string    `json:"status"`
+	Attempts       int32     `json:"attempts,omitempty"`
+	ResponseStatus int32     `json:"responseStatus,omitempty"`
+	LastError      string    `json:"lastError,omitempty"`
+	LastAttemptAt  time.Time `json:"lastAttemptAt,omitempty"`
+	NextAttemptAt  time.Time `json:"nextAttemptAt,omitempty"`
+	CreatedAt      time.Time `json:"createdAt,omitempty"`
+}
+
+type Event struct {
+	Id         string          `json:"id"`
+	Type       string          `json:"type"`
+	PatientId  string          `json:"patientId"`
+	RecordId   string          `json:"recordId,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data,omitempty"`
+}
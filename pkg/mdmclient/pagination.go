@@ -0,0 +1,86 @@
+package mdmclient
+
+import (
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ListOptions selects a page of list operations. Zero Limit returns all the
+// items from Offset.
+type ListOptions struct {
+	Limit  int
+	Offset int
+}
+
+func (o *ListOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return query
+}
+
+// Page is one page of a list operation
+type Page[T any] struct {
+	Items []T
+	// Offset of the first item of the page
+	Offset int
+	// Total number of items regardless of the paging
+	Total int
+}
+
+// HasNext reports whether there are items after this page
+func (p *Page[T]) HasNext() bool {
+	return len(p.Items) > 0 && p.Offset+len(p.Items) < p.Total
+}
+
+// NextOffset is the offset of the page following this one
+func (p *Page[T]) NextOffset() int {
+	return p.Offset + len(p.Items)
+}
+
+func newPage[T any](resp *http.Response, items []T, options *ListOptions) *Page[T] {
+	page := &Page[T]{Items: items, Total: len(items)}
+	if options != nil {
+		page.Offset = options.Offset
+	}
+	if total, err := strconv.Atoi(resp.Header.Get("X-Total-Count")); err == nil {
+		page.Total = total
+	} else {
+		page.Total += page.Offset
+	}
+	return page
+}
+
+// paginate iterates over all items, fetching pages of given size one by one.
+// Iteration stops at the first error, which is yielded with zero item.
+func paginate[T any](pageSize int, fetch func(options *ListOptions) (*Page[T], error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		options := &ListOptions{Limit: pageSize}
+		for {
+			page, err := fetch(options)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasNext() {
+				return
+			}
+			options = &ListOptions{Limit: pageSize, Offset: page.NextOffset()}
+		}
+	}
+}
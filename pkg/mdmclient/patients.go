@@ -0,0 +1,55 @@
+package mdmclient
+
+import (
+	"context"
+	"iter"
+	"net/http"
+)
+
+// ListPatients returns a page of patients, all patients if options are nil
+func (c *Client) ListPatients(ctx context.Context, options *ListOptions) (*Page[Patient], error) {
+	var patients []Patient
+	resp, err := c.do(ctx, http.MethodGet, c.endpoint(options.query(), "patients"), nil, &patients)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(resp, patients, options), nil
+}
+
+// AllPatients iterates over all patients, loading them in pages of given size
+func (c *Client) AllPatients(ctx context.Context, pageSize int) iter.Seq2[Patient, error] {
+	return paginate(pageSize, func(options *ListOptions) (*Page[Patient], error) {
+		return c.ListPatients(ctx, options)
+	})
+}
+
+func (c *Client) GetPatient(ctx context.Context, patientId string) (*Patient, error) {
+	patient := &Patient{}
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(nil, "patients", patientId), nil, patient); err != nil {
+		return nil, err
+	}
+	return patient, nil
+}
+
+// CreatePatient creates the patient and returns it as stored by the server
+func (c *Client) CreatePatient(ctx context.Context, patient *Patient) (*Patient, error) {
+	created := &Patient{}
+	if _, err := c.do(ctx, http.MethodPost, c.endpoint(nil, "patients"), patient, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdatePatient replaces the patient and returns it as stored by the server
+func (c *Client) UpdatePatient(ctx context.Context, patientId string, patient *Patient) (*Patient, error) {
+	updated := &Patient{}
+	if _, err := c.do(ctx, http.MethodPut, c.endpoint(nil, "patients", patientId), patient, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Client) DeletePatient(ctx context.Context, patientId string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.endpoint(nil, "patients", patientId), nil, nil)
+	return err
+}
@@ -0,0 +1,64 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// VitalsOptions selects vital signs by their types, all types when empty,
+// and by the range of time, which spans the last week when zero
+type VitalsOptions struct {
+	Types []string
+	From  time.Time
+	To    time.Time
+}
+
+func (o VitalsOptions) query() url.Values {
+	query := url.Values{}
+	for _, vitalType := range o.Types {
+		query.Add("type", vitalType)
+	}
+	if !o.From.IsZero() {
+		query.Set("from", o.From.Format(time.RFC3339))
+	}
+	if !o.To.IsZero() {
+		query.Set("to", o.To.Format(time.RFC3339))
+	}
+	return query
+}
+
+// RecordVitals stores the batch of vital signs of the patient and returns
+// them as recorded by the server
+func (c *Client) RecordVitals(ctx context.Context, patientId string, vitals []VitalSign) ([]VitalSign, error) {
+	var recorded []VitalSign
+	if _, err := c.do(ctx, http.MethodPost, c.endpoint(nil, "patients", patientId, "vitals"), vitals, &recorded); err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+// ListVitals returns vital signs of the patient selected by the options from
+// the oldest
+func (c *Client) ListVitals(ctx context.Context, patientId string, opts VitalsOptions) ([]VitalSign, error) {
+	var vitals []VitalSign
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(opts.query(), "patients", patientId, "vitals"), nil, &vitals); err != nil {
+		return nil, err
+	}
+	return vitals, nil
+}
+
+// SummarizeVitals returns vital signs of the patient selected by the options
+// downsampled to intervals, the server chooses the interval when zero
+func (c *Client) SummarizeVitals(ctx context.Context, patientId string, opts VitalsOptions, interval time.Duration) ([]VitalSignSummary, error) {
+	query := opts.query()
+	if interval > 0 {
+		query.Set("interval", interval.String())
+	}
+	var summaries []VitalSignSummary
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(query, "patients", patientId, "vitals", "summary"), nil, &summaries); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
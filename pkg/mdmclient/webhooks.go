@@ -0,0 +1,55 @@
+package mdmclient
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(nil, "webhooks"), nil, &subscriptions); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (c *Client) GetWebhookSubscription(ctx context.Context, subscriptionId string) (*WebhookSubscription, error) {
+	subscription := &WebhookSubscription{}
+	if _, err := c.do(ctx, http.MethodGet, c.endpoint(nil, "webhooks", subscriptionId), nil, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// CreateWebhookSubscription registers the subscription. The returned
+// subscription carries the signing secret, which is not returned later.
+func (c *Client) CreateWebhookSubscription(ctx context.Context, subscription *WebhookSubscription) (*WebhookSubscription, error) {
+	created := &WebhookSubscription{}
+	if _, err := c.do(ctx, http.MethodPost, c.endpoint(nil, "webhooks"), subscription, created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateWebhookSubscription replaces the subscription, empty secret keeps the current one
+func (c *Client) UpdateWebhookSubscription(ctx context.Context, subscriptionId string, subscription *WebhookSubscription) (*WebhookSubscription, error) {
+	updated := &WebhookSubscription{}
+	if _, err := c.do(ctx, http.MethodPut, c.endpoint(nil, "webhooks", subscriptionId), subscription, updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (c *Client) DeleteWebhookSubscription(ctx context.Context, subscriptionId string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.endpoint(nil, "webhooks", subscriptionId), nil, nil)
+	return err
+}
+
+func (c *Client) ListWebhookDeliveries(ctx context.Context, subscriptionId string) ([]WebhookDelivery, error) {
+	var deliveries []WebhookDelivery
+	endpoint := c.endpoint(nil, "webhooks", subscriptionId, "deliveries")
+	if _, err := c.do(ctx, http.MethodGet, endpoint, nil, &deliveries); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
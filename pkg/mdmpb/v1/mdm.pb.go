@@ -0,0 +1,2068 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: mdm/v1/mdm.proto
+
+// Patient and Medical Records management for Web-In-Cloud system.
+// Messages mirror schemas of api/mdm.openapi.yaml.
+
+package mdmpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Street        string                 `protobuf:"bytes,1,opt,name=street,proto3" json:"street,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,3,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type EmergencyContact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Relationship  string                 `protobuf:"bytes,2,opt,name=relationship,proto3" json:"relationship,omitempty"`
+	PhoneNumber   string                 `protobuf:"bytes,3,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmergencyContact) Reset() {
+	*x = EmergencyContact{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmergencyContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyContact) ProtoMessage() {}
+
+func (x *EmergencyContact) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyContact.ProtoReflect.Descriptor instead.
+func (*EmergencyContact) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EmergencyContact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EmergencyContact) GetRelationship() string {
+	if x != nil {
+		return x.Relationship
+	}
+	return ""
+}
+
+func (x *EmergencyContact) GetPhoneNumber() string {
+	if x != nil {
+		return x.PhoneNumber
+	}
+	return ""
+}
+
+type Allergy struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Substance string                 `protobuf:"bytes,1,opt,name=substance,proto3" json:"substance,omitempty"`
+	Reaction  string                 `protobuf:"bytes,2,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	// Mild, Moderate or Severe, unknown when empty
+	Severity      string `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Verified      bool   `protobuf:"varint,4,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Allergy) Reset() {
+	*x = Allergy{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Allergy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Allergy) ProtoMessage() {}
+
+func (x *Allergy) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allergy.ProtoReflect.Descriptor instead.
+func (*Allergy) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Allergy) GetSubstance() string {
+	if x != nil {
+		return x.Substance
+	}
+	return ""
+}
+
+func (x *Allergy) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+func (x *Allergy) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Allergy) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+type Patient struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FirstName string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// date of birth in YYYY-MM-DD format
+	DateOfBirth string `protobuf:"bytes,4,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	// M - male, F - female, O - other
+	Gender string `protobuf:"bytes,5,opt,name=gender,proto3" json:"gender,omitempty"`
+	// insurance number (rodné číslo)
+	InsuranceNumber string `protobuf:"bytes,6,opt,name=insurance_number,json=insuranceNumber,proto3" json:"insurance_number,omitempty"`
+	BloodType       string `protobuf:"bytes,7,opt,name=blood_type,json=bloodType,proto3" json:"blood_type,omitempty"`
+	// Stable, Critical, Recovering or Discharged
+	Status           string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	MedicalNotes     string                 `protobuf:"bytes,10,opt,name=medical_notes,json=medicalNotes,proto3" json:"medical_notes,omitempty"`
+	Address          *Address               `protobuf:"bytes,11,opt,name=address,proto3" json:"address,omitempty"`
+	EmergencyContact *EmergencyContact      `protobuf:"bytes,12,opt,name=emergency_contact,json=emergencyContact,proto3" json:"emergency_contact,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Allergies        []*Allergy             `protobuf:"bytes,15,rep,name=allergies,proto3" json:"allergies,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Patient) Reset() {
+	*x = Patient{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Patient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Patient) ProtoMessage() {}
+
+func (x *Patient) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Patient.ProtoReflect.Descriptor instead.
+func (*Patient) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Patient) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Patient) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *Patient) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *Patient) GetDateOfBirth() string {
+	if x != nil {
+		return x.DateOfBirth
+	}
+	return ""
+}
+
+func (x *Patient) GetGender() string {
+	if x != nil {
+		return x.Gender
+	}
+	return ""
+}
+
+func (x *Patient) GetInsuranceNumber() string {
+	if x != nil {
+		return x.InsuranceNumber
+	}
+	return ""
+}
+
+func (x *Patient) GetBloodType() string {
+	if x != nil {
+		return x.BloodType
+	}
+	return ""
+}
+
+func (x *Patient) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Patient) GetMedicalNotes() string {
+	if x != nil {
+		return x.MedicalNotes
+	}
+	return ""
+}
+
+func (x *Patient) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Patient) GetEmergencyContact() *EmergencyContact {
+	if x != nil {
+		return x.EmergencyContact
+	}
+	return nil
+}
+
+func (x *Patient) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Patient) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Patient) GetAllergies() []*Allergy {
+	if x != nil {
+		return x.Allergies
+	}
+	return nil
+}
+
+type Medication struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Dosage    string                 `protobuf:"bytes,2,opt,name=dosage,proto3" json:"dosage,omitempty"`
+	Frequency string                 `protobuf:"bytes,3,opt,name=frequency,proto3" json:"frequency,omitempty"`
+	Duration  string                 `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`
+	// ATC code of the medicinal substance
+	AtcCode string `protobuf:"bytes,5,opt,name=atc_code,json=atcCode,proto3" json:"atc_code,omitempty"`
+	Id      string `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	// first and last day of the treatment as YYYY-MM-DD, no last day for
+	// long-term treatment
+	StartDate string `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate   string `protobuf:"bytes,8,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Continued, Changed or Stopped by the last reconciliation
+	Status        string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Medication) Reset() {
+	*x = Medication{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Medication) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Medication) ProtoMessage() {}
+
+func (x *Medication) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Medication.ProtoReflect.Descriptor instead.
+func (*Medication) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Medication) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Medication) GetDosage() string {
+	if x != nil {
+		return x.Dosage
+	}
+	return ""
+}
+
+func (x *Medication) GetFrequency() string {
+	if x != nil {
+		return x.Frequency
+	}
+	return ""
+}
+
+func (x *Medication) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *Medication) GetAtcCode() string {
+	if x != nil {
+		return x.AtcCode
+	}
+	return ""
+}
+
+func (x *Medication) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Medication) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *Medication) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *Medication) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// medication of a former visit reconciled at the visit of the record
+type MedicationReconciliation struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	MedicationId string                 `protobuf:"bytes,1,opt,name=medication_id,json=medicationId,proto3" json:"medication_id,omitempty"`
+	RecordId     string                 `protobuf:"bytes,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Continued, Changed or Stopped
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// medication replacing the changed one
+	Medication    *Medication `protobuf:"bytes,5,opt,name=medication,proto3" json:"medication,omitempty"`
+	Reason        string      `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MedicationReconciliation) Reset() {
+	*x = MedicationReconciliation{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MedicationReconciliation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MedicationReconciliation) ProtoMessage() {}
+
+func (x *MedicationReconciliation) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MedicationReconciliation.ProtoReflect.Descriptor instead.
+func (*MedicationReconciliation) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MedicationReconciliation) GetMedicationId() string {
+	if x != nil {
+		return x.MedicationId
+	}
+	return ""
+}
+
+func (x *MedicationReconciliation) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *MedicationReconciliation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MedicationReconciliation) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MedicationReconciliation) GetMedication() *Medication {
+	if x != nil {
+		return x.Medication
+	}
+	return nil
+}
+
+func (x *MedicationReconciliation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// diagnosis coded by ICD-10 (MKCH-10)
+type CodedDiagnosis struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Display       string                 `protobuf:"bytes,2,opt,name=display,proto3" json:"display,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CodedDiagnosis) Reset() {
+	*x = CodedDiagnosis{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodedDiagnosis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodedDiagnosis) ProtoMessage() {}
+
+func (x *CodedDiagnosis) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodedDiagnosis.ProtoReflect.Descriptor instead.
+func (*CodedDiagnosis) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CodedDiagnosis) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CodedDiagnosis) GetDisplay() string {
+	if x != nil {
+		return x.Display
+	}
+	return ""
+}
+
+// medication conflicting with allergy of the patient
+type AllergyWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Medication    string                 `protobuf:"bytes,1,opt,name=medication,proto3" json:"medication,omitempty"`
+	Substance     string                 `protobuf:"bytes,2,opt,name=substance,proto3" json:"substance,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	Verified      bool                   `protobuf:"varint,4,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllergyWarning) Reset() {
+	*x = AllergyWarning{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllergyWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllergyWarning) ProtoMessage() {}
+
+func (x *AllergyWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllergyWarning.ProtoReflect.Descriptor instead.
+func (*AllergyWarning) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AllergyWarning) GetMedication() string {
+	if x != nil {
+		return x.Medication
+	}
+	return ""
+}
+
+func (x *AllergyWarning) GetSubstance() string {
+	if x != nil {
+		return x.Substance
+	}
+	return ""
+}
+
+func (x *AllergyWarning) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *AllergyWarning) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+// interaction of the medication with another one of the record or actively
+// taken by the patient
+type DrugInteraction struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Medication           string                 `protobuf:"bytes,1,opt,name=medication,proto3" json:"medication,omitempty"`
+	AtcCode              string                 `protobuf:"bytes,2,opt,name=atc_code,json=atcCode,proto3" json:"atc_code,omitempty"`
+	InteractsWith        string                 `protobuf:"bytes,3,opt,name=interacts_with,json=interactsWith,proto3" json:"interacts_with,omitempty"`
+	InteractsWithAtcCode string                 `protobuf:"bytes,4,opt,name=interacts_with_atc_code,json=interactsWithAtcCode,proto3" json:"interacts_with_atc_code,omitempty"`
+	// record the interacting medication is taken from, empty within the record
+	InteractsWithRecordId string `protobuf:"bytes,5,opt,name=interacts_with_record_id,json=interactsWithRecordId,proto3" json:"interacts_with_record_id,omitempty"`
+	// Minor, Moderate, Major or Contraindicated
+	Severity      string `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`
+	Description   string `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrugInteraction) Reset() {
+	*x = DrugInteraction{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrugInteraction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrugInteraction) ProtoMessage() {}
+
+func (x *DrugInteraction) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrugInteraction.ProtoReflect.Descriptor instead.
+func (*DrugInteraction) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DrugInteraction) GetMedication() string {
+	if x != nil {
+		return x.Medication
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetAtcCode() string {
+	if x != nil {
+		return x.AtcCode
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetInteractsWith() string {
+	if x != nil {
+		return x.InteractsWith
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetInteractsWithAtcCode() string {
+	if x != nil {
+		return x.InteractsWithAtcCode
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetInteractsWithRecordId() string {
+	if x != nil {
+		return x.InteractsWithRecordId
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *DrugInteraction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type MedicalRecord struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PatientId   string                 `protobuf:"bytes,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	DateOfVisit *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date_of_visit,json=dateOfVisit,proto3" json:"date_of_visit,omitempty"`
+	Diagnosis   string                 `protobuf:"bytes,4,opt,name=diagnosis,proto3" json:"diagnosis,omitempty"`
+	Symptoms    []string               `protobuf:"bytes,5,rep,name=symptoms,proto3" json:"symptoms,omitempty"`
+	Treatment   string                 `protobuf:"bytes,6,opt,name=treatment,proto3" json:"treatment,omitempty"`
+	Medications []*Medication          `protobuf:"bytes,7,rep,name=medications,proto3" json:"medications,omitempty"`
+	DoctorName  string                 `protobuf:"bytes,8,opt,name=doctor_name,json=doctorName,proto3" json:"doctor_name,omitempty"`
+	Notes       string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	// follow-up date in YYYY-MM-DD format
+	FollowUpDate       string                 `protobuf:"bytes,10,opt,name=follow_up_date,json=followUpDate,proto3" json:"follow_up_date,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	PrimaryDiagnosis   *CodedDiagnosis        `protobuf:"bytes,13,opt,name=primary_diagnosis,json=primaryDiagnosis,proto3" json:"primary_diagnosis,omitempty"`
+	SecondaryDiagnoses []*CodedDiagnosis      `protobuf:"bytes,14,rep,name=secondary_diagnoses,json=secondaryDiagnoses,proto3" json:"secondary_diagnoses,omitempty"`
+	// medications conflicting with allergies of the patient are prescribed
+	// anyway, the conflicts are returned in allergy_warnings
+	AllergyOverride bool                        `protobuf:"varint,15,opt,name=allergy_override,json=allergyOverride,proto3" json:"allergy_override,omitempty"`
+	AllergyWarnings []*AllergyWarning           `protobuf:"bytes,16,rep,name=allergy_warnings,json=allergyWarnings,proto3" json:"allergy_warnings,omitempty"`
+	Interactions    []*DrugInteraction          `protobuf:"bytes,17,rep,name=interactions,proto3" json:"interactions,omitempty"`
+	Reconciliations []*MedicationReconciliation `protobuf:"bytes,18,rep,name=reconciliations,proto3" json:"reconciliations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MedicalRecord) Reset() {
+	*x = MedicalRecord{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MedicalRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MedicalRecord) ProtoMessage() {}
+
+func (x *MedicalRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MedicalRecord.ProtoReflect.Descriptor instead.
+func (*MedicalRecord) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MedicalRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetDateOfVisit() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DateOfVisit
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetDiagnosis() string {
+	if x != nil {
+		return x.Diagnosis
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetSymptoms() []string {
+	if x != nil {
+		return x.Symptoms
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetTreatment() string {
+	if x != nil {
+		return x.Treatment
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetMedications() []*Medication {
+	if x != nil {
+		return x.Medications
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetDoctorName() string {
+	if x != nil {
+		return x.DoctorName
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetFollowUpDate() string {
+	if x != nil {
+		return x.FollowUpDate
+	}
+	return ""
+}
+
+func (x *MedicalRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetPrimaryDiagnosis() *CodedDiagnosis {
+	if x != nil {
+		return x.PrimaryDiagnosis
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetSecondaryDiagnoses() []*CodedDiagnosis {
+	if x != nil {
+		return x.SecondaryDiagnoses
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetAllergyOverride() bool {
+	if x != nil {
+		return x.AllergyOverride
+	}
+	return false
+}
+
+func (x *MedicalRecord) GetAllergyWarnings() []*AllergyWarning {
+	if x != nil {
+		return x.AllergyWarnings
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetInteractions() []*DrugInteraction {
+	if x != nil {
+		return x.Interactions
+	}
+	return nil
+}
+
+func (x *MedicalRecord) GetReconciliations() []*MedicationReconciliation {
+	if x != nil {
+		return x.Reconciliations
+	}
+	return nil
+}
+
+type ListPatientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPatientsRequest) Reset() {
+	*x = ListPatientsRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPatientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPatientsRequest) ProtoMessage() {}
+
+func (x *ListPatientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPatientsRequest.ProtoReflect.Descriptor instead.
+func (*ListPatientsRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{10}
+}
+
+type ListPatientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patients      []*Patient             `protobuf:"bytes,1,rep,name=patients,proto3" json:"patients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPatientsResponse) Reset() {
+	*x = ListPatientsResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPatientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPatientsResponse) ProtoMessage() {}
+
+func (x *ListPatientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPatientsResponse.ProtoReflect.Descriptor instead.
+func (*ListPatientsResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListPatientsResponse) GetPatients() []*Patient {
+	if x != nil {
+		return x.Patients
+	}
+	return nil
+}
+
+type GetPatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPatientRequest) Reset() {
+	*x = GetPatientRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPatientRequest) ProtoMessage() {}
+
+func (x *GetPatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPatientRequest.ProtoReflect.Descriptor instead.
+func (*GetPatientRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetPatientRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+type GetPatientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPatientResponse) Reset() {
+	*x = GetPatientResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPatientResponse) ProtoMessage() {}
+
+func (x *GetPatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPatientResponse.ProtoReflect.Descriptor instead.
+func (*GetPatientResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPatientResponse) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+type CreatePatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePatientRequest) Reset() {
+	*x = CreatePatientRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePatientRequest) ProtoMessage() {}
+
+func (x *CreatePatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePatientRequest.ProtoReflect.Descriptor instead.
+func (*CreatePatientRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreatePatientRequest) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+type CreatePatientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePatientResponse) Reset() {
+	*x = CreatePatientResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePatientResponse) ProtoMessage() {}
+
+func (x *CreatePatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePatientResponse.ProtoReflect.Descriptor instead.
+func (*CreatePatientResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreatePatientResponse) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+type UpdatePatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	Patient       *Patient               `protobuf:"bytes,2,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePatientRequest) Reset() {
+	*x = UpdatePatientRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePatientRequest) ProtoMessage() {}
+
+func (x *UpdatePatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePatientRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePatientRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdatePatientRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *UpdatePatientRequest) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+type UpdatePatientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePatientResponse) Reset() {
+	*x = UpdatePatientResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePatientResponse) ProtoMessage() {}
+
+func (x *UpdatePatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePatientResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePatientResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdatePatientResponse) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+type DeletePatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePatientRequest) Reset() {
+	*x = DeletePatientRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePatientRequest) ProtoMessage() {}
+
+func (x *DeletePatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePatientRequest.ProtoReflect.Descriptor instead.
+func (*DeletePatientRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeletePatientRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+type DeletePatientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePatientResponse) Reset() {
+	*x = DeletePatientResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePatientResponse) ProtoMessage() {}
+
+func (x *DeletePatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePatientResponse.ProtoReflect.Descriptor instead.
+func (*DeletePatientResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{19}
+}
+
+type ListMedicalRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMedicalRecordsRequest) Reset() {
+	*x = ListMedicalRecordsRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMedicalRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMedicalRecordsRequest) ProtoMessage() {}
+
+func (x *ListMedicalRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMedicalRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListMedicalRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListMedicalRecordsRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+type ListMedicalRecordsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MedicalRecords []*MedicalRecord       `protobuf:"bytes,1,rep,name=medical_records,json=medicalRecords,proto3" json:"medical_records,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListMedicalRecordsResponse) Reset() {
+	*x = ListMedicalRecordsResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMedicalRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMedicalRecordsResponse) ProtoMessage() {}
+
+func (x *ListMedicalRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMedicalRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListMedicalRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListMedicalRecordsResponse) GetMedicalRecords() []*MedicalRecord {
+	if x != nil {
+		return x.MedicalRecords
+	}
+	return nil
+}
+
+type CreateMedicalRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	MedicalRecord *MedicalRecord         `protobuf:"bytes,2,opt,name=medical_record,json=medicalRecord,proto3" json:"medical_record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMedicalRecordRequest) Reset() {
+	*x = CreateMedicalRecordRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMedicalRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMedicalRecordRequest) ProtoMessage() {}
+
+func (x *CreateMedicalRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMedicalRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateMedicalRecordRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateMedicalRecordRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *CreateMedicalRecordRequest) GetMedicalRecord() *MedicalRecord {
+	if x != nil {
+		return x.MedicalRecord
+	}
+	return nil
+}
+
+type CreateMedicalRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MedicalRecord *MedicalRecord         `protobuf:"bytes,1,opt,name=medical_record,json=medicalRecord,proto3" json:"medical_record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMedicalRecordResponse) Reset() {
+	*x = CreateMedicalRecordResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMedicalRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMedicalRecordResponse) ProtoMessage() {}
+
+func (x *CreateMedicalRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMedicalRecordResponse.ProtoReflect.Descriptor instead.
+func (*CreateMedicalRecordResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateMedicalRecordResponse) GetMedicalRecord() *MedicalRecord {
+	if x != nil {
+		return x.MedicalRecord
+	}
+	return nil
+}
+
+type UpdateMedicalRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	RecordId      string                 `protobuf:"bytes,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	MedicalRecord *MedicalRecord         `protobuf:"bytes,3,opt,name=medical_record,json=medicalRecord,proto3" json:"medical_record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMedicalRecordRequest) Reset() {
+	*x = UpdateMedicalRecordRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMedicalRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMedicalRecordRequest) ProtoMessage() {}
+
+func (x *UpdateMedicalRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMedicalRecordRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMedicalRecordRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateMedicalRecordRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *UpdateMedicalRecordRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+func (x *UpdateMedicalRecordRequest) GetMedicalRecord() *MedicalRecord {
+	if x != nil {
+		return x.MedicalRecord
+	}
+	return nil
+}
+
+type UpdateMedicalRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MedicalRecord *MedicalRecord         `protobuf:"bytes,1,opt,name=medical_record,json=medicalRecord,proto3" json:"medical_record,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMedicalRecordResponse) Reset() {
+	*x = UpdateMedicalRecordResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMedicalRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMedicalRecordResponse) ProtoMessage() {}
+
+func (x *UpdateMedicalRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMedicalRecordResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMedicalRecordResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateMedicalRecordResponse) GetMedicalRecord() *MedicalRecord {
+	if x != nil {
+		return x.MedicalRecord
+	}
+	return nil
+}
+
+type DeleteMedicalRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	RecordId      string                 `protobuf:"bytes,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMedicalRecordRequest) Reset() {
+	*x = DeleteMedicalRecordRequest{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMedicalRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMedicalRecordRequest) ProtoMessage() {}
+
+func (x *DeleteMedicalRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMedicalRecordRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMedicalRecordRequest) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteMedicalRecordRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *DeleteMedicalRecordRequest) GetRecordId() string {
+	if x != nil {
+		return x.RecordId
+	}
+	return ""
+}
+
+type DeleteMedicalRecordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMedicalRecordResponse) Reset() {
+	*x = DeleteMedicalRecordResponse{}
+	mi := &file_mdm_v1_mdm_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMedicalRecordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMedicalRecordResponse) ProtoMessage() {}
+
+func (x *DeleteMedicalRecordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mdm_v1_mdm_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMedicalRecordResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMedicalRecordResponse) Descriptor() ([]byte, []int) {
+	return file_mdm_v1_mdm_proto_rawDescGZIP(), []int{27}
+}
+
+var File_mdm_v1_mdm_proto protoreflect.FileDescriptor
+
+const file_mdm_v1_mdm_proto_rawDesc = "" +
+	"\n" +
+	"\x10mdm/v1/mdm.proto\x12\x06mdm.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"p\n" +
+	"\aAddress\x12\x16\n" +
+	"\x06street\x18\x01 \x01(\tR\x06street\x12\x12\n" +
+	"\x04city\x18\x02 \x01(\tR\x04city\x12\x1f\n" +
+	"\vpostal_code\x18\x03 \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\x04 \x01(\tR\acountry\"m\n" +
+	"\x10EmergencyContact\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\frelationship\x18\x02 \x01(\tR\frelationship\x12!\n" +
+	"\fphone_number\x18\x03 \x01(\tR\vphoneNumber\"{\n" +
+	"\aAllergy\x12\x1c\n" +
+	"\tsubstance\x18\x01 \x01(\tR\tsubstance\x12\x1a\n" +
+	"\breaction\x18\x02 \x01(\tR\breaction\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bverified\x18\x04 \x01(\bR\bverified\"\xb5\x04\n" +
+	"\aPatient\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\"\n" +
+	"\rdate_of_birth\x18\x04 \x01(\tR\vdateOfBirth\x12\x16\n" +
+	"\x06gender\x18\x05 \x01(\tR\x06gender\x12)\n" +
+	"\x10insurance_number\x18\x06 \x01(\tR\x0finsuranceNumber\x12\x1d\n" +
+	"\n" +
+	"blood_type\x18\a \x01(\tR\tbloodType\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
+	"\rmedical_notes\x18\n" +
+	" \x01(\tR\fmedicalNotes\x12)\n" +
+	"\aaddress\x18\v \x01(\v2\x0f.mdm.v1.AddressR\aaddress\x12E\n" +
+	"\x11emergency_contact\x18\f \x01(\v2\x18.mdm.v1.EmergencyContactR\x10emergencyContact\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12-\n" +
+	"\tallergies\x18\x0f \x03(\v2\x0f.mdm.v1.AllergyR\tallergiesJ\x04\b\t\x10\n" +
+	"\"\x80\x02\n" +
+	"\n" +
+	"Medication\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06dosage\x18\x02 \x01(\tR\x06dosage\x12\x1c\n" +
+	"\tfrequency\x18\x03 \x01(\tR\tfrequency\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\tR\bduration\x12\x19\n" +
+	"\batc_code\x18\x05 \x01(\tR\aatcCode\x12\x0e\n" +
+	"\x02id\x18\x06 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\a \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\b \x01(\tR\aendDate\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06statusJ\x04\b\n" +
+	"\x10\vR\tunchecked\"\xd4\x01\n" +
+	"\x18MedicationReconciliation\x12#\n" +
+	"\rmedication_id\x18\x01 \x01(\tR\fmedicationId\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\tR\brecordId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x122\n" +
+	"\n" +
+	"medication\x18\x05 \x01(\v2\x12.mdm.v1.MedicationR\n" +
+	"medication\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\"N\n" +
+	"\x0eCodedDiagnosis\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\adisplay\x18\x02 \x01(\tR\adisplayJ\x04\b\x03\x10\x04R\bunlisted\"\x86\x01\n" +
+	"\x0eAllergyWarning\x12\x1e\n" +
+	"\n" +
+	"medication\x18\x01 \x01(\tR\n" +
+	"medication\x12\x1c\n" +
+	"\tsubstance\x18\x02 \x01(\tR\tsubstance\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x1a\n" +
+	"\bverified\x18\x04 \x01(\bR\bverified\"\xa1\x02\n" +
+	"\x0fDrugInteraction\x12\x1e\n" +
+	"\n" +
+	"medication\x18\x01 \x01(\tR\n" +
+	"medication\x12\x19\n" +
+	"\batc_code\x18\x02 \x01(\tR\aatcCode\x12%\n" +
+	"\x0einteracts_with\x18\x03 \x01(\tR\rinteractsWith\x125\n" +
+	"\x17interacts_with_atc_code\x18\x04 \x01(\tR\x14interactsWithAtcCode\x127\n" +
+	"\x18interacts_with_record_id\x18\x05 \x01(\tR\x15interactsWithRecordId\x12\x1a\n" +
+	"\bseverity\x18\x06 \x01(\tR\bseverity\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xe4\x06\n" +
+	"\rMedicalRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x02 \x01(\tR\tpatientId\x12>\n" +
+	"\rdate_of_visit\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vdateOfVisit\x12\x1c\n" +
+	"\tdiagnosis\x18\x04 \x01(\tR\tdiagnosis\x12\x1a\n" +
+	"\bsymptoms\x18\x05 \x03(\tR\bsymptoms\x12\x1c\n" +
+	"\ttreatment\x18\x06 \x01(\tR\ttreatment\x124\n" +
+	"\vmedications\x18\a \x03(\v2\x12.mdm.v1.MedicationR\vmedications\x12\x1f\n" +
+	"\vdoctor_name\x18\b \x01(\tR\n" +
+	"doctorName\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x12$\n" +
+	"\x0efollow_up_date\x18\n" +
+	" \x01(\tR\ffollowUpDate\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12C\n" +
+	"\x11primary_diagnosis\x18\r \x01(\v2\x16.mdm.v1.CodedDiagnosisR\x10primaryDiagnosis\x12G\n" +
+	"\x13secondary_diagnoses\x18\x0e \x03(\v2\x16.mdm.v1.CodedDiagnosisR\x12secondaryDiagnoses\x12)\n" +
+	"\x10allergy_override\x18\x0f \x01(\bR\x0fallergyOverride\x12A\n" +
+	"\x10allergy_warnings\x18\x10 \x03(\v2\x16.mdm.v1.AllergyWarningR\x0fallergyWarnings\x12;\n" +
+	"\finteractions\x18\x11 \x03(\v2\x17.mdm.v1.DrugInteractionR\finteractions\x12J\n" +
+	"\x0freconciliations\x18\x12 \x03(\v2 .mdm.v1.MedicationReconciliationR\x0freconciliations\"\x15\n" +
+	"\x13ListPatientsRequest\"C\n" +
+	"\x14ListPatientsResponse\x12+\n" +
+	"\bpatients\x18\x01 \x03(\v2\x0f.mdm.v1.PatientR\bpatients\"2\n" +
+	"\x11GetPatientRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\"?\n" +
+	"\x12GetPatientResponse\x12)\n" +
+	"\apatient\x18\x01 \x01(\v2\x0f.mdm.v1.PatientR\apatient\"A\n" +
+	"\x14CreatePatientRequest\x12)\n" +
+	"\apatient\x18\x01 \x01(\v2\x0f.mdm.v1.PatientR\apatient\"B\n" +
+	"\x15CreatePatientResponse\x12)\n" +
+	"\apatient\x18\x01 \x01(\v2\x0f.mdm.v1.PatientR\apatient\"`\n" +
+	"\x14UpdatePatientRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\x12)\n" +
+	"\apatient\x18\x02 \x01(\v2\x0f.mdm.v1.PatientR\apatient\"B\n" +
+	"\x15UpdatePatientResponse\x12)\n" +
+	"\apatient\x18\x01 \x01(\v2\x0f.mdm.v1.PatientR\apatient\"5\n" +
+	"\x14DeletePatientRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\"\x17\n" +
+	"\x15DeletePatientResponse\":\n" +
+	"\x19ListMedicalRecordsRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\"\\\n" +
+	"\x1aListMedicalRecordsResponse\x12>\n" +
+	"\x0fmedical_records\x18\x01 \x03(\v2\x15.mdm.v1.MedicalRecordR\x0emedicalRecords\"y\n" +
+	"\x1aCreateMedicalRecordRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\x12<\n" +
+	"\x0emedical_record\x18\x02 \x01(\v2\x15.mdm.v1.MedicalRecordR\rmedicalRecord\"[\n" +
+	"\x1bCreateMedicalRecordResponse\x12<\n" +
+	"\x0emedical_record\x18\x01 \x01(\v2\x15.mdm.v1.MedicalRecordR\rmedicalRecord\"\x96\x01\n" +
+	"\x1aUpdateMedicalRecordRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\tR\brecordId\x12<\n" +
+	"\x0emedical_record\x18\x03 \x01(\v2\x15.mdm.v1.MedicalRecordR\rmedicalRecord\"[\n" +
+	"\x1bUpdateMedicalRecordResponse\x12<\n" +
+	"\x0emedical_record\x18\x01 \x01(\v2\x15.mdm.v1.MedicalRecordR\rmedicalRecord\"X\n" +
+	"\x1aDeleteMedicalRecordRequest\x12\x1d\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tR\tpatientId\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\tR\brecordId\"\x1d\n" +
+	"\x1bDeleteMedicalRecordResponse2\x8b\x03\n" +
+	"\x0fPatientsService\x12I\n" +
+	"\fListPatients\x12\x1b.mdm.v1.ListPatientsRequest\x1a\x1c.mdm.v1.ListPatientsResponse\x12C\n" +
+	"\n" +
+	"GetPatient\x12\x19.mdm.v1.GetPatientRequest\x1a\x1a.mdm.v1.GetPatientResponse\x12L\n" +
+	"\rCreatePatient\x12\x1c.mdm.v1.CreatePatientRequest\x1a\x1d.mdm.v1.CreatePatientResponse\x12L\n" +
+	"\rUpdatePatient\x12\x1c.mdm.v1.UpdatePatientRequest\x1a\x1d.mdm.v1.UpdatePatientResponse\x12L\n" +
+	"\rDeletePatient\x12\x1c.mdm.v1.DeletePatientRequest\x1a\x1d.mdm.v1.DeletePatientResponse2\x94\x03\n" +
+	"\x15MedicalRecordsService\x12[\n" +
+	"\x12ListMedicalRecords\x12!.mdm.v1.ListMedicalRecordsRequest\x1a\".mdm.v1.ListMedicalRecordsResponse\x12^\n" +
+	"\x13CreateMedicalRecord\x12\".mdm.v1.CreateMedicalRecordRequest\x1a#.mdm.v1.CreateMedicalRecordResponse\x12^\n" +
+	"\x13UpdateMedicalRecord\x12\".mdm.v1.UpdateMedicalRecordRequest\x1a#.mdm.v1.UpdateMedicalRecordResponse\x12^\n" +
+	"\x13DeleteMedicalRecord\x12\".mdm.v1.DeleteMedicalRecordRequest\x1a#.mdm.v1.DeleteMedicalRecordResponseB1Z/github.com/samsvi/mdm-webapi/pkg/mdmpb/v1;mdmpbb\x06proto3"
+
+var (
+	file_mdm_v1_mdm_proto_rawDescOnce sync.Once
+	file_mdm_v1_mdm_proto_rawDescData []byte
+)
+
+func file_mdm_v1_mdm_proto_rawDescGZIP() []byte {
+	file_mdm_v1_mdm_proto_rawDescOnce.Do(func() {
+		file_mdm_v1_mdm_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mdm_v1_mdm_proto_rawDesc), len(file_mdm_v1_mdm_proto_rawDesc)))
+	})
+	return file_mdm_v1_mdm_proto_rawDescData
+}
+
+var file_mdm_v1_mdm_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_mdm_v1_mdm_proto_goTypes = []any{
+	(*Address)(nil),                     // 0: mdm.v1.Address
+	(*EmergencyContact)(nil),            // 1: mdm.v1.EmergencyContact
+	(*Allergy)(nil),                     // 2: mdm.v1.Allergy
+	(*Patient)(nil),                     // 3: mdm.v1.Patient
+	(*Medication)(nil),                  // 4: mdm.v1.Medication
+	(*MedicationReconciliation)(nil),    // 5: mdm.v1.MedicationReconciliation
+	(*CodedDiagnosis)(nil),              // 6: mdm.v1.CodedDiagnosis
+	(*AllergyWarning)(nil),              // 7: mdm.v1.AllergyWarning
+	(*DrugInteraction)(nil),             // 8: mdm.v1.DrugInteraction
+	(*MedicalRecord)(nil),               // 9: mdm.v1.MedicalRecord
+	(*ListPatientsRequest)(nil),         // 10: mdm.v1.ListPatientsRequest
+	(*ListPatientsResponse)(nil),        // 11: mdm.v1.ListPatientsResponse
+	(*GetPatientRequest)(nil),           // 12: mdm.v1.GetPatientRequest
+	(*GetPatientResponse)(nil),          // 13: mdm.v1.GetPatientResponse
+	(*CreatePatientRequest)(nil),        // 14: mdm.v1.CreatePatientRequest
+	(*CreatePatientResponse)(nil),       // 15: mdm.v1.CreatePatientResponse
+	(*UpdatePatientRequest)(nil),        // 16: mdm.v1.UpdatePatientRequest
+	(*UpdatePatientResponse)(nil),       // 17: mdm.v1.UpdatePatientResponse
+	(*DeletePatientRequest)(nil),        // 18: mdm.v1.DeletePatientRequest
+	(*DeletePatientResponse)(nil),       // 19: mdm.v1.DeletePatientResponse
+	(*ListMedicalRecordsRequest)(nil),   // 20: mdm.v1.ListMedicalRecordsRequest
+	(*ListMedicalRecordsResponse)(nil),  // 21: mdm.v1.ListMedicalRecordsResponse
+	(*CreateMedicalRecordRequest)(nil),  // 22: mdm.v1.CreateMedicalRecordRequest
+	(*CreateMedicalRecordResponse)(nil), // 23: mdm.v1.CreateMedicalRecordResponse
+	(*UpdateMedicalRecordRequest)(nil),  // 24: mdm.v1.UpdateMedicalRecordRequest
+	(*UpdateMedicalRecordResponse)(nil), // 25: mdm.v1.UpdateMedicalRecordResponse
+	(*DeleteMedicalRecordRequest)(nil),  // 26: mdm.v1.DeleteMedicalRecordRequest
+	(*DeleteMedicalRecordResponse)(nil), // 27: mdm.v1.DeleteMedicalRecordResponse
+	(*timestamppb.Timestamp)(nil),       // 28: google.protobuf.Timestamp
+}
+var file_mdm_v1_mdm_proto_depIdxs = []int32{
+	0,  // 0: mdm.v1.Patient.address:type_name -> mdm.v1.Address
+	1,  // 1: mdm.v1.Patient.emergency_contact:type_name -> mdm.v1.EmergencyContact
+	28, // 2: mdm.v1.Patient.created_at:type_name -> google.protobuf.Timestamp
+	28, // 3: mdm.v1.Patient.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 4: mdm.v1.Patient.allergies:type_name -> mdm.v1.Allergy
+	4,  // 5: mdm.v1.MedicationReconciliation.medication:type_name -> mdm.v1.Medication
+	28, // 6: mdm.v1.MedicalRecord.date_of_visit:type_name -> google.protobuf.Timestamp
+	4,  // 7: mdm.v1.MedicalRecord.medications:type_name -> mdm.v1.Medication
+	28, // 8: mdm.v1.MedicalRecord.created_at:type_name -> google.protobuf.Timestamp
+	28, // 9: mdm.v1.MedicalRecord.updated_at:type_name -> google.protobuf.Timestamp
+	6,  // 10: mdm.v1.MedicalRecord.primary_diagnosis:type_name -> mdm.v1.CodedDiagnosis
+	6,  // 11: mdm.v1.MedicalRecord.secondary_diagnoses:type_name -> mdm.v1.CodedDiagnosis
+	7,  // 12: mdm.v1.MedicalRecord.allergy_warnings:type_name -> mdm.v1.AllergyWarning
+	8,  // 13: mdm.v1.MedicalRecord.interactions:type_name -> mdm.v1.DrugInteraction
+	5,  // 14: mdm.v1.MedicalRecord.reconciliations:type_name -> mdm.v1.MedicationReconciliation
+	3,  // 15: mdm.v1.ListPatientsResponse.patients:type_name -> mdm.v1.Patient
+	3,  // 16: mdm.v1.GetPatientResponse.patient:type_name -> mdm.v1.Patient
+	3,  // 17: mdm.v1.CreatePatientRequest.patient:type_name -> mdm.v1.Patient
+	3,  // 18: mdm.v1.CreatePatientResponse.patient:type_name -> mdm.v1.Patient
+	3,  // 19: mdm.v1.UpdatePatientRequest.patient:type_name -> mdm.v1.Patient
+	3,  // 20: mdm.v1.UpdatePatientResponse.patient:type_name -> mdm.v1.Patient
+	9,  // 21: mdm.v1.ListMedicalRecordsResponse.medical_records:type_name -> mdm.v1.MedicalRecord
+	9,  // 22: mdm.v1.CreateMedicalRecordRequest.medical_record:type_name -> mdm.v1.MedicalRecord
+	9,  // 23: mdm.v1.CreateMedicalRecordResponse.medical_record:type_name -> mdm.v1.MedicalRecord
+	9,  // 24: mdm.v1.UpdateMedicalRecordRequest.medical_record:type_name -> mdm.v1.MedicalRecord
+	9,  // 25: mdm.v1.UpdateMedicalRecordResponse.medical_record:type_name -> mdm.v1.MedicalRecord
+	10, // 26: mdm.v1.PatientsService.ListPatients:input_type -> mdm.v1.ListPatientsRequest
+	12, // 27: mdm.v1.PatientsService.GetPatient:input_type -> mdm.v1.GetPatientRequest
+	14, // 28: mdm.v1.PatientsService.CreatePatient:input_type -> mdm.v1.CreatePatientRequest
+	16, // 29: mdm.v1.PatientsService.UpdatePatient:input_type -> mdm.v1.UpdatePatientRequest
+	18, // 30: mdm.v1.PatientsService.DeletePatient:input_type -> mdm.v1.DeletePatientRequest
+	20, // 31: mdm.v1.MedicalRecordsService.ListMedicalRecords:input_type -> mdm.v1.ListMedicalRecordsRequest
+	22, // 32: mdm.v1.MedicalRecordsService.CreateMedicalRecord:input_type -> mdm.v1.CreateMedicalRecordRequest
+	24, // 33: mdm.v1.MedicalRecordsService.UpdateMedicalRecord:input_type -> mdm.v1.UpdateMedicalRecordRequest
+	26, // 34: mdm.v1.MedicalRecordsService.DeleteMedicalRecord:input_type -> mdm.v1.DeleteMedicalRecordRequest
+	11, // 35: mdm.v1.PatientsService.ListPatients:output_type -> mdm.v1.ListPatientsResponse
+	13, // 36: mdm.v1.PatientsService.GetPatient:output_type -> mdm.v1.GetPatientResponse
+	15, // 37: mdm.v1.PatientsService.CreatePatient:output_type -> mdm.v1.CreatePatientResponse
+	17, // 38: mdm.v1.PatientsService.UpdatePatient:output_type -> mdm.v1.UpdatePatientResponse
+	19, // 39: mdm.v1.PatientsService.DeletePatient:output_type -> mdm.v1.DeletePatientResponse
+	21, // 40: mdm.v1.MedicalRecordsService.ListMedicalRecords:output_type -> mdm.v1.ListMedicalRecordsResponse
+	23, // 41: mdm.v1.MedicalRecordsService.CreateMedicalRecord:output_type -> mdm.v1.CreateMedicalRecordResponse
+	25, // 42: mdm.v1.MedicalRecordsService.UpdateMedicalRecord:output_type -> mdm.v1.UpdateMedicalRecordResponse
+	27, // 43: mdm.v1.MedicalRecordsService.DeleteMedicalRecord:output_type -> mdm.v1.DeleteMedicalRecordResponse
+	35, // [35:44] is the sub-list for method output_type
+	26, // [26:35] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_mdm_v1_mdm_proto_init() }
+func file_mdm_v1_mdm_proto_init() {
+	if File_mdm_v1_mdm_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mdm_v1_mdm_proto_rawDesc), len(file_mdm_v1_mdm_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_mdm_v1_mdm_proto_goTypes,
+		DependencyIndexes: file_mdm_v1_mdm_proto_depIdxs,
+		MessageInfos:      file_mdm_v1_mdm_proto_msgTypes,
+	}.Build()
+	File_mdm_v1_mdm_proto = out.File
+	file_mdm_v1_mdm_proto_goTypes = nil
+	file_mdm_v1_mdm_proto_depIdxs = nil
+}
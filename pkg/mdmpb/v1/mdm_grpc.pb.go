@@ -0,0 +1,510 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: mdm/v1/mdm.proto
+
+// Patient and Medical Records management for Web-In-Cloud system.
+// Messages mirror schemas of api/mdm.openapi.yaml.
+
+package mdmpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PatientsService_ListPatients_FullMethodName  = "/mdm.v1.PatientsService/ListPatients"
+	PatientsService_GetPatient_FullMethodName    = "/mdm.v1.PatientsService/GetPatient"
+	PatientsService_CreatePatient_FullMethodName = "/mdm.v1.PatientsService/CreatePatient"
+	PatientsService_UpdatePatient_FullMethodName = "/mdm.v1.PatientsService/UpdatePatient"
+	PatientsService_DeletePatient_FullMethodName = "/mdm.v1.PatientsService/DeletePatient"
+)
+
+// PatientsServiceClient is the client API for PatientsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PatientsServiceClient interface {
+	// Provides list of all patients
+	ListPatients(ctx context.Context, in *ListPatientsRequest, opts ...grpc.CallOption) (*ListPatientsResponse, error)
+	// Provides details about specific patient
+	GetPatient(ctx context.Context, in *GetPatientRequest, opts ...grpc.CallOption) (*GetPatientResponse, error)
+	// Creates a new patient
+	CreatePatient(ctx context.Context, in *CreatePatientRequest, opts ...grpc.CallOption) (*CreatePatientResponse, error)
+	// Updates specific patient
+	UpdatePatient(ctx context.Context, in *UpdatePatientRequest, opts ...grpc.CallOption) (*UpdatePatientResponse, error)
+	// Deletes specific patient
+	DeletePatient(ctx context.Context, in *DeletePatientRequest, opts ...grpc.CallOption) (*DeletePatientResponse, error)
+}
+
+type patientsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPatientsServiceClient(cc grpc.ClientConnInterface) PatientsServiceClient {
+	return &patientsServiceClient{cc}
+}
+
+func (c *patientsServiceClient) ListPatients(ctx context.Context, in *ListPatientsRequest, opts ...grpc.CallOption) (*ListPatientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPatientsResponse)
+	err := c.cc.Invoke(ctx, PatientsService_ListPatients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientsServiceClient) GetPatient(ctx context.Context, in *GetPatientRequest, opts ...grpc.CallOption) (*GetPatientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPatientResponse)
+	err := c.cc.Invoke(ctx, PatientsService_GetPatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientsServiceClient) CreatePatient(ctx context.Context, in *CreatePatientRequest, opts ...grpc.CallOption) (*CreatePatientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePatientResponse)
+	err := c.cc.Invoke(ctx, PatientsService_CreatePatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientsServiceClient) UpdatePatient(ctx context.Context, in *UpdatePatientRequest, opts ...grpc.CallOption) (*UpdatePatientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdatePatientResponse)
+	err := c.cc.Invoke(ctx, PatientsService_UpdatePatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientsServiceClient) DeletePatient(ctx context.Context, in *DeletePatientRequest, opts ...grpc.CallOption) (*DeletePatientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePatientResponse)
+	err := c.cc.Invoke(ctx, PatientsService_DeletePatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatientsServiceServer is the server API for PatientsService service.
+// All implementations must embed UnimplementedPatientsServiceServer
+// for forward compatibility.
+type PatientsServiceServer interface {
+	// Provides list of all patients
+	ListPatients(context.Context, *ListPatientsRequest) (*ListPatientsResponse, error)
+	// Provides details about specific patient
+	GetPatient(context.Context, *GetPatientRequest) (*GetPatientResponse, error)
+	// Creates a new patient
+	CreatePatient(context.Context, *CreatePatientRequest) (*CreatePatientResponse, error)
+	// Updates specific patient
+	UpdatePatient(context.Context, *UpdatePatientRequest) (*UpdatePatientResponse, error)
+	// Deletes specific patient
+	DeletePatient(context.Context, *DeletePatientRequest) (*DeletePatientResponse, error)
+	mustEmbedUnimplementedPatientsServiceServer()
+}
+
+// UnimplementedPatientsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPatientsServiceServer struct{}
+
+func (UnimplementedPatientsServiceServer) ListPatients(context.Context, *ListPatientsRequest) (*ListPatientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPatients not implemented")
+}
+func (UnimplementedPatientsServiceServer) GetPatient(context.Context, *GetPatientRequest) (*GetPatientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPatient not implemented")
+}
+func (UnimplementedPatientsServiceServer) CreatePatient(context.Context, *CreatePatientRequest) (*CreatePatientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePatient not implemented")
+}
+func (UnimplementedPatientsServiceServer) UpdatePatient(context.Context, *UpdatePatientRequest) (*UpdatePatientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePatient not implemented")
+}
+func (UnimplementedPatientsServiceServer) DeletePatient(context.Context, *DeletePatientRequest) (*DeletePatientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePatient not implemented")
+}
+func (UnimplementedPatientsServiceServer) mustEmbedUnimplementedPatientsServiceServer() {}
+func (UnimplementedPatientsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafePatientsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PatientsServiceServer will
+// result in compilation errors.
+type UnsafePatientsServiceServer interface {
+	mustEmbedUnimplementedPatientsServiceServer()
+}
+
+func RegisterPatientsServiceServer(s grpc.ServiceRegistrar, srv PatientsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPatientsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PatientsService_ServiceDesc, srv)
+}
+
+func _PatientsService_ListPatients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPatientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientsServiceServer).ListPatients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientsService_ListPatients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientsServiceServer).ListPatients(ctx, req.(*ListPatientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientsService_GetPatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientsServiceServer).GetPatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientsService_GetPatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientsServiceServer).GetPatient(ctx, req.(*GetPatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientsService_CreatePatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientsServiceServer).CreatePatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientsService_CreatePatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientsServiceServer).CreatePatient(ctx, req.(*CreatePatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientsService_UpdatePatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientsServiceServer).UpdatePatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientsService_UpdatePatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientsServiceServer).UpdatePatient(ctx, req.(*UpdatePatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientsService_DeletePatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientsServiceServer).DeletePatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientsService_DeletePatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientsServiceServer).DeletePatient(ctx, req.(*DeletePatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PatientsService_ServiceDesc is the grpc.ServiceDesc for PatientsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PatientsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mdm.v1.PatientsService",
+	HandlerType: (*PatientsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPatients",
+			Handler:    _PatientsService_ListPatients_Handler,
+		},
+		{
+			MethodName: "GetPatient",
+			Handler:    _PatientsService_GetPatient_Handler,
+		},
+		{
+			MethodName: "CreatePatient",
+			Handler:    _PatientsService_CreatePatient_Handler,
+		},
+		{
+			MethodName: "UpdatePatient",
+			Handler:    _PatientsService_UpdatePatient_Handler,
+		},
+		{
+			MethodName: "DeletePatient",
+			Handler:    _PatientsService_DeletePatient_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mdm/v1/mdm.proto",
+}
+
+const (
+	MedicalRecordsService_ListMedicalRecords_FullMethodName  = "/mdm.v1.MedicalRecordsService/ListMedicalRecords"
+	MedicalRecordsService_CreateMedicalRecord_FullMethodName = "/mdm.v1.MedicalRecordsService/CreateMedicalRecord"
+	MedicalRecordsService_UpdateMedicalRecord_FullMethodName = "/mdm.v1.MedicalRecordsService/UpdateMedicalRecord"
+	MedicalRecordsService_DeleteMedicalRecord_FullMethodName = "/mdm.v1.MedicalRecordsService/DeleteMedicalRecord"
+)
+
+// MedicalRecordsServiceClient is the client API for MedicalRecordsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MedicalRecordsServiceClient interface {
+	// Provides all medical records for specific patient
+	ListMedicalRecords(ctx context.Context, in *ListMedicalRecordsRequest, opts ...grpc.CallOption) (*ListMedicalRecordsResponse, error)
+	// Creates new medical record for patient
+	CreateMedicalRecord(ctx context.Context, in *CreateMedicalRecordRequest, opts ...grpc.CallOption) (*CreateMedicalRecordResponse, error)
+	// Updates specific medical record
+	UpdateMedicalRecord(ctx context.Context, in *UpdateMedicalRecordRequest, opts ...grpc.CallOption) (*UpdateMedicalRecordResponse, error)
+	// Deletes specific medical record
+	DeleteMedicalRecord(ctx context.Context, in *DeleteMedicalRecordRequest, opts ...grpc.CallOption) (*DeleteMedicalRecordResponse, error)
+}
+
+type medicalRecordsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMedicalRecordsServiceClient(cc grpc.ClientConnInterface) MedicalRecordsServiceClient {
+	return &medicalRecordsServiceClient{cc}
+}
+
+func (c *medicalRecordsServiceClient) ListMedicalRecords(ctx context.Context, in *ListMedicalRecordsRequest, opts ...grpc.CallOption) (*ListMedicalRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMedicalRecordsResponse)
+	err := c.cc.Invoke(ctx, MedicalRecordsService_ListMedicalRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medicalRecordsServiceClient) CreateMedicalRecord(ctx context.Context, in *CreateMedicalRecordRequest, opts ...grpc.CallOption) (*CreateMedicalRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateMedicalRecordResponse)
+	err := c.cc.Invoke(ctx, MedicalRecordsService_CreateMedicalRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medicalRecordsServiceClient) UpdateMedicalRecord(ctx context.Context, in *UpdateMedicalRecordRequest, opts ...grpc.CallOption) (*UpdateMedicalRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateMedicalRecordResponse)
+	err := c.cc.Invoke(ctx, MedicalRecordsService_UpdateMedicalRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *medicalRecordsServiceClient) DeleteMedicalRecord(ctx context.Context, in *DeleteMedicalRecordRequest, opts ...grpc.CallOption) (*DeleteMedicalRecordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMedicalRecordResponse)
+	err := c.cc.Invoke(ctx, MedicalRecordsService_DeleteMedicalRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MedicalRecordsServiceServer is the server API for MedicalRecordsService service.
+// All implementations must embed UnimplementedMedicalRecordsServiceServer
+// for forward compatibility.
+type MedicalRecordsServiceServer interface {
+	// Provides all medical records for specific patient
+	ListMedicalRecords(context.Context, *ListMedicalRecordsRequest) (*ListMedicalRecordsResponse, error)
+	// Creates new medical record for patient
+	CreateMedicalRecord(context.Context, *CreateMedicalRecordRequest) (*CreateMedicalRecordResponse, error)
+	// Updates specific medical record
+	UpdateMedicalRecord(context.Context, *UpdateMedicalRecordRequest) (*UpdateMedicalRecordResponse, error)
+	// Deletes specific medical record
+	DeleteMedicalRecord(context.Context, *DeleteMedicalRecordRequest) (*DeleteMedicalRecordResponse, error)
+	mustEmbedUnimplementedMedicalRecordsServiceServer()
+}
+
+// UnimplementedMedicalRecordsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMedicalRecordsServiceServer struct{}
+
+func (UnimplementedMedicalRecordsServiceServer) ListMedicalRecords(context.Context, *ListMedicalRecordsRequest) (*ListMedicalRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMedicalRecords not implemented")
+}
+func (UnimplementedMedicalRecordsServiceServer) CreateMedicalRecord(context.Context, *CreateMedicalRecordRequest) (*CreateMedicalRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMedicalRecord not implemented")
+}
+func (UnimplementedMedicalRecordsServiceServer) UpdateMedicalRecord(context.Context, *UpdateMedicalRecordRequest) (*UpdateMedicalRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMedicalRecord not implemented")
+}
+func (UnimplementedMedicalRecordsServiceServer) DeleteMedicalRecord(context.Context, *DeleteMedicalRecordRequest) (*DeleteMedicalRecordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMedicalRecord not implemented")
+}
+func (UnimplementedMedicalRecordsServiceServer) mustEmbedUnimplementedMedicalRecordsServiceServer() {}
+func (UnimplementedMedicalRecordsServiceServer) testEmbeddedByValue()                               {}
+
+// UnsafeMedicalRecordsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MedicalRecordsServiceServer will
+// result in compilation errors.
+type UnsafeMedicalRecordsServiceServer interface {
+	mustEmbedUnimplementedMedicalRecordsServiceServer()
+}
+
+func RegisterMedicalRecordsServiceServer(s grpc.ServiceRegistrar, srv MedicalRecordsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMedicalRecordsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MedicalRecordsService_ServiceDesc, srv)
+}
+
+func _MedicalRecordsService_ListMedicalRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMedicalRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicalRecordsServiceServer).ListMedicalRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicalRecordsService_ListMedicalRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicalRecordsServiceServer).ListMedicalRecords(ctx, req.(*ListMedicalRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedicalRecordsService_CreateMedicalRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMedicalRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicalRecordsServiceServer).CreateMedicalRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicalRecordsService_CreateMedicalRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicalRecordsServiceServer).CreateMedicalRecord(ctx, req.(*CreateMedicalRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedicalRecordsService_UpdateMedicalRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMedicalRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicalRecordsServiceServer).UpdateMedicalRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicalRecordsService_UpdateMedicalRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicalRecordsServiceServer).UpdateMedicalRecord(ctx, req.(*UpdateMedicalRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MedicalRecordsService_DeleteMedicalRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMedicalRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicalRecordsServiceServer).DeleteMedicalRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicalRecordsService_DeleteMedicalRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicalRecordsServiceServer).DeleteMedicalRecord(ctx, req.(*DeleteMedicalRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MedicalRecordsService_ServiceDesc is the grpc.ServiceDesc for MedicalRecordsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MedicalRecordsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mdm.v1.MedicalRecordsService",
+	HandlerType: (*MedicalRecordsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListMedicalRecords",
+			Handler:    _MedicalRecordsService_ListMedicalRecords_Handler,
+		},
+		{
+			MethodName: "CreateMedicalRecord",
+			Handler:    _MedicalRecordsService_CreateMedicalRecord_Handler,
+		},
+		{
+			MethodName: "UpdateMedicalRecord",
+			Handler:    _MedicalRecordsService_UpdateMedicalRecord_Handler,
+		},
+		{
+			MethodName: "DeleteMedicalRecord",
+			Handler:    _MedicalRecordsService_DeleteMedicalRecord_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mdm/v1/mdm.proto",
+}
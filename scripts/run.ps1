@@ -10,6 +10,7 @@ $ProjectRoot = "${PSScriptRoot}/.."
 
 $env:MDM_API_ENVIRONMENT="Development"
 $env:MDM_API_PORT="8080"
+$env:MDM_API_GRPC_PORT="9090"
 $env:MDM_API_MONGODB_USERNAME="root"
 $env:MDM_API_MONGODB_PASSWORD="neUhaDnes"
 
@@ -35,6 +36,9 @@ switch ($command) {
     "openapi" {
         docker run --rm -ti -v ${ProjectRoot}:/local openapitools/openapi-generator-cli generate -c /local/scripts/generator-cfg.yaml
     }
+    "proto" {
+        docker run --rm -v ${ProjectRoot}:/workspace --workdir /workspace bufbuild/buf generate
+    }
     default {
         throw "Unknown command: $command"
     }